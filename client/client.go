@@ -1,16 +1,22 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"time"
 
 	"github.com/0xPolygon/agglayer/rpc/types"
 	"github.com/0xPolygon/agglayer/tx"
+	aggTypes "github.com/0xPolygon/agglayer/types"
 	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	jsonrpcTypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -23,6 +29,9 @@ type ClientFactoryInterface interface {
 type ClientInterface interface {
 	SendTx(signedTx tx.SignedTx) (common.Hash, error)
 	GetTxStatus(hash common.Hash) (ethtxmanager.MonitoredTxStatus, error)
+	GetTxDetails(hash common.Hash) (aggTypes.TxDetails, error)
+	ListTxs(filter aggTypes.TxListFilter) (aggTypes.TxList, error)
+	GetRollupState(rollupID uint32) (aggTypes.RollupState, error)
 	WaitTxToBeMined(hash common.Hash, ctx context.Context) error
 }
 
@@ -36,7 +45,9 @@ func (f *ClientFactory) New(url string) ClientInterface {
 
 // Client wraps all the available endpoints of the data abailability committee node server
 type Client struct {
-	url string
+	url        string
+	apiKey     string
+	httpClient *http.Client
 }
 
 // New returns a client ready to be used
@@ -46,14 +57,26 @@ func New(url string) *Client {
 	}
 }
 
+// WithAPIKey authenticates the requests with the API key scoped to the rollup of the txs sent
+func (c *Client) WithAPIKey(apiKey string) *Client {
+	c.apiKey = apiKey
+	return c
+}
+
+// WithTLSConfig sends the requests with the client certificate of the TLS config
+func (c *Client) WithTLSConfig(tlsConfig *tls.Config) *Client {
+	c.httpClient = &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	return c
+}
+
 func (c *Client) SendTx(signedTx tx.SignedTx) (common.Hash, error) {
-	response, err := client.JSONRPCCall(c.url, "interop_sendTx", signedTx)
+	response, err := c.call("interop_sendTx", signedTx)
 	if err != nil {
 		return common.Hash{}, err
 	}
 
 	if response.Error != nil {
-		return common.Hash{}, fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+		return common.Hash{}, responseError(response.Error)
 	}
 
 	var result types.ArgHash
@@ -65,14 +88,34 @@ func (c *Client) SendTx(signedTx tx.SignedTx) (common.Hash, error) {
 	return result.Hash(), nil
 }
 
+// SimulateTx runs the checks of the tx and builds the L1 tx that would settle it, without sending it
+func (c *Client) SimulateTx(signedTx tx.SignedTx) (aggTypes.TxSimulation, error) {
+	response, err := c.call("interop_simulateTx", signedTx)
+	if err != nil {
+		return aggTypes.TxSimulation{}, err
+	}
+
+	if response.Error != nil {
+		return aggTypes.TxSimulation{}, responseError(response.Error)
+	}
+
+	var result aggTypes.TxSimulation
+	err = json.Unmarshal(response.Result, &result)
+	if err != nil {
+		return aggTypes.TxSimulation{}, err
+	}
+
+	return result, nil
+}
+
 func (c *Client) GetTxStatus(hash common.Hash) (ethtxmanager.MonitoredTxStatus, error) {
-	response, err := client.JSONRPCCall(c.url, "interop_getTxStatus", hash)
+	response, err := c.call("interop_getTxStatus", hash)
 	if err != nil {
 		return ethtxmanager.MonitoredTxStatus(""), err
 	}
 
 	if response.Error != nil {
-		return ethtxmanager.MonitoredTxStatus(""), fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+		return ethtxmanager.MonitoredTxStatus(""), responseError(response.Error)
 	}
 
 	var result ethtxmanager.MonitoredTxStatus
@@ -84,20 +127,80 @@ func (c *Client) GetTxStatus(hash common.Hash) (ethtxmanager.MonitoredTxStatus,
 	return result, nil
 }
 
+func (c *Client) GetTxDetails(hash common.Hash) (aggTypes.TxDetails, error) {
+	response, err := c.call("interop_getTxDetails", hash)
+	if err != nil {
+		return aggTypes.TxDetails{}, err
+	}
+
+	if response.Error != nil {
+		return aggTypes.TxDetails{}, responseError(response.Error)
+	}
+
+	var result aggTypes.TxDetails
+	err = json.Unmarshal(response.Result, &result)
+	if err != nil {
+		return aggTypes.TxDetails{}, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) ListTxs(filter aggTypes.TxListFilter) (aggTypes.TxList, error) {
+	response, err := c.call("interop_listTxs", filter)
+	if err != nil {
+		return aggTypes.TxList{}, err
+	}
+
+	if response.Error != nil {
+		return aggTypes.TxList{}, responseError(response.Error)
+	}
+
+	var result aggTypes.TxList
+	err = json.Unmarshal(response.Result, &result)
+	if err != nil {
+		return aggTypes.TxList{}, err
+	}
+
+	return result, nil
+}
+
+func (c *Client) GetRollupState(rollupID uint32) (aggTypes.RollupState, error) {
+	response, err := c.call("interop_getRollupState", rollupID)
+	if err != nil {
+		return aggTypes.RollupState{}, err
+	}
+
+	if response.Error != nil {
+		return aggTypes.RollupState{}, responseError(response.Error)
+	}
+
+	var result aggTypes.RollupState
+	err = json.Unmarshal(response.Result, &result)
+	if err != nil {
+		return aggTypes.RollupState{}, err
+	}
+
+	return result, nil
+}
+
+// WaitTxToBeMined polls the status of the tx every second until it's settled, rejected or failed.
+// The client of client/v2 waits for a given target, reporting progress
 func (c *Client) WaitTxToBeMined(hash common.Hash, ctx context.Context) error {
 	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return errors.New("context finished before tx was mined")
 		case <-ticker.C:
-			response, err := client.JSONRPCCall(c.url, "interop_getTxStatus", hash)
+			response, err := c.call("interop_getTxStatus", hash)
 			if err != nil {
 				return err
 			}
 
 			if response.Error != nil {
-				return fmt.Errorf("%v %v", response.Error.Code, response.Error.Message)
+				return responseError(response.Error)
 			}
 
 			var result ethtxmanager.MonitoredTxStatus
@@ -105,9 +208,84 @@ func (c *Client) WaitTxToBeMined(hash common.Hash, ctx context.Context) error {
 			if err != nil {
 				return err
 			}
-			if result == ethtxmanager.MonitoredTxStatusDone {
+			// the eth tx manager leaves the txs settled as confirmed, done is only set by an operator
+			switch result {
+			case ethtxmanager.MonitoredTxStatusConfirmed, ethtxmanager.MonitoredTxStatusDone:
 				return nil
+			case ethtxmanager.MonitoredTxStatusFailed:
+				return errors.New("tx settlement failed on L1")
+			}
+			if string(result) == aggTypes.IntakeTxStatusRejected.String() {
+				return errors.New("tx was rejected by the agglayer")
 			}
 		}
 	}
 }
+
+// call executes a JSON RPC request, with the credentials of the client if any
+func (c *Client) call(method string, parameters ...interface{}) (jsonrpcTypes.Response, error) {
+	if c.apiKey == "" && c.httpClient == nil {
+		return client.JSONRPCCall(c.url, method, parameters...)
+	}
+
+	params, err := json.Marshal(parameters)
+	if err != nil {
+		return jsonrpcTypes.Response{}, err
+	}
+
+	reqBody, err := json.Marshal(jsonrpcTypes.Request{
+		JSONRPC: "2.0",
+		ID:      float64(1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return jsonrpcTypes.Response{}, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(reqBody))
+	if err != nil {
+		return jsonrpcTypes.Response{}, err
+	}
+	httpReq.Header.Add("Content-type", "application/json")
+	if c.apiKey != "" {
+		httpReq.Header.Add("Authorization", "Bearer "+c.apiKey)
+	}
+
+	httpClient := c.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	httpRes, err := httpClient.Do(httpReq)
+	if err != nil {
+		return jsonrpcTypes.Response{}, err
+	}
+	defer httpRes.Body.Close()
+
+	resBody, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return jsonrpcTypes.Response{}, err
+	}
+
+	if httpRes.StatusCode != http.StatusOK {
+		return jsonrpcTypes.Response{}, fmt.Errorf("%v - %v", httpRes.StatusCode, string(resBody))
+	}
+
+	var res jsonrpcTypes.Response
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		return jsonrpcTypes.Response{}, err
+	}
+
+	return res, nil
+}
+
+// responseError returns the error of the response, it matches the sentinel errors of rpc/types by its code
+func responseError(e *jsonrpcTypes.ErrorObject) error {
+	var data []byte
+	if e.Data != nil {
+		data = *e.Data
+	}
+
+	return types.NewError(e.Code, e.Message, data)
+}
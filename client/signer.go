@@ -0,0 +1,36 @@
+package client
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/0xPolygon/agglayer/tx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs txs using the typed data scheme for a given agglayer deployment
+type Signer struct {
+	privateKey *ecdsa.PrivateKey
+	domain     tx.SigningDomain
+}
+
+// NewSigner returns a signer for the agglayer deployed on the given L1 chain and rollup manager
+func NewSigner(privateKey *ecdsa.PrivateKey, l1ChainID uint64, rollupManagerContract common.Address) *Signer {
+	return &Signer{
+		privateKey: privateKey,
+		domain: tx.SigningDomain{
+			L1ChainID:             l1ChainID,
+			RollupManagerContract: rollupManagerContract,
+		},
+	}
+}
+
+// Address returns the address of the signer
+func (s *Signer) Address() common.Address {
+	return crypto.PubkeyToAddress(s.privateKey.PublicKey)
+}
+
+// Sign returns the tx signed using the typed data scheme
+func (s *Signer) Sign(t tx.Tx) (*tx.SignedTx, error) {
+	return t.SignTypedData(s.privateKey, s.domain)
+}
@@ -0,0 +1,333 @@
+// Package client is the context-aware client of the interop endpoints. Every call takes a
+// context, transport failures are retried with an exponential backoff, and the failures
+// are returned as typed errors
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	jsonrpcTypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/0xPolygon/agglayer/rpc/types"
+	"github.com/0xPolygon/agglayer/tx"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	aggTypes "github.com/0xPolygon/agglayer/types"
+)
+
+const (
+	jsonRPCVersion = "2.0"
+
+	// RequestIDHeader carries the ID of the request, the same one as its JSON-RPC ID
+	RequestIDHeader = "X-Request-ID"
+
+	defaultMaxRetries      = 3
+	defaultRetryBackoff    = 100 * time.Millisecond
+	defaultMaxRetryBackoff = 2 * time.Second
+)
+
+var _ ClientInterface = (*Client)(nil)
+
+// ClientInterface is the interface that defines the implementation of all the endpoints
+type ClientInterface interface {
+	SendTx(ctx context.Context, signedTx tx.SignedTx) (common.Hash, error)
+	SimulateTx(ctx context.Context, signedTx tx.SignedTx) (aggTypes.TxSimulation, error)
+	GetTxStatus(ctx context.Context, hash common.Hash) (TxStatus, error)
+	GetTxDetails(ctx context.Context, hash common.Hash) (aggTypes.TxDetails, error)
+	ListTxs(ctx context.Context, filter aggTypes.TxListFilter) (aggTypes.TxList, error)
+	GetRollupState(ctx context.Context, rollupID uint32) (aggTypes.RollupState, error)
+	WaitTx(ctx context.Context, hash common.Hash, target WaitTarget, opts ...WaitOption) (TxStatus, error)
+}
+
+// TxStatus is the status of a tx returned by interop_getTxStatus with the outcome it implies
+type TxStatus struct {
+	// Hash is the tx
+	Hash common.Hash
+	// Status is either the status of the tx in the agglayer, until it's handed over to L1,
+	// or the status of its settlement
+	Status string
+	// Outcome is the outcome of the tx so far, the same one as interop_getTxDetails
+	Outcome aggTypes.TxOutcome
+}
+
+// newTxStatus returns the status of the tx, with its outcome derived as interop_getTxDetails does
+func newTxStatus(hash common.Hash, status string) TxStatus {
+	s := TxStatus{Hash: hash, Status: status, Outcome: aggTypes.TxOutcomePending}
+
+	switch status {
+	case aggTypes.IntakeTxStatusRejected.String():
+		s.Outcome = aggTypes.TxOutcomeRejected
+	case txmTypes.MonitoredTxStatusConfirmed.String(), txmTypes.MonitoredTxStatusDone.String():
+		s.Outcome = aggTypes.TxOutcomeSettled
+	case txmTypes.MonitoredTxStatusFailed.String():
+		s.Outcome = aggTypes.TxOutcomeFailed
+	}
+
+	return s
+}
+
+// HTTPError is a response of the agglayer with a non 200 status
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%v - %v", e.StatusCode, e.Body)
+}
+
+// Option configures the client
+type Option func(*Client)
+
+// WithHTTPClient sends the requests with the given http client, its timeout included
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTLSConfig sends the requests with the client certificate of the TLS config
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(c *Client) {
+		c.httpClient = &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	}
+}
+
+// WithAPIKey authenticates the requests with the API key scoped to the rollup of the txs sent
+func WithAPIKey(apiKey string) Option {
+	return WithHeader("Authorization", "Bearer "+apiKey)
+}
+
+// WithHeader adds the header to every request
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.headers.Add(key, value)
+	}
+}
+
+// WithRetries retries the requests that fail to reach the agglayer up to maxRetries times,
+// waiting backoff before the first retry and doubling it on each subsequent one up to maxBackoff
+func WithRetries(maxRetries int, backoff, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryBackoff = backoff
+		c.maxRetryBackoff = maxBackoff
+	}
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context sending the requests with the given ID, rather than one
+// generated by the client, to correlate them with the logs of the caller
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// Client wraps all the available endpoints of the agglayer
+type Client struct {
+	url             string
+	httpClient      *http.Client
+	headers         http.Header
+	maxRetries      int
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
+	l1              L1HeaderReader
+
+	nextID atomic.Uint64
+}
+
+// New returns a client ready to be used
+func New(url string, opts ...Option) *Client {
+	c := &Client{
+		url:             url,
+		httpClient:      http.DefaultClient,
+		headers:         http.Header{},
+		maxRetries:      defaultMaxRetries,
+		retryBackoff:    defaultRetryBackoff,
+		maxRetryBackoff: defaultMaxRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// SendTx sends the tx to the agglayer, sending the same tx again returns the same hash
+func (c *Client) SendTx(ctx context.Context, signedTx tx.SignedTx) (common.Hash, error) {
+	var result types.ArgHash
+	if err := c.call(ctx, &result, "interop_sendTx", signedTx); err != nil {
+		return common.Hash{}, err
+	}
+
+	return result.Hash(), nil
+}
+
+// SimulateTx runs the checks of the tx and builds the L1 tx that would settle it, without sending it
+func (c *Client) SimulateTx(ctx context.Context, signedTx tx.SignedTx) (aggTypes.TxSimulation, error) {
+	var result aggTypes.TxSimulation
+	if err := c.call(ctx, &result, "interop_simulateTx", signedTx); err != nil {
+		return aggTypes.TxSimulation{}, err
+	}
+
+	return result, nil
+}
+
+// GetTxStatus returns the status of the tx
+func (c *Client) GetTxStatus(ctx context.Context, hash common.Hash) (TxStatus, error) {
+	var result string
+	if err := c.call(ctx, &result, "interop_getTxStatus", hash); err != nil {
+		return TxStatus{}, err
+	}
+
+	return newTxStatus(hash, result), nil
+}
+
+// GetTxDetails returns the lifecycle of the tx
+func (c *Client) GetTxDetails(ctx context.Context, hash common.Hash) (aggTypes.TxDetails, error) {
+	var result aggTypes.TxDetails
+	if err := c.call(ctx, &result, "interop_getTxDetails", hash); err != nil {
+		return aggTypes.TxDetails{}, err
+	}
+
+	return result, nil
+}
+
+// ListTxs returns a page of the txs handed over to L1 matching the filter
+func (c *Client) ListTxs(ctx context.Context, filter aggTypes.TxListFilter) (aggTypes.TxList, error) {
+	var result aggTypes.TxList
+	if err := c.call(ctx, &result, "interop_listTxs", filter); err != nil {
+		return aggTypes.TxList{}, err
+	}
+
+	return result, nil
+}
+
+// GetRollupState returns the state of the rollup, as settled by the agglayer
+func (c *Client) GetRollupState(ctx context.Context, rollupID uint32) (aggTypes.RollupState, error) {
+	var result aggTypes.RollupState
+	if err := c.call(ctx, &result, "interop_getRollupState", rollupID); err != nil {
+		return aggTypes.RollupState{}, err
+	}
+
+	return result, nil
+}
+
+// retryable returns whether a failed request may succeed if sent again
+func retryable(err error) bool {
+	var statusErr *HTTPError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
+
+// call sends a JSON-RPC request and decodes its result, the errors of the response are returned as *types.Error
+func (c *Client) call(ctx context.Context, result interface{}, method string, parameters ...interface{}) error {
+	id := c.nextID.Add(1)
+
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	if !ok || requestID == "" {
+		requestID = strconv.FormatUint(id, 10)
+	}
+
+	params, err := json.Marshal(parameters)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(jsonrpcTypes.Request{
+		JSONRPC: jsonRPCVersion,
+		ID:      id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	backoff := c.retryBackoff
+	for attempt := 0; ; attempt++ {
+		res, err := c.send(ctx, requestID, body)
+		if err == nil {
+			if res.Error != nil {
+				return responseError(res.Error)
+			}
+
+			return json.Unmarshal(res.Result, result)
+		}
+
+		if attempt >= c.maxRetries || !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w, last error: %s", ctx.Err(), err)
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if c.maxRetryBackoff > 0 && backoff > c.maxRetryBackoff {
+			backoff = c.maxRetryBackoff
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, requestID string, body []byte) (jsonrpcTypes.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return jsonrpcTypes.Response{}, err
+	}
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-type", "application/json")
+	req.Header.Set(RequestIDHeader, requestID)
+
+	httpRes, err := c.httpClient.Do(req)
+	if err != nil {
+		return jsonrpcTypes.Response{}, err
+	}
+	defer httpRes.Body.Close()
+
+	resBody, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return jsonrpcTypes.Response{}, err
+	}
+
+	if httpRes.StatusCode != http.StatusOK {
+		return jsonrpcTypes.Response{}, &HTTPError{StatusCode: httpRes.StatusCode, Body: string(resBody)}
+	}
+
+	var res jsonrpcTypes.Response
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		return jsonrpcTypes.Response{}, err
+	}
+
+	return res, nil
+}
+
+// responseError returns the error of the response, it matches the sentinel errors of rpc/types by its code
+func responseError(e *jsonrpcTypes.ErrorObject) error {
+	var data []byte
+	if e.Data != nil {
+		data = *e.Data
+	}
+
+	return types.NewError(e.Code, e.Message, data)
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jsonrpcTypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/agglayer/rpc/types"
+	"github.com/0xPolygon/agglayer/tx"
+)
+
+// handler answers a JSON-RPC request with a result or an error
+type handler func(t *testing.T, r *http.Request, req jsonrpcTypes.Request) (interface{}, *jsonrpcTypes.ErrorObject)
+
+// newServer serves the handler, answering with the given statuses first
+func newServer(t *testing.T, h handler, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+
+		var req jsonrpcTypes.Request
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		res := jsonrpcTypes.Response{JSONRPC: "2.0", ID: req.ID}
+		result, rpcErr := h(t, r, req)
+		if rpcErr != nil {
+			res.Error = rpcErr
+		} else {
+			encoded, err := json.Marshal(result)
+			require.NoError(t, err)
+			res.Result = encoded
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestClientCall(t *testing.T) {
+	t.Parallel()
+
+	hash := common.HexToHash("0x1")
+
+	sendTx := func(t *testing.T, r *http.Request, req jsonrpcTypes.Request) (interface{}, *jsonrpcTypes.ErrorObject) {
+		require.Equal(t, "interop_sendTx", req.Method)
+		return hash, nil
+	}
+
+	t.Run("sends the headers and the request ID", func(t *testing.T) {
+		t.Parallel()
+
+		server, _ := newServer(t, func(t *testing.T, r *http.Request, req jsonrpcTypes.Request) (interface{}, *jsonrpcTypes.ErrorObject) {
+			require.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+			require.Equal(t, "value", r.Header.Get("X-Custom"))
+			require.Equal(t, "request-1", r.Header.Get(RequestIDHeader))
+
+			return sendTx(t, r, req)
+		})
+
+		c := New(server.URL, WithAPIKey("key"), WithHeader("X-Custom", "value"))
+		result, err := c.SendTx(WithRequestID(context.Background(), "request-1"), tx.SignedTx{})
+		require.NoError(t, err)
+		require.Equal(t, hash, result)
+	})
+
+	t.Run("generates a request ID per call", func(t *testing.T) {
+		t.Parallel()
+
+		var ids []string
+		server, _ := newServer(t, func(t *testing.T, r *http.Request, req jsonrpcTypes.Request) (interface{}, *jsonrpcTypes.ErrorObject) {
+			ids = append(ids, r.Header.Get(RequestIDHeader))
+			return sendTx(t, r, req)
+		})
+
+		c := New(server.URL)
+		for i := 0; i < 2; i++ {
+			_, err := c.SendTx(context.Background(), tx.SignedTx{})
+			require.NoError(t, err)
+		}
+		require.Equal(t, []string{"1", "2"}, ids)
+	})
+
+	t.Run("retries the unavailable server", func(t *testing.T) {
+		t.Parallel()
+
+		server, requests := newServer(t, sendTx, http.StatusServiceUnavailable, http.StatusBadGateway)
+
+		c := New(server.URL, WithRetries(2, time.Millisecond, 2*time.Millisecond))
+		result, err := c.SendTx(context.Background(), tx.SignedTx{})
+		require.NoError(t, err)
+		require.Equal(t, hash, result)
+		require.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("gives up once the retries are exhausted", func(t *testing.T) {
+		t.Parallel()
+
+		server, requests := newServer(t, sendTx, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+
+		c := New(server.URL, WithRetries(1, time.Millisecond, time.Millisecond))
+		_, err := c.SendTx(context.Background(), tx.SignedTx{})
+
+		var httpErr *HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		require.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+		require.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("doesn't retry the rejected requests", func(t *testing.T) {
+		t.Parallel()
+
+		server, requests := newServer(t, sendTx, http.StatusUnauthorized)
+
+		c := New(server.URL, WithRetries(2, time.Millisecond, time.Millisecond))
+		_, err := c.SendTx(context.Background(), tx.SignedTx{})
+
+		var httpErr *HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		require.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+		require.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("stops retrying once the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		server, _ := newServer(t, sendTx, http.StatusServiceUnavailable)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		c := New(server.URL, WithRetries(1, time.Minute, time.Minute))
+		_, err := c.SendTx(ctx, tx.SignedTx{})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("returns the typed errors of the response", func(t *testing.T) {
+		t.Parallel()
+
+		server, _ := newServer(t, func(t *testing.T, r *http.Request, req jsonrpcTypes.Request) (interface{}, *jsonrpcTypes.ErrorObject) {
+			return nil, &jsonrpcTypes.ErrorObject{Code: types.ErrorCodeUnknownRollup, Message: "unknown rollup 3"}
+		})
+
+		c := New(server.URL)
+		_, err := c.SendTx(context.Background(), tx.SignedTx{})
+		require.ErrorIs(t, err, types.ErrUnknownRollup)
+	})
+}
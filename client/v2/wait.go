@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/0xPolygon/agglayer/rpc/types"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	aggTypes "github.com/0xPolygon/agglayer/types"
+)
+
+const (
+	// WaitSent waits until an L1 tx is sent to settle the tx
+	WaitSent = WaitTarget("sent")
+
+	// WaitConfirmed waits until the tx is settled by an L1 tx mined successfully
+	WaitConfirmed = WaitTarget("confirmed")
+
+	// WaitFinalized waits until the L1 block settling the tx is finalized, it requires WithL1Client
+	WaitFinalized = WaitTarget("finalized")
+
+	defaultPollInterval    = time.Second
+	defaultMaxPollInterval = 10 * time.Second
+)
+
+var (
+	// ErrTxRejected is returned when the agglayer rejects a tx waited for
+	ErrTxRejected = errors.New("tx rejected by the agglayer")
+
+	// ErrTxFailed is returned when the settlement of a tx waited for reverts on L1
+	ErrTxFailed = errors.New("tx settlement failed on L1")
+
+	// ErrNoL1Client is returned when waiting for WaitFinalized without an L1 client
+	ErrNoL1Client = errors.New("an L1 client is required to wait for the finalized block")
+
+	// ErrUnknownWaitTarget is returned when waiting for a target that isn't one of the WaitTarget constants
+	ErrUnknownWaitTarget = errors.New("unknown wait target")
+)
+
+// WaitTarget is the state of a tx WaitTx returns at
+type WaitTarget string
+
+// String returns a string representation of the target
+func (t WaitTarget) String() string {
+	return string(t)
+}
+
+// L1HeaderReader reads the headers of L1 to know the finalized block, ethclient.Client implements it
+type L1HeaderReader interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error)
+}
+
+// WithL1Client reads the finalized block of L1 with the client, to wait for WaitFinalized
+func WithL1Client(l1 L1HeaderReader) Option {
+	return func(c *Client) {
+		c.l1 = l1
+	}
+}
+
+// WaitOption configures a single call of WaitTx
+type WaitOption func(*waitConfig)
+
+type waitConfig struct {
+	pollInterval    time.Duration
+	maxPollInterval time.Duration
+	progress        func(TxStatus)
+}
+
+// WithPollBackoff polls the status of the tx after interval, doubling it on each poll up to maxInterval
+func WithPollBackoff(interval, maxInterval time.Duration) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.pollInterval = interval
+		cfg.maxPollInterval = maxInterval
+	}
+}
+
+// WithProgress calls progress with the first status of the tx and then on each change of its status
+func WithProgress(progress func(TxStatus)) WaitOption {
+	return func(cfg *waitConfig) {
+		cfg.progress = progress
+	}
+}
+
+// WaitTx polls the status of the tx until it reaches the target. It returns as soon as the tx
+// is rejected, with ErrTxRejected wrapping the error the tx was rejected with, or its settlement
+// fails, with ErrTxFailed and the revert reason. A settlement reorged is waited for again
+func (c *Client) WaitTx(ctx context.Context, hash common.Hash, target WaitTarget, opts ...WaitOption) (TxStatus, error) {
+	switch target {
+	case WaitSent, WaitConfirmed:
+	case WaitFinalized:
+		if c.l1 == nil {
+			return TxStatus{}, ErrNoL1Client
+		}
+	default:
+		return TxStatus{}, fmt.Errorf("%w: %s", ErrUnknownWaitTarget, target)
+	}
+
+	cfg := waitConfig{pollInterval: defaultPollInterval, maxPollInterval: defaultMaxPollInterval}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		last     TxStatus
+		interval = cfg.pollInterval
+	)
+	for {
+		status, err := c.GetTxStatus(ctx, hash)
+		if err != nil {
+			return last, err
+		}
+
+		if cfg.progress != nil && status.Status != last.Status {
+			cfg.progress(status)
+		}
+		last = status
+
+		switch status.Outcome {
+		case aggTypes.TxOutcomeRejected:
+			return status, c.rejection(ctx, hash)
+		case aggTypes.TxOutcomeFailed:
+			return status, c.failure(ctx, hash)
+		}
+
+		reached, err := c.reached(ctx, status, target)
+		if err != nil {
+			return status, err
+		}
+		if reached {
+			return status, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status, fmt.Errorf("%w, last status: %s", ctx.Err(), status.Status)
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if cfg.maxPollInterval > 0 && interval > cfg.maxPollInterval {
+			interval = cfg.maxPollInterval
+		}
+	}
+}
+
+// reached returns whether the tx reached the target, settlements in the confirmed status are
+// only finalized once L1 finalizes the block they were mined in
+func (c *Client) reached(ctx context.Context, status TxStatus, target WaitTarget) (bool, error) {
+	switch status.Status {
+	case txmTypes.MonitoredTxStatusSent.String(), txmTypes.MonitoredTxStatusReorged.String():
+		return target == WaitSent, nil
+	case txmTypes.MonitoredTxStatusConfirmed.String(), txmTypes.MonitoredTxStatusDone.String():
+		if target != WaitFinalized {
+			return true, nil
+		}
+	default:
+		return false, nil
+	}
+
+	details, err := c.GetTxDetails(ctx, status.Hash)
+	if err != nil {
+		return false, err
+	}
+	if details.Settlement == nil || details.Settlement.BlockNumber == nil {
+		return false, nil
+	}
+
+	finalized, err := c.l1.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil {
+		return false, fmt.Errorf("failed to get the finalized block of L1: %w", err)
+	}
+
+	return finalized.Number.Cmp(details.Settlement.BlockNumber.ToInt()) >= 0, nil
+}
+
+// rejection returns the error the tx was rejected with, matching the sentinel errors of rpc/types
+func (c *Client) rejection(ctx context.Context, hash common.Hash) error {
+	details, err := c.GetTxDetails(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("%w: %s, failed to get the reason: %w", ErrTxRejected, hash.Hex(), err)
+	}
+
+	if details.ErrorCode == 0 {
+		return fmt.Errorf("%w: %s", ErrTxRejected, details.Error)
+	}
+
+	return fmt.Errorf("%w: %w", ErrTxRejected, types.NewError(details.ErrorCode, details.Error, nil))
+}
+
+// failure returns the error of the settlement that failed, with the revert reason of its last attempt
+func (c *Client) failure(ctx context.Context, hash common.Hash) error {
+	details, err := c.GetTxDetails(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("%w: %s, failed to get the reason: %w", ErrTxFailed, hash.Hex(), err)
+	}
+
+	if details.Settlement != nil {
+		for i := len(details.Settlement.Attempts) - 1; i >= 0; i-- {
+			if reason := details.Settlement.Attempts[i].RevertMessage; reason != "" {
+				return fmt.Errorf("%w: %s", ErrTxFailed, reason)
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrTxFailed, hash.Hex())
+}
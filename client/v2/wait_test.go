@@ -0,0 +1,192 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jsonrpcTypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/agglayer/rpc/types"
+	aggTypes "github.com/0xPolygon/agglayer/types"
+)
+
+// l1Headers returns the finalized blocks in order, the last one once they're exhausted
+type l1Headers struct {
+	finalized []int64
+	calls     atomic.Int32
+}
+
+func (l *l1Headers) HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error) {
+	if len(l.finalized) == 0 {
+		return nil, errors.New("unavailable")
+	}
+
+	n := int(l.calls.Add(1))
+	if n > len(l.finalized) {
+		n = len(l.finalized)
+	}
+
+	return &ethTypes.Header{Number: big.NewInt(l.finalized[n-1])}, nil
+}
+
+func TestClientWaitTx(t *testing.T) {
+	t.Parallel()
+
+	hash := common.HexToHash("0x1")
+	poll := WithPollBackoff(time.Millisecond, 2*time.Millisecond)
+
+	// statusServer answers interop_getTxStatus with the statuses in order, the last one once they're exhausted
+	statusServer := func(t *testing.T, details aggTypes.TxDetails, statuses ...string) *httptest.Server {
+		t.Helper()
+
+		var calls atomic.Int32
+		server, _ := newServer(t, func(t *testing.T, r *http.Request, req jsonrpcTypes.Request) (interface{}, *jsonrpcTypes.ErrorObject) {
+			switch req.Method {
+			case "interop_getTxStatus":
+				n := int(calls.Add(1))
+				if n > len(statuses) {
+					n = len(statuses)
+				}
+
+				return statuses[n-1], nil
+			case "interop_getTxDetails":
+				return details, nil
+			default:
+				return nil, &jsonrpcTypes.ErrorObject{Code: jsonrpcTypes.NotFoundErrorCode, Message: req.Method}
+			}
+		})
+
+		return server
+	}
+
+	settled := aggTypes.TxDetails{Settlement: &aggTypes.TxSettlement{BlockNumber: (*hexutil.Big)(big.NewInt(10))}}
+
+	testCases := []struct {
+		name        string
+		target      WaitTarget
+		statuses    []string
+		details     aggTypes.TxDetails
+		l1          L1HeaderReader
+		expected    TxStatus
+		expectedErr []error
+	}{
+		{
+			name:     "sent",
+			target:   WaitSent,
+			statuses: []string{"received", "verified", "settling", "created", "sent"},
+			expected: TxStatus{Hash: hash, Status: "sent", Outcome: aggTypes.TxOutcomePending},
+		},
+		{
+			name:     "already confirmed when waiting for sent",
+			target:   WaitSent,
+			statuses: []string{"confirmed"},
+			expected: TxStatus{Hash: hash, Status: "confirmed", Outcome: aggTypes.TxOutcomeSettled},
+		},
+		{
+			name:     "confirmed",
+			target:   WaitConfirmed,
+			statuses: []string{"verified", "sent", "reorged", "confirmed"},
+			expected: TxStatus{Hash: hash, Status: "confirmed", Outcome: aggTypes.TxOutcomeSettled},
+		},
+		{
+			name:     "finalized",
+			target:   WaitFinalized,
+			statuses: []string{"sent", "confirmed"},
+			details:  settled,
+			l1:       &l1Headers{finalized: []int64{8, 9, 10}},
+			expected: TxStatus{Hash: hash, Status: "confirmed", Outcome: aggTypes.TxOutcomeSettled},
+		},
+		{
+			name:        "finalized without L1 client",
+			target:      WaitFinalized,
+			statuses:    []string{"confirmed"},
+			expectedErr: []error{ErrNoL1Client},
+		},
+		{
+			name:        "unknown target",
+			target:      WaitTarget("mined"),
+			statuses:    []string{"confirmed"},
+			expectedErr: []error{ErrUnknownWaitTarget},
+		},
+		{
+			name:     "rejected",
+			target:   WaitConfirmed,
+			statuses: []string{"received", "rejected"},
+			details: aggTypes.TxDetails{
+				Error:     "proof rejected by L1: execution reverted: InvalidProof()",
+				ErrorCode: types.ErrorCodeProofRejected,
+			},
+			expected:    TxStatus{Hash: hash, Status: "rejected", Outcome: aggTypes.TxOutcomeRejected},
+			expectedErr: []error{ErrTxRejected, types.ErrProofRejected},
+		},
+		{
+			name:     "failed",
+			target:   WaitFinalized,
+			statuses: []string{"sent", "failed"},
+			details: aggTypes.TxDetails{Settlement: &aggTypes.TxSettlement{
+				Attempts: []aggTypes.L1Attempt{{RevertMessage: "InvalidProof()"}},
+			}},
+			l1:          &l1Headers{},
+			expected:    TxStatus{Hash: hash, Status: "failed", Outcome: aggTypes.TxOutcomeFailed},
+			expectedErr: []error{ErrTxFailed},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := statusServer(t, tc.details, tc.statuses...)
+
+			opts := []Option{}
+			if tc.l1 != nil {
+				opts = append(opts, WithL1Client(tc.l1))
+			}
+
+			status, err := New(server.URL, opts...).WaitTx(context.Background(), hash, tc.target, poll)
+			for _, expectedErr := range tc.expectedErr {
+				require.ErrorIs(t, err, expectedErr)
+			}
+			if len(tc.expectedErr) == 0 {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expected, status)
+		})
+	}
+
+	t.Run("reports progress", func(t *testing.T) {
+		t.Parallel()
+
+		server := statusServer(t, aggTypes.TxDetails{}, "received", "received", "verified", "sent", "sent", "confirmed")
+
+		var progress []string
+		_, err := New(server.URL).WaitTx(context.Background(), hash, WaitConfirmed, poll, WithProgress(func(status TxStatus) {
+			progress = append(progress, status.Status)
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []string{"received", "verified", "sent", "confirmed"}, progress)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		t.Parallel()
+
+		server := statusServer(t, aggTypes.TxDetails{}, "sent")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := New(server.URL).WaitTx(ctx, hash, WaitConfirmed, poll)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
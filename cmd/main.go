@@ -18,14 +18,12 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/jackc/pgx/v4/stdlib"
-	"github.com/pascaldekloe/etherkeyms"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/urfave/cli/v2"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/sdk/metric"
 
-	kms "cloud.google.com/go/kms/apiv1"
 	agglayer "github.com/0xPolygon/agglayer"
 	"github.com/0xPolygon/agglayer/config"
 	"github.com/0xPolygon/agglayer/db"
@@ -60,6 +58,8 @@ func main() {
 			Action:  start,
 			Flags:   []cli.Flag{&configFileFlag},
 		},
+		txCommand(),
+		relayerCommand(),
 	}
 
 	err := app.Run(os.Args)
@@ -132,10 +132,27 @@ func start(cliCtx *cli.Context) error {
 		return err
 	}
 
+	// Drop the cached trusted sequencers as soon as they're changed on L1
+	var sequencers *etherman.SequencerWatcher
+	if c.SequencerCache.TTL.Duration > 0 {
+		sequencers = etherman.NewSequencerWatcher(log.WithFields("module", "sequencers"), &ethMan)
+	}
+
 	// Prepare EthTxMan client
 	ethTxManagerStorage := txmanager.NewPostgresStorage(pg)
 	etm := txmanager.New(c.EthTxManager, &ethMan, ethTxManagerStorage, &ethMan)
 
+	// Push the status changes persisted by EthTxMan to the interop_subscribe subscribers
+	var (
+		statusFeed *rpc.StatusFeed
+		wsServer   *rpc.WebSocketServer
+	)
+	if c.WebSocket.Enabled {
+		statusFeed = rpc.NewStatusFeed(log.WithFields("module", "subscriptions"), c.WebSocket, storage)
+		wsServer = rpc.NewWebSocketServer(log.WithFields("module", "websocket"), c.WebSocket, statusFeed)
+		etm.StatusListener = statusFeed
+	}
+
 	// Create opentelemetry metric provider
 	meterProvider, err := createMeterProvider()
 	if err != nil {
@@ -151,15 +168,59 @@ func start(cliCtx *cli.Context) error {
 		etm,
 	)
 
+	// Refuse to retry a failed tx whose batch range was settled by a newer tx meanwhile
+	etm.Settlements = executor
+
+	// Discover the rollups of the rollup manager to reject the txs of unknown ones
+	var discovery *etherman.RollupDiscovery
+	if c.Discovery.Enabled {
+		discovery = etherman.NewRollupDiscovery(log.WithFields("module", "discovery"), c, &ethMan, storage)
+		executor.RollupDiscovery = discovery
+	}
+
+	// Prepare the pipeline processing the txs received through interop_sendTx
+	pipeline := interop.NewPipeline(
+		log.WithFields("module", "pipeline"),
+		c,
+		executor,
+		storage,
+	)
+	// Push the statuses of the txs in the intake queue as well, before they're handed over to EthTxMan
+	if statusFeed != nil {
+		pipeline.StatusListener = statusFeed
+	}
+
+	// Operate EthTxMan through the admin namespace on its own listener
+	var adminServer *rpc.AdminServer
+	if c.Admin.Enabled {
+		adminServer, err = rpc.NewAdminServer(log.WithFields("module", "admin"), c.Admin, etm, storage)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Register services
+	endpoints := rpc.NewInteropEndpoints(log.WithFields("module", "rpc"), executor, pipeline, storage, c)
+	services := []jRPC.Service{
+		{
+			Name:    rpc.INTEROP,
+			Service: endpoints,
+		},
+	}
+
+	// Describe the registered services, and the WebSocket subscriptions, through rpc_discover
+	documented := append([]jRPC.Service{}, services...)
+	if wsServer != nil {
+		documented = append(documented, wsServer.Service())
+	}
+	services = append(services, jRPC.Service{
+		Name:    rpc.RPC,
+		Service: rpc.NewDiscoverEndpoints(documented...),
+	})
+
 	server := jRPC.NewServer(
 		c.RPC,
-		[]jRPC.Service{
-			{
-				Name:    rpc.INTEROP,
-				Service: rpc.NewInteropEndpoints(log.WithFields("module", "rpc"), executor, storage, c),
-			},
-		},
+		services,
 		jRPC.WithHealthHandler(healthHandler(storage)),
 		jRPC.WithLogger(log.WithFields("module", "rpc")),
 	)
@@ -171,9 +232,62 @@ func start(cliCtx *cli.Context) error {
 		}
 	}()
 
+	// Serve interop_sendTx and interop_simulateTx over TLS for the rollups authenticated by their client certificate
+	var tlsServer *rpc.SendTxTLSServer
+	if c.Auth.TLSCertFile != "" && c.Auth.TLSKeyFile != "" {
+		tlsServer, err = rpc.NewSendTxTLSServer(log.WithFields("module", "tls"), c.Auth, endpoints)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			if err := tlsServer.Start(); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	// Run WebSocket subscriptions
+	if wsServer != nil {
+		go statusFeed.Start()
+		go func() {
+			if err := wsServer.Start(); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
+	// Run the admin namespace
+	if adminServer != nil {
+		go func() {
+			if err := adminServer.Start(); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
+
 	// Run EthTxMan
 	go etm.Start()
 
+	// Run the intake pipeline
+	go pipeline.Start()
+
+	// Run the rollup discovery
+	if discovery != nil {
+		go discovery.Start()
+	}
+
+	// Run the trusted sequencers watcher
+	if sequencers != nil {
+		go sequencers.Start()
+	}
+
+	// Reload the rollup registry at runtime
+	stopRegistry, err := runRegistry(cliCtx.Context, c, storage)
+	if err != nil {
+		return err
+	}
+
 	// Run prometheus server
 	closePrometheus, err := runPrometheusServer(c)
 	if err != nil {
@@ -182,12 +296,46 @@ func start(cliCtx *cli.Context) error {
 
 	// Stop services
 	waitSignal([]context.CancelFunc{
+		stopRegistry,
+		func() {
+			if discovery != nil {
+				discovery.Stop()
+			}
+		},
+		func() {
+			if sequencers != nil {
+				sequencers.Stop()
+			}
+		},
+		pipeline.Stop,
 		etm.Stop,
 		func() {
 			if err := server.Stop(); err != nil {
 				log.Error(err)
 			}
 		},
+		func() {
+			if tlsServer != nil {
+				if err := tlsServer.Stop(); err != nil {
+					log.Error(err)
+				}
+			}
+		},
+		func() {
+			if wsServer != nil {
+				if err := wsServer.Stop(); err != nil {
+					log.Error(err)
+				}
+				statusFeed.Stop()
+			}
+		},
+		func() {
+			if adminServer != nil {
+				if err := adminServer.Stop(); err != nil {
+					log.Error(err)
+				}
+			}
+		},
 		ethTxManagerStorage.Close,
 		closePrometheus,
 		func() {
@@ -200,6 +348,23 @@ func start(cliCtx *cli.Context) error {
 	return nil
 }
 
+func runRegistry(ctx context.Context, c *config.Config, storage *db.DB) (context.CancelFunc, error) {
+	switch c.Registry.Source {
+	case config.RegistrySourceFile:
+		c.WatchFile()
+	case config.RegistrySourceDB:
+		ctx, cancel := context.WithCancel(ctx)
+		go c.PollDB(ctx, storage)
+
+		return cancel, nil
+	case config.RegistrySourceNone, "":
+	default:
+		return nil, fmt.Errorf("unknown registry source %q", c.Registry.Source)
+	}
+
+	return func() {}, nil
+}
+
 func setupLog(c log.Config) {
 	if err := log.InitLogger(c); err != nil {
 		panic(fmt.Errorf("could not setup logger. Err: %w", err))
@@ -275,14 +440,9 @@ func useKMSAuth(c *config.Config) (*bind.TransactOpts, common.Address, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), c.EthTxManager.KMSConnectionTimeout.Duration)
 	defer cancel()
 
-	client, err := kms.NewKeyManagementClient(ctx)
-	if err != nil {
-		return nil, common.Address{}, fmt.Errorf("failed to create kms client: %w", err)
-	}
-
-	mk, err := etherkeyms.NewManagedKey(ctx, client, c.EthTxManager.KMSKeyName)
+	mk, err := newManagedKey(ctx, c.EthTxManager.KMSKeyName)
 	if err != nil {
-		return nil, common.Address{}, fmt.Errorf("failed to create managed key: %w", err)
+		return nil, common.Address{}, err
 	}
 	signer := types.LatestSignerForChainID(big.NewInt(c.L1.ChainID))
 
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	agglayer "github.com/0xPolygon/agglayer"
+	client "github.com/0xPolygon/agglayer/client/v2"
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/interop"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/relayer"
+	"github.com/0xPolygon/agglayer/tx"
+)
+
+// relayerCommand runs the relayer of the [Relayer] config instead of the agglayer
+func relayerCommand() *cli.Command {
+	return &cli.Command{
+		Name:   "relayer",
+		Usage:  "Relay the proofs of a rollup from its zkEVM node to the agglayer",
+		Action: startRelayer,
+		Flags:  []cli.Flag{&configFileFlag},
+	}
+}
+
+func startRelayer(cliCtx *cli.Context) error {
+	c, err := config.Load(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	setupLog(c.Log)
+
+	log.Infof("Starting relayer of rollup %d...\n%s", c.Relayer.RollupID, agglayer.GetVersionInfo())
+
+	if c.Relayer.NodeURL == "" || c.Relayer.AggLayerURL == "" {
+		return errors.New("Relayer.NodeURL and Relayer.AggLayerURL are required")
+	}
+
+	proofs, err := relayer.NewProofSource(c.Relayer)
+	if err != nil {
+		return err
+	}
+
+	signer, err := newRelayerSigner(c.Relayer)
+	if err != nil {
+		return err
+	}
+
+	opts := []client.Option{}
+	if c.Relayer.APIKey != "" {
+		opts = append(opts, client.WithAPIKey(c.Relayer.APIKey))
+	}
+
+	logger := log.WithFields("module", "relayer")
+	node := interop.NewZkEVMClientCreator(logger, c.ZkEVMClient).NewClient(c.Relayer.NodeURL)
+	domain := tx.SigningDomain{L1ChainID: uint64(c.L1.ChainID), RollupManagerContract: c.L1.RollupManagerContract}
+
+	r := relayer.New(logger, c.Relayer, domain, node, client.New(c.Relayer.AggLayerURL, opts...), proofs, signer)
+	go r.Start()
+
+	waitSignal([]context.CancelFunc{r.Stop})
+
+	return nil
+}
+
+// newRelayerSigner returns the KMS key of the config if set, else its keystore
+func newRelayerSigner(c config.RelayerConfig) (relayer.Signer, error) {
+	if c.KMSKeyName != "" {
+		log.Debugf("using KMS key: %s", c.KMSKeyName)
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.KMSConnectionTimeout.Duration)
+		defer cancel()
+
+		return newManagedKey(ctx, c.KMSKeyName)
+	}
+
+	log.Debugf("using local private key: %s", c.PrivateKey.Path)
+
+	pk, err := config.NewKeyFromKeystore(c.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create private key from keystore: %w", err)
+	}
+
+	return &keystoreSigner{privateKey: pk}, nil
+}
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/pascaldekloe/etherkeyms"
+	"github.com/urfave/cli/v2"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	client "github.com/0xPolygon/agglayer/client/v2"
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/tx"
+)
+
+const (
+	flagTx            = "tx"
+	flagSignedTx      = "signed-tx"
+	flagOutput        = "output"
+	flagKeystore      = "keystore"
+	flagPassword      = "password"
+	flagKMSKey        = "kms-key"
+	flagL1ChainID     = "l1-chain-id"
+	flagRollupManager = "rollup-manager"
+	flagLegacy        = "legacy"
+	flagURL           = "url"
+	flagAPIKey        = "api-key"
+	flagWait          = "wait"
+	flagL1URL         = "l1-url"
+	flagTimeout       = "timeout"
+	flagDetails       = "details"
+
+	kmsConnectionTimeout = 30 * time.Second
+)
+
+var (
+	signFlags = []cli.Flag{
+		&configFileFlag,
+		&cli.StringFlag{Name: flagTx, Usage: "JSON `FILE` of the tx to sign"},
+		&cli.StringFlag{Name: flagKeystore, Usage: "Keystore `FILE` of the key signing the tx"},
+		&cli.StringFlag{Name: flagPassword, Usage: "Password of the keystore", EnvVars: []string{"AGGLAYER_KEYSTORE_PASSWORD"}},
+		&cli.StringFlag{Name: flagKMSKey, Usage: "`NAME` of the GCP KMS key signing the tx, instead of a keystore"},
+		&cli.Int64Flag{Name: flagL1ChainID, Usage: "`ID` of the L1 chain of the agglayer, the one of the config by default"},
+		&cli.StringFlag{Name: flagRollupManager, Usage: "`ADDRESS` of the rollup manager, the one of the config by default"},
+		&cli.BoolFlag{Name: flagLegacy, Usage: "Sign the legacy hash of the tx rather than its typed data"},
+	}
+
+	clientFlags = []cli.Flag{
+		&cli.StringFlag{Name: flagURL, Usage: "`URL` of the agglayer RPC", Required: true},
+		&cli.StringFlag{Name: flagAPIKey, Usage: "API `KEY` scoped to the rollup of the tx", EnvVars: []string{"AGGLAYER_API_KEY"}},
+		&cli.StringFlag{Name: flagWait, Usage: "Wait for the tx to reach the `TARGET`: sent, confirmed or finalized"},
+		&cli.StringFlag{Name: flagL1URL, Usage: "`URL` of the L1 node to wait for the finalized block, the one of the config by default"},
+		&cli.DurationFlag{Name: flagTimeout, Usage: "Maximum `DURATION` of the command, waiting included", Value: 30 * time.Minute},
+	}
+)
+
+// txCommand signs, sends and follows the txs of the rollups, to replay a proof by hand
+func txCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "tx",
+		Usage: "Sign, send and follow txs",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "sign",
+				Usage:  "Sign a tx with a keystore or a KMS key and print the signed tx",
+				Action: signTxAction,
+				Flags: concatFlags(signFlags, []cli.Flag{
+					&cli.StringFlag{Name: flagOutput, Aliases: []string{"o"}, Usage: "`FILE` the signed tx is written to, stdout by default"},
+				}),
+			},
+			{
+				Name:   "send",
+				Usage:  "Send a tx to the agglayer, signing it first unless it's already signed",
+				Action: sendTxAction,
+				Flags: concatFlags(signFlags, clientFlags, []cli.Flag{
+					&cli.StringFlag{Name: flagSignedTx, Usage: "JSON `FILE` of the signed tx to send, as printed by tx sign"},
+				}),
+			},
+			{
+				Name:      "status",
+				Usage:     "Print the status of a tx",
+				ArgsUsage: "HASH",
+				Action:    txStatusAction,
+				Flags: concatFlags(clientFlags, []cli.Flag{
+					&configFileFlag,
+					&cli.BoolFlag{Name: flagDetails, Usage: "Print the lifecycle of the tx rather than its status"},
+				}),
+			},
+		},
+	}
+}
+
+func concatFlags(groups ...[]cli.Flag) []cli.Flag {
+	var flags []cli.Flag
+	for _, group := range groups {
+		flags = append(flags, group...)
+	}
+
+	return flags
+}
+
+func signTxAction(cliCtx *cli.Context) error {
+	signedTx, err := signTx(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(signedTx, "", "  ")
+	if err != nil {
+		return err
+	}
+	out = append(out, '\n')
+
+	if output := cliCtx.String(flagOutput); output != "" {
+		return os.WriteFile(filepath.Clean(output), out, 0o600)
+	}
+
+	_, err = os.Stdout.Write(out)
+
+	return err
+}
+
+func sendTxAction(cliCtx *cli.Context) error {
+	var (
+		signedTx *tx.SignedTx
+		err      error
+	)
+	switch {
+	case cliCtx.IsSet(flagSignedTx) && cliCtx.IsSet(flagTx):
+		return fmt.Errorf("either --%s or --%s must be provided, not both", flagTx, flagSignedTx)
+	case cliCtx.IsSet(flagSignedTx):
+		signedTx = &tx.SignedTx{}
+		err = readJSON(cliCtx.String(flagSignedTx), signedTx)
+	default:
+		signedTx, err = signTx(cliCtx)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cliCtx.Context, cliCtx.Duration(flagTimeout))
+	defer cancel()
+
+	c, err := newClient(ctx, cliCtx)
+	if err != nil {
+		return err
+	}
+
+	hash, err := c.SendTx(ctx, *signedTx)
+	if err != nil {
+		return fmt.Errorf("failed to send tx: %w", err)
+	}
+	fmt.Println(hash.Hex())
+
+	return waitTx(ctx, cliCtx, c, hash)
+}
+
+func txStatusAction(cliCtx *cli.Context) error {
+	if cliCtx.NArg() != 1 {
+		return errors.New("the hash of the tx must be provided")
+	}
+	hash := common.HexToHash(cliCtx.Args().First())
+
+	ctx, cancel := context.WithTimeout(cliCtx.Context, cliCtx.Duration(flagTimeout))
+	defer cancel()
+
+	c, err := newClient(ctx, cliCtx)
+	if err != nil {
+		return err
+	}
+
+	if err := waitTx(ctx, cliCtx, c, hash); err != nil {
+		return err
+	}
+
+	if cliCtx.Bool(flagDetails) {
+		details, err := c.GetTxDetails(ctx, hash)
+		if err != nil {
+			return err
+		}
+
+		out, err := json.MarshalIndent(details, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+
+		return nil
+	}
+
+	status, err := c.GetTxStatus(ctx, hash)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s (%s)\n", status.Status, status.Outcome)
+
+	return nil
+}
+
+// waitTx waits for the tx to reach the target of the wait flag, if any, printing its progress to stderr
+func waitTx(ctx context.Context, cliCtx *cli.Context, c *client.Client, hash common.Hash) error {
+	if !cliCtx.IsSet(flagWait) {
+		return nil
+	}
+
+	_, err := c.WaitTx(ctx, hash, client.WaitTarget(cliCtx.String(flagWait)), client.WithProgress(func(status client.TxStatus) {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", status.Hash.Hex(), status.Status)
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to wait for tx %s: %w", hash.Hex(), err)
+	}
+
+	return nil
+}
+
+// newClient returns the client of the agglayer, with an L1 client when waiting for the finalized block
+func newClient(ctx context.Context, cliCtx *cli.Context) (*client.Client, error) {
+	opts := []client.Option{}
+	if apiKey := cliCtx.String(flagAPIKey); apiKey != "" {
+		opts = append(opts, client.WithAPIKey(apiKey))
+	}
+
+	if client.WaitTarget(cliCtx.String(flagWait)) == client.WaitFinalized {
+		l1URL := cliCtx.String(flagL1URL)
+		if l1URL == "" && cliCtx.IsSet(config.FlagCfg) {
+			c, err := config.Load(cliCtx)
+			if err != nil {
+				return nil, err
+			}
+			l1URL = c.L1.NodeURL
+		}
+		if l1URL == "" {
+			return nil, fmt.Errorf("--%s or a config file is required to wait for the finalized block", flagL1URL)
+		}
+
+		ethClient, err := ethclient.DialContext(ctx, l1URL)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to %s: %w", l1URL, err)
+		}
+		opts = append(opts, client.WithL1Client(ethClient))
+	}
+
+	return client.New(cliCtx.String(flagURL), opts...), nil
+}
+
+// hashSigner signs the hash of a tx
+type hashSigner interface {
+	SignHash(ctx context.Context, hash common.Hash) ([]byte, error)
+}
+
+// keystoreSigner signs with a key decrypted from a keystore
+type keystoreSigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+func (s *keystoreSigner) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return crypto.Sign(hash.Bytes(), s.privateKey)
+}
+
+// signTx reads the tx of the tx flag and signs it with the keystore or the KMS key of the flags
+func signTx(cliCtx *cli.Context) (*tx.SignedTx, error) {
+	if !cliCtx.IsSet(flagTx) {
+		return nil, fmt.Errorf("--%s must be provided", flagTx)
+	}
+
+	var t tx.Tx
+	if err := readJSON(cliCtx.String(flagTx), &t); err != nil {
+		return nil, err
+	}
+
+	signer, err := newHashSigner(cliCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := t.Hash()
+	if !cliCtx.Bool(flagLegacy) {
+		domain, err := signingDomain(cliCtx)
+		if err != nil {
+			return nil, err
+		}
+		hash = t.TypedDataHash(domain)
+	}
+
+	sig, err := signer.SignHash(cliCtx.Context, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign tx: %w", err)
+	}
+
+	return &tx.SignedTx{Tx: t, Signature: sig}, nil
+}
+
+func newHashSigner(cliCtx *cli.Context) (hashSigner, error) {
+	switch {
+	case cliCtx.IsSet(flagKMSKey) && cliCtx.IsSet(flagKeystore):
+		return nil, fmt.Errorf("either --%s or --%s must be provided, not both", flagKeystore, flagKMSKey)
+	case cliCtx.IsSet(flagKMSKey):
+		ctx, cancel := context.WithTimeout(cliCtx.Context, kmsConnectionTimeout)
+		defer cancel()
+
+		return newManagedKey(ctx, cliCtx.String(flagKMSKey))
+	case cliCtx.IsSet(flagKeystore):
+		pk, err := config.NewKeyFromKeystore(types.KeystoreFileConfig{
+			Path:     cliCtx.String(flagKeystore),
+			Password: cliCtx.String(flagPassword),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create private key from keystore: %w", err)
+		}
+
+		return &keystoreSigner{privateKey: pk}, nil
+	default:
+		return nil, fmt.Errorf("either --%s or --%s must be provided", flagKeystore, flagKMSKey)
+	}
+}
+
+// signingDomain returns the domain of the typed data signatures, from the flags or else from the config
+func signingDomain(cliCtx *cli.Context) (tx.SigningDomain, error) {
+	var domain tx.SigningDomain
+	if cliCtx.IsSet(config.FlagCfg) {
+		c, err := config.Load(cliCtx)
+		if err != nil {
+			return tx.SigningDomain{}, err
+		}
+		domain = tx.SigningDomain{L1ChainID: uint64(c.L1.ChainID), RollupManagerContract: c.L1.RollupManagerContract}
+	} else if !cliCtx.IsSet(flagL1ChainID) || !cliCtx.IsSet(flagRollupManager) {
+		// the defaults of the config are the ones of the local environment, never sign for them implicitly
+		return tx.SigningDomain{}, fmt.Errorf("--%s and --%s, or a config file, are required to sign the typed data", flagL1ChainID, flagRollupManager)
+	}
+
+	if cliCtx.IsSet(flagL1ChainID) {
+		domain.L1ChainID = uint64(cliCtx.Int64(flagL1ChainID))
+	}
+	if cliCtx.IsSet(flagRollupManager) {
+		address := cliCtx.String(flagRollupManager)
+		if !common.IsHexAddress(address) {
+			return tx.SigningDomain{}, fmt.Errorf("invalid rollup manager address %q", address)
+		}
+		domain.RollupManagerContract = common.HexToAddress(address)
+	}
+
+	return domain, nil
+}
+
+// newManagedKey returns the GCP KMS key of the name
+func newManagedKey(ctx context.Context, keyName string) (*etherkeyms.ManagedKey, error) {
+	kmsClient, err := kms.NewKeyManagementClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kms client: %w", err)
+	}
+
+	mk, err := etherkeyms.NewManagedKey(ctx, kmsClient, keyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create managed key: %w", err)
+	}
+
+	return mk, nil
+}
+
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	return nil
+}
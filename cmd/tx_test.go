@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/0xPolygon/agglayer/tx"
+)
+
+// txFixture writes a tx and the keystore of a new key, returning the tx, their files and the address of the key
+func txFixture(t *testing.T) (tx.Tx, string, string, common.Address) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	t1 := tx.Tx{
+		RollupID:          1,
+		LastVerifiedBatch: 2,
+		NewVerifiedBatch:  4,
+		ZKP: tx.ZKP{
+			NewStateRoot:     common.HexToHash("0x1"),
+			NewLocalExitRoot: common.HexToHash("0x2"),
+			Proof:            []byte{0x03},
+		},
+	}
+	data, err := json.Marshal(t1)
+	require.NoError(t, err)
+
+	txFile := filepath.Join(dir, "tx.json")
+	require.NoError(t, os.WriteFile(txFile, data, 0o600))
+
+	account, err := keystore.StoreKey(filepath.Join(dir, "keystore"), "testonly", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	return t1, txFile, account.URL.Path, account.Address
+}
+
+// runTx runs the tx command with the args
+func runTx(args ...string) error {
+	app := cli.NewApp()
+	app.Writer = io.Discard
+	app.ErrWriter = io.Discard
+	app.Commands = []*cli.Command{txCommand()}
+
+	return app.Run(append([]string{appName, "tx"}, args...))
+}
+
+func TestSignTx(t *testing.T) {
+	t.Parallel()
+
+	domain := tx.SigningDomain{L1ChainID: 1337, RollupManagerContract: common.HexToAddress("0xB7f8BC63BbcaD18155201308C8f3540b07f84F5e")}
+	domainFlags := []string{"--" + flagL1ChainID, "1337", "--" + flagRollupManager, domain.RollupManagerContract.Hex()}
+
+	testCases := []struct {
+		name          string
+		flags         []string
+		expectedError string
+		signer        func(stx tx.SignedTx) (common.Address, error)
+	}{
+		{
+			name:  "typed data",
+			flags: domainFlags,
+			signer: func(stx tx.SignedTx) (common.Address, error) {
+				return stx.TypedDataSigner(domain)
+			},
+		},
+		{
+			name:  "legacy",
+			flags: []string{"--" + flagLegacy},
+			signer: func(stx tx.SignedTx) (common.Address, error) {
+				return stx.Signer()
+			},
+		},
+		{
+			name:          "missing rollup manager",
+			flags:         []string{"--" + flagL1ChainID, "1337"},
+			expectedError: "--l1-chain-id and --rollup-manager, or a config file, are required to sign the typed data",
+		},
+		{
+			name:          "missing L1 chain ID",
+			flags:         []string{"--" + flagRollupManager, domain.RollupManagerContract.Hex()},
+			expectedError: "--l1-chain-id and --rollup-manager, or a config file, are required to sign the typed data",
+		},
+		{
+			name:          "invalid rollup manager",
+			flags:         []string{"--" + flagL1ChainID, "1337", "--" + flagRollupManager, "0xinvalid"},
+			expectedError: `invalid rollup manager address "0xinvalid"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			t1, txFile, keystoreFile, address := txFixture(t)
+			output := filepath.Join(t.TempDir(), "signed.json")
+
+			err := runTx(append([]string{"sign",
+				"--" + flagTx, txFile,
+				"--" + flagKeystore, keystoreFile,
+				"--" + flagPassword, "testonly",
+				"--" + flagOutput, output,
+			}, tc.flags...)...)
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				require.NoFileExists(t, output)
+				return
+			}
+			require.NoError(t, err)
+
+			var signedTx tx.SignedTx
+			require.NoError(t, readJSON(output, &signedTx))
+			require.Equal(t, t1.Hash(), signedTx.Tx.Hash())
+
+			signer, err := tc.signer(signedTx)
+			require.NoError(t, err)
+			require.Equal(t, address, signer)
+		})
+	}
+
+	t.Run("either a keystore or a KMS key", func(t *testing.T) {
+		t.Parallel()
+
+		_, txFile, keystoreFile, _ := txFixture(t)
+
+		err := runTx("sign", "--"+flagTx, txFile, "--"+flagLegacy)
+		require.ErrorContains(t, err, "either --keystore or --kms-key must be provided")
+
+		err = runTx("sign", "--"+flagTx, txFile, "--"+flagLegacy, "--"+flagKeystore, keystoreFile, "--"+flagKMSKey, "key")
+		require.ErrorContains(t, err, "not both")
+	})
+}
+
+func TestSendTx(t *testing.T) {
+	t.Parallel()
+
+	domain := tx.SigningDomain{L1ChainID: 1337, RollupManagerContract: common.HexToAddress("0xB7f8BC63BbcaD18155201308C8f3540b07f84F5e")}
+
+	// server returns the hash of the txs it receives through interop_sendTx
+	server := func(t *testing.T) (string, chan tx.SignedTx) {
+		t.Helper()
+
+		received := make(chan tx.SignedTx, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				ID     json.RawMessage `json:"id"`
+				Method string          `json:"method"`
+				Params []tx.SignedTx   `json:"params"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "interop_sendTx", req.Method)
+			require.Len(t, req.Params, 1)
+			received <- req.Params[0]
+
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      req.ID,
+				"result":  req.Params[0].Tx.Hash().Hex(),
+			}))
+		}))
+		t.Cleanup(srv.Close)
+
+		return srv.URL, received
+	}
+
+	t.Run("signs and sends the tx", func(t *testing.T) {
+		t.Parallel()
+
+		url, received := server(t)
+		t1, txFile, keystoreFile, address := txFixture(t)
+
+		require.NoError(t, runTx("send",
+			"--"+flagURL, url,
+			"--"+flagTx, txFile,
+			"--"+flagKeystore, keystoreFile,
+			"--"+flagPassword, "testonly",
+			"--"+flagL1ChainID, "1337",
+			"--"+flagRollupManager, domain.RollupManagerContract.Hex(),
+		))
+
+		signedTx := <-received
+		require.Equal(t, t1.Hash(), signedTx.Tx.Hash())
+
+		signer, err := signedTx.TypedDataSigner(domain)
+		require.NoError(t, err)
+		require.Equal(t, address, signer)
+	})
+
+	t.Run("sends a signed tx as is", func(t *testing.T) {
+		t.Parallel()
+
+		url, received := server(t)
+		t1, _, _, _ := txFixture(t)
+
+		signed := tx.SignedTx{Tx: t1, Signature: []byte{0x01, 0x02}}
+		data, err := json.Marshal(signed)
+		require.NoError(t, err)
+		signedFile := filepath.Join(t.TempDir(), "signed.json")
+		require.NoError(t, os.WriteFile(signedFile, data, 0o600))
+
+		require.NoError(t, runTx("send", "--"+flagURL, url, "--"+flagSignedTx, signedFile))
+		require.Equal(t, signed, <-received)
+	})
+
+	t.Run("either a tx or a signed tx", func(t *testing.T) {
+		t.Parallel()
+
+		url, _ := server(t)
+		_, txFile, _, _ := txFixture(t)
+
+		err := runTx("send", "--"+flagURL, url, "--"+flagTx, txFile, "--"+flagSignedTx, txFile)
+		require.ErrorContains(t, err, "either --tx or --signed-tx must be provided, not both")
+	})
+}
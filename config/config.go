@@ -2,9 +2,12 @@ package config
 
 import (
 	"crypto/ecdsa"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/0xPolygon/agglayer/log"
 	cdkrpc "github.com/0xPolygon/cdk-rpc/rpc"
@@ -23,21 +26,98 @@ const (
 	FlagCfg = "cfg"
 )
 
-type FullNodeRPCs map[uint32]string
+// FullNodeRPCs holds per rollup id the URLs of its full nodes. A single URL
+// is still accepted for backward compatibility
+type FullNodeRPCs map[uint32][]string
+
+// FullNodeStrategy selects the order in which the full nodes of a rollup are queried
+type FullNodeStrategy string
+
+const (
+	// FullNodeStrategyPriority queries the full nodes in the configured order
+	FullNodeStrategyPriority FullNodeStrategy = "priority"
+	// FullNodeStrategyRoundRobin spreads the queries across the full nodes
+	FullNodeStrategyRoundRobin FullNodeStrategy = "roundrobin"
+)
+
+// FullNodesConfig controls how the full nodes in FullNodeRPCs are queried
+type FullNodesConfig struct {
+	// Strategy is the order in which healthy full nodes are queried, failing over to the next one on error
+	Strategy FullNodeStrategy `mapstructure:"Strategy"`
+}
+
+// ZkEVMClientConfig tunes the clients used to query the full nodes
+type ZkEVMClientConfig struct {
+	// Timeout bounds each request to a full node, retries included
+	Timeout types.Duration `mapstructure:"Timeout"`
+	// MaxIdleConnsPerHost is the number of connections kept open to each full node
+	MaxIdleConnsPerHost int `mapstructure:"MaxIdleConnsPerHost"`
+	// IdleConnTimeout is how long an unused connection is kept open
+	IdleConnTimeout types.Duration `mapstructure:"IdleConnTimeout"`
+	// MaxRetries is the number of times a request failing to reach the full node is retried
+	MaxRetries int `mapstructure:"MaxRetries"`
+	// RetryBackoff is the wait before the first retry, doubled on each subsequent one up to MaxRetryBackoff.
+	// It must be at least MinRetryBackoff when retries are enabled
+	RetryBackoff    types.Duration `mapstructure:"RetryBackoff"`
+	MaxRetryBackoff types.Duration `mapstructure:"MaxRetryBackoff"`
+	// BreakerThreshold is the number of consecutive failed requests after which a full node
+	// isn't queried anymore for BreakerCooldown, 0 disables the circuit breaker
+	BreakerThreshold int            `mapstructure:"BreakerThreshold"`
+	BreakerCooldown  types.Duration `mapstructure:"BreakerCooldown"`
+}
+
+// Soundness holds per rollup ID how the roots proven by its txs are checked
+type Soundness map[uint32]SoundnessConfig
+
+// SoundnessMode selects how the soundness of the txs of a rollup is checked
+type SoundnessMode string
+
+const (
+	// SoundnessModeFullNode compares the tx against the full node registered in FullNodeRPCs
+	SoundnessModeFullNode SoundnessMode = "fullnode"
+	// SoundnessModeQuorum requires Quorum of the RPCs to agree with the tx
+	SoundnessModeQuorum SoundnessMode = "quorum"
+	// SoundnessModeNone trusts the rollup purely based on its ZKP
+	SoundnessModeNone SoundnessMode = "none"
+)
+
+// SoundnessConfig is the soundness check of a single rollup
+type SoundnessConfig struct {
+	Mode SoundnessMode `mapstructure:"Mode"`
+	// RPCs are the full nodes queried in quorum mode
+	RPCs []string `mapstructure:"RPCs"`
+	// Quorum is the number of RPCs that must agree with the tx in quorum mode
+	Quorum int `mapstructure:"Quorum"`
+}
 
 // ProofSigners holds the address for authorized signers of proofs for a given rollup ip
 type ProofSigners map[uint32]common.Address
 
 // Config represents the full configuration of the data node
 type Config struct {
-	FullNodeRPCs FullNodeRPCs       `mapstructure:"FullNodeRPCs"`
-	RPC          cdkrpc.Config      `mapstructure:"RPC"`
-	ProofSigners ProofSigners       `mapstructure:"ProofSigners"`
-	Log          log.Config         `mapstructure:"Log"`
-	DB           db.Config          `mapstructure:"DB"`
-	EthTxManager EthTxManagerConfig `mapstructure:"EthTxManager"`
-	L1           L1Config           `mapstructure:"L1"`
-	Telemetry    Telemetry          `mapstructure:"Telemetry"`
+	FullNodeRPCs   FullNodeRPCs          `mapstructure:"FullNodeRPCs"`
+	FullNodes      FullNodesConfig       `mapstructure:"FullNodes"`
+	ZkEVMClient    ZkEVMClientConfig     `mapstructure:"ZkEVMClient"`
+	RPC            cdkrpc.Config         `mapstructure:"RPC"`
+	ProofSigners   ProofSigners          `mapstructure:"ProofSigners"`
+	Soundness      Soundness             `mapstructure:"Soundness"`
+	Log            log.Config            `mapstructure:"Log"`
+	DB             db.Config             `mapstructure:"DB"`
+	EthTxManager   EthTxManagerConfig    `mapstructure:"EthTxManager"`
+	L1             L1Config              `mapstructure:"L1"`
+	Telemetry      Telemetry             `mapstructure:"Telemetry"`
+	Signatures     SignaturesConfig      `mapstructure:"Signatures"`
+	Intake         IntakeConfig          `mapstructure:"Intake"`
+	Registry       RegistryConfig        `mapstructure:"Registry"`
+	Discovery      RollupDiscoveryConfig `mapstructure:"Discovery"`
+	SequencerCache SequencerCacheConfig  `mapstructure:"SequencerCache"`
+	WebSocket      WebSocketConfig       `mapstructure:"WebSocket"`
+	Admin          AdminConfig           `mapstructure:"Admin"`
+	Auth           AuthConfig            `mapstructure:"Auth"`
+	Relayer        RelayerConfig         `mapstructure:"Relayer"`
+
+	rollupsOnce sync.Once
+	rollups     *RollupRegistry
 }
 
 type L1Config struct {
@@ -50,6 +130,167 @@ type Telemetry struct {
 	PrometheusAddr string
 }
 
+// SignaturesConfig controls which signing schemes are accepted for txs
+type SignaturesConfig struct {
+	// AcceptLegacyUntil is the moment after which signatures over the legacy
+	// tx hash, which doesn't bind the rollup ID nor the L1 chain, are rejected.
+	// A zero value keeps accepting them during the migration to typed data signatures
+	AcceptLegacyUntil time.Time `mapstructure:"AcceptLegacyUntil"`
+}
+
+// AcceptsLegacy returns whether legacy signatures are still accepted at the given moment
+func (c SignaturesConfig) AcceptsLegacy(now time.Time) bool {
+	return c.AcceptLegacyUntil.IsZero() || now.Before(c.AcceptLegacyUntil)
+}
+
+// IntakeConfig controls the pipeline processing the txs received through interop_sendTx
+type IntakeConfig struct {
+	// Workers is the number of txs processed concurrently
+	Workers int `mapstructure:"Workers"`
+	// FrequencyToProcessTxs is how often the intake queue is polled for pending txs
+	FrequencyToProcessTxs types.Duration `mapstructure:"FrequencyToProcessTxs"`
+	// ProcessTimeout bounds the verification and settlement of a single tx
+	ProcessTimeout types.Duration `mapstructure:"ProcessTimeout"`
+	// MaxAttempts is how many times a tx is processed again after a failure unrelated to the tx, backing
+	// off exponentially from FrequencyToProcessTxs, before it's rejected with the last error. 0 retries forever
+	MaxAttempts int `mapstructure:"MaxAttempts"`
+}
+
+// RollupDiscoveryConfig controls the discovery of the rollups registered in the rollup manager
+type RollupDiscoveryConfig struct {
+	// Enabled rejects the txs of rollups that aren't registered in the rollup manager
+	Enabled bool `mapstructure:"Enabled"`
+	// FrequencyToPoll is how often the rollup manager events are polled
+	FrequencyToPoll types.Duration `mapstructure:"FrequencyToPoll"`
+	// MaxBlockRange is the maximum number of blocks whose events are requested at once
+	MaxBlockRange uint64 `mapstructure:"MaxBlockRange"`
+}
+
+// SequencerCacheConfig controls the cache of the trusted sequencers of the rollups
+type SequencerCacheConfig struct {
+	// TTL is how long a trusted sequencer is cached, 0 disables the cache
+	TTL types.Duration `mapstructure:"TTL"`
+	// FrequencyToPoll is how often the SetTrustedSequencer events of the cached rollups are polled
+	FrequencyToPoll types.Duration `mapstructure:"FrequencyToPoll"`
+	// MaxBlockRange is the maximum number of blocks whose events are requested at once
+	MaxBlockRange uint64 `mapstructure:"MaxBlockRange"`
+}
+
+// WebSocketConfig controls the server pushing the tx status changes to the interop_subscribe subscribers
+type WebSocketConfig struct {
+	// Enabled starts the WebSocket server next to the HTTP one
+	Enabled bool `mapstructure:"Enabled"`
+	// Host and Port the WebSocket server listens on
+	Host string `mapstructure:"Host"`
+	Port int    `mapstructure:"Port"`
+	// MaxSubscriptionsPerConn is the number of subscriptions a single connection can hold
+	MaxSubscriptionsPerConn int `mapstructure:"MaxSubscriptionsPerConn"`
+	// SubscriptionBuffer is the number of status changes queued for a subscription,
+	// subscriptions falling further behind are dropped
+	SubscriptionBuffer int `mapstructure:"SubscriptionBuffer"`
+	// MaxConnections is the number of connections served at once, 0 means unlimited
+	MaxConnections int `mapstructure:"MaxConnections"`
+	// ReadLimit is the maximum size in bytes of a message read from a connection, larger ones close it
+	ReadLimit int64 `mapstructure:"ReadLimit"`
+	// AllowedOrigins are the origins browsers may connect from, "*" allows any. Without any only
+	// the connections with no Origin header or one matching the host of the server are accepted
+	AllowedOrigins []string `mapstructure:"AllowedOrigins"`
+}
+
+// AdminConfig controls the server of the admin namespace operating the tx manager, which
+// requires either the token of an operator or a client certificate signed by ClientCAFile
+type AdminConfig struct {
+	// Enabled starts the admin server on its own listener
+	Enabled bool `mapstructure:"Enabled"`
+	// Host and Port the admin server listens on
+	Host string `mapstructure:"Host"`
+	Port int    `mapstructure:"Port"`
+	// ReadTimeout and WriteTimeout bound the requests to the admin server
+	ReadTimeout  types.Duration `mapstructure:"ReadTimeout"`
+	WriteTimeout types.Duration `mapstructure:"WriteTimeout"`
+	// Operators are authenticated by their bearer token
+	Operators []AdminOperator `mapstructure:"Operators"`
+	// TLSCertFile and TLSKeyFile serve the admin namespace over TLS
+	TLSCertFile string `mapstructure:"TLSCertFile"`
+	TLSKeyFile  string `mapstructure:"TLSKeyFile"`
+	// ClientCAFile authenticates the operators by their client certificate, identified by
+	// its common name. It requires TLS
+	ClientCAFile string `mapstructure:"ClientCAFile"`
+}
+
+// AdminOperator is an operator of the admin namespace, its name is recorded in the audit table
+type AdminOperator struct {
+	Name  string `mapstructure:"Name"`
+	Token string `mapstructure:"Token"`
+}
+
+// AuthConfig authenticates the callers of interop_sendTx, every credential being scoped to a rollup
+type AuthConfig struct {
+	// Enabled rejects the txs sent without a credential scoped to their rollup
+	Enabled bool `mapstructure:"Enabled"`
+	// APIKeys are sent as a bearer token in the Authorization header
+	APIKeys []RollupAPIKey `mapstructure:"APIKeys"`
+	// TLSHost and TLSPort serve interop_sendTx over TLS when TLSCertFile and TLSKeyFile are set
+	TLSHost     string `mapstructure:"TLSHost"`
+	TLSPort     int    `mapstructure:"TLSPort"`
+	TLSCertFile string `mapstructure:"TLSCertFile"`
+	TLSKeyFile  string `mapstructure:"TLSKeyFile"`
+	// ClientCAFile verifies the client certificates, whose common name is mapped to a rollup by ClientCerts
+	ClientCAFile string             `mapstructure:"ClientCAFile"`
+	ClientCerts  []RollupClientCert `mapstructure:"ClientCerts"`
+}
+
+// RollupAPIKey is an API key allowed to send the txs of a rollup
+type RollupAPIKey struct {
+	RollupID uint32 `mapstructure:"RollupID"`
+	Key      string `mapstructure:"Key"`
+}
+
+// RollupClientCert is a client certificate, identified by its common name, allowed to send the txs of a rollup
+type RollupClientCert struct {
+	RollupID   uint32 `mapstructure:"RollupID"`
+	CommonName string `mapstructure:"CommonName"`
+}
+
+// ProofSourceType is where the relayer fetches the proofs from
+type ProofSourceType string
+
+const (
+	// ProofSourceDir reads the proofs from the files of a directory
+	ProofSourceDir ProofSourceType = "dir"
+	// ProofSourceHTTP fetches the proofs from an HTTP endpoint
+	ProofSourceHTTP ProofSourceType = "http"
+)
+
+// RelayerConfig configures the relayer submitting the proofs of a rollup to the agglayer,
+// run with the relayer command rather than next to the agglayer
+type RelayerConfig struct {
+	// RollupID is the rollup the proofs are relayed for
+	RollupID uint32 `mapstructure:"RollupID"`
+	// NodeURL is the zkEVM node of the rollup the roots of the batches are read from
+	NodeURL string `mapstructure:"NodeURL"`
+	// AggLayerURL is the RPC of the agglayer the txs are sent to, APIKey authenticates them if set
+	AggLayerURL string `mapstructure:"AggLayerURL"`
+	APIKey      string `mapstructure:"APIKey"`
+	// FrequencyToPoll is how often the zkEVM node, the proofs and the tx in flight are polled
+	FrequencyToPoll types.Duration `mapstructure:"FrequencyToPoll"`
+	// ProofSource is where the proofs are fetched from, a directory or an HTTP endpoint
+	ProofSource ProofSourceType `mapstructure:"ProofSource"`
+	// ProofDir holds a <lastVerifiedBatch>.json file per proof when ProofSource is dir
+	ProofDir string `mapstructure:"ProofDir"`
+	// ProofURL serves the proofs at <ProofURL>/<lastVerifiedBatch> when ProofSource is http
+	ProofURL string `mapstructure:"ProofURL"`
+	// StatePath is the file the progress of the relayer is persisted to, to resume on restart
+	StatePath string `mapstructure:"StatePath"`
+	// MaxRejections is how many times in a row a batch range is sent again after being rejected,
+	// backing off between attempts, before the relayer stops until L1 moves past the range
+	MaxRejections uint64 `mapstructure:"MaxRejections"`
+	// PrivateKey or else KMSKeyName signs the txs, with the domain of [L1]
+	PrivateKey           types.KeystoreFileConfig `mapstructure:"PrivateKey"`
+	KMSKeyName           string                   `mapstructure:"KMSKeyName"`
+	KMSConnectionTimeout types.Duration           `mapstructure:"KMSConnectionTimeout"`
+}
+
 type EthTxManagerConfig struct {
 	ethtxmanager.Config  `mapstructure:",squash"`
 	GasOffset            uint64         `mapstructure:"GasOffset"`
@@ -94,8 +335,40 @@ func Load(ctx *cli.Context) (*Config, error) {
 		// this allows arrays to be decoded from env var separated by ",", example: MY_VAR="value1,value2,value3"
 		viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(mapstructure.TextUnmarshallerHookFunc(), mapstructure.StringToSliceHookFunc(","))),
 	}
-	err = viper.Unmarshal(&cfg, decodeHooks...)
-	return cfg, err
+	if err = viper.Unmarshal(&cfg, decodeHooks...); err != nil {
+		return nil, err
+	}
+
+	if err = cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// MinRetryBackoff is the lowest RetryBackoff of the zkEVM clients, so a full node
+// that is down isn't hammered with retries
+const MinRetryBackoff = 10 * time.Millisecond
+
+// validate rejects the settings that can't work, so they fail at startup rather than on the first tx
+func (c *Config) validate() error {
+	if c.ZkEVMClient.MaxRetries > 0 && c.ZkEVMClient.RetryBackoff.Duration < MinRetryBackoff {
+		return fmt.Errorf("ZkEVMClient.RetryBackoff: %s is below the minimum of %s", c.ZkEVMClient.RetryBackoff.Duration, MinRetryBackoff)
+	}
+
+	for rollupID, soundness := range c.Soundness {
+		switch soundness.Mode {
+		case SoundnessModeFullNode, SoundnessModeNone, "":
+		case SoundnessModeQuorum:
+			if soundness.Quorum < 1 || soundness.Quorum > len(soundness.RPCs) {
+				return fmt.Errorf("Soundness.%d: quorum %d must be between 1 and its %d RPCs", rollupID, soundness.Quorum, len(soundness.RPCs))
+			}
+		default:
+			return fmt.Errorf("Soundness.%d: unknown mode %q", rollupID, soundness.Mode)
+		}
+	}
+
+	return nil
 }
 
 // NewKeyFromKeystore creates a private key from a keystore file
@@ -2,6 +2,7 @@ package config
 
 import (
 	"flag"
+	"strings"
 	"testing"
 
 	"github.com/mitchellh/mapstructure"
@@ -56,4 +57,128 @@ func TestLoad(t *testing.T) {
 		require.NotNil(t, cfg)
 		require.Equal(t, ethTxManagerCfg, cfg.EthTxManager)
 	})
+	t.Run("soundness config", func(t *testing.T) {
+		v := viper.New()
+		v.SetConfigType("toml")
+		err := v.ReadConfig(strings.NewReader(`
+[Soundness]
+	[Soundness.1]
+		Mode = "quorum"
+		RPCs = ["http://a", "http://b", "http://c"]
+		Quorum = 2
+	[Soundness.2]
+		Mode = "none"
+`))
+		require.NoError(t, err)
+
+		var cfg Config
+		err = v.Unmarshal(&cfg, viper.DecodeHook(mapstructure.TextUnmarshallerHookFunc()))
+		require.NoError(t, err)
+		require.Equal(t, Soundness{
+			1: {Mode: SoundnessModeQuorum, RPCs: []string{"http://a", "http://b", "http://c"}, Quorum: 2},
+			2: {Mode: SoundnessModeNone},
+		}, cfg.Soundness)
+	})
+	t.Run("full node RPCs", func(t *testing.T) {
+		v := viper.New()
+		v.SetConfigType("toml")
+		err := v.ReadConfig(strings.NewReader(`
+[FullNodeRPCs]
+	1 = "http://a"
+	2 = ["http://b", "http://c"]
+`))
+		require.NoError(t, err)
+
+		var cfg Config
+		err = v.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+			mapstructure.TextUnmarshallerHookFunc(), mapstructure.StringToSliceHookFunc(","),
+		)))
+		require.NoError(t, err)
+		require.Equal(t, FullNodeRPCs{
+			1: {"http://a"},
+			2: {"http://b", "http://c"},
+		}, cfg.FullNodeRPCs)
+	})
+	t.Run("admin operators", func(t *testing.T) {
+		v := viper.New()
+		v.SetConfigType("toml")
+		err := v.ReadConfig(strings.NewReader(`
+[Admin]
+	Enabled = true
+	[[Admin.Operators]]
+		Name = "alice"
+		Token = "secret-a"
+	[[Admin.Operators]]
+		Name = "bob"
+		Token = "secret-b"
+`))
+		require.NoError(t, err)
+
+		var cfg Config
+		err = v.Unmarshal(&cfg, viper.DecodeHook(mapstructure.TextUnmarshallerHookFunc()))
+		require.NoError(t, err)
+		require.True(t, cfg.Admin.Enabled)
+		require.Equal(t, []AdminOperator{
+			{Name: "alice", Token: "secret-a"},
+			{Name: "bob", Token: "secret-b"},
+		}, cfg.Admin.Operators)
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		soundness   Soundness
+		zkEVMClient ZkEVMClientConfig
+		err         string
+	}{
+		{
+			name: "valid",
+			soundness: Soundness{
+				1: {Mode: SoundnessModeQuorum, RPCs: []string{"http://a", "http://b"}, Quorum: 2},
+				2: {Mode: SoundnessModeNone},
+				3: {Mode: SoundnessModeFullNode},
+			},
+		},
+		{
+			name:      "quorum above the RPCs",
+			soundness: Soundness{1: {Mode: SoundnessModeQuorum, RPCs: []string{"http://a"}, Quorum: 2}},
+			err:       "Soundness.1: quorum 2 must be between 1 and its 1 RPCs",
+		},
+		{
+			name:      "quorum without RPCs",
+			soundness: Soundness{1: {Mode: SoundnessModeQuorum}},
+			err:       "Soundness.1: quorum 0 must be between 1 and its 0 RPCs",
+		},
+		{
+			name:      "unknown mode",
+			soundness: Soundness{1: {Mode: "other"}},
+			err:       `Soundness.1: unknown mode "other"`,
+		},
+		{
+			name:        "retries without backoff",
+			zkEVMClient: ZkEVMClientConfig{MaxRetries: 3},
+			err:         "ZkEVMClient.RetryBackoff: 0s is below the minimum of 10ms",
+		},
+		{
+			name:        "no retries without backoff",
+			zkEVMClient: ZkEVMClientConfig{MaxRetries: 0},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := (&Config{Soundness: tc.soundness, ZkEVMClient: tc.zkEVMClient}).validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.err)
+			}
+		})
+	}
 }
@@ -12,6 +12,19 @@ const DefaultValues = `
 [FullNodeRPCs]
 	1 = "http://zkevm-node:8123"
 
+[FullNodes]
+	Strategy = "priority" # "priority" or "roundrobin"
+
+[ZkEVMClient]
+	Timeout = "10s"
+	MaxIdleConnsPerHost = 10
+	IdleConnTimeout = "90s"
+	MaxRetries = 3
+	RetryBackoff = "100ms"
+	MaxRetryBackoff = "2s"
+	BreakerThreshold = 5
+	BreakerCooldown = "30s"
+
 [RPC]
 	Host = "0.0.0.0"
 	Port = 4444
@@ -23,6 +36,13 @@ const DefaultValues = `
 [ProofSigners]
 #	1 = "0x0000000000000000000000000000000000000000"
 
+# Rollups without an entry are checked against their full node in FullNodeRPCs
+[Soundness]
+#	[Soundness.1]
+#		Mode = "quorum" # "fullnode", "quorum" or "none"
+#		RPCs = ["http://zkevm-node-1:8123", "http://zkevm-node-2:8123", "http://zkevm-node-3:8123"]
+#		Quorum = 2
+
 [Log]
 	Environment = "development" # "production" or "development"
 	Level = "debug"
@@ -58,6 +78,73 @@ const DefaultValues = `
 
 [Telemetry]
 	PrometheusAddr = "0.0.0.0:2223"
+
+# Legacy signatures don't bind the rollup ID nor the L1 chain, set a deadline to stop accepting them
+[Signatures]
+#	AcceptLegacyUntil = "2024-12-31T00:00:00Z"
+
+[Intake]
+	Workers = 10
+	FrequencyToProcessTxs = "1s"
+	ProcessTimeout = "60s"
+	MaxAttempts = 10
+
+# Reloads [FullNodeRPCs] and [ProofSigners] at runtime, from the config file or from the database
+[Registry]
+	Source = "none" # "none", "file" or "db"
+	FrequencyToPoll = "10s"
+
+# Keeps track of the rollups registered in the rollup manager to reject the txs of unknown ones
+[Discovery]
+	Enabled = false
+	FrequencyToPoll = "10s"
+	MaxBlockRange = 10000
+
+# Caches the trusted sequencers, which are still read from L1 right before settling a tx
+[SequencerCache]
+	TTL = "5m"
+	FrequencyToPoll = "5s"
+	MaxBlockRange = 10000
+
+# Pushes the tx status changes to the interop_subscribe subscribers
+[WebSocket]
+	Enabled = false
+	Host = "127.0.0.1"
+	Port = 4445
+	MaxSubscriptionsPerConn = 100
+	SubscriptionBuffer = 100
+	MaxConnections = 1000
+	ReadLimit = 32768
+	AllowedOrigins = []
+
+# Operates the eth tx manager, requires operator tokens or a client CA when enabled
+[Admin]
+	Enabled = false
+	Host = "127.0.0.1"
+	Port = 4446
+	ReadTimeout = "60s"
+	WriteTimeout = "60s"
+
+# Requires an API key or a client certificate scoped to the rollup of the txs sent
+[Auth]
+	Enabled = false
+	TLSHost = "0.0.0.0"
+	TLSPort = 4447
+
+# Submits the proofs of a rollup to the agglayer, run with the relayer command
+[Relayer]
+	RollupID = 1
+	NodeURL = "http://zkevm-node:8123"
+	AggLayerURL = "http://agglayer:4444"
+	FrequencyToPoll = "10s"
+	ProofSource = "dir" # "dir" or "http"
+	ProofDir = "/proofs"
+#	ProofURL = "http://zkevm-aggregator:8080/proofs"
+	StatePath = "/data/relayer.json"
+	MaxRejections = 3
+	PrivateKey = {Path = "/pk/relayer.keystore", Password = "testonly"}
+#	KMSKeyName = "gcp/resource/id"
+	KMSConnectionTimeout = "30s"
 `
 
 // Default parses the default configuration values.
@@ -69,7 +156,7 @@ func Default() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
-	err = viper.Unmarshal(&cfg, viper.DecodeHook(mapstructure.TextUnmarshallerHookFunc()))
+	err = viper.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(mapstructure.TextUnmarshallerHookFunc(), mapstructure.StringToSliceHookFunc(","))))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,289 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fsnotify/fsnotify"
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/viper"
+)
+
+// RegistrySource selects where the rollup registry is reloaded from at runtime
+type RegistrySource string
+
+const (
+	// RegistrySourceNone keeps the rollups of the config file loaded at startup
+	RegistrySourceNone RegistrySource = "none"
+	// RegistrySourceFile reloads the rollups when the config file changes
+	RegistrySourceFile RegistrySource = "file"
+	// RegistrySourceDB polls the rollups from the database, overriding the ones of the config file
+	RegistrySourceDB RegistrySource = "db"
+)
+
+// RegistryConfig controls how the rollup registry is reloaded at runtime
+type RegistryConfig struct {
+	Source RegistrySource `mapstructure:"Source"`
+	// FrequencyToPoll is how often the database is polled when Source is db
+	FrequencyToPoll types.Duration `mapstructure:"FrequencyToPoll"`
+}
+
+// RollupStore is a source of rollups other than the config file
+type RollupStore interface {
+	GetRollups(ctx context.Context) (FullNodeRPCs, ProofSigners, error)
+}
+
+// rollups is an immutable snapshot of the registry
+type rollups struct {
+	fullNodeRPCs FullNodeRPCs
+	proofSigners ProofSigners
+}
+
+// RollupRegistry holds the full nodes and the proof signers of the rollups. Reads are
+// lock free and updates replace the whole registry at once once validated
+type RollupRegistry struct {
+	// mu serializes the updates so each one is audited against the snapshot it replaces
+	mu       sync.Mutex
+	snapshot atomic.Pointer[rollups]
+}
+
+// NewRollupRegistry creates a registry with the given rollups, which aren't validated
+// as they're the ones of the config file the process started with
+func NewRollupRegistry(fullNodeRPCs FullNodeRPCs, proofSigners ProofSigners) *RollupRegistry {
+	r := &RollupRegistry{}
+	r.snapshot.Store(&rollups{
+		fullNodeRPCs: cloneFullNodeRPCs(fullNodeRPCs),
+		proofSigners: cloneProofSigners(proofSigners),
+	})
+
+	return r
+}
+
+// Rollups returns the registry of the rollups, seeded with FullNodeRPCs and ProofSigners
+// on first use. The maps of the config aren't updated when the registry is
+func (c *Config) Rollups() *RollupRegistry {
+	c.rollupsOnce.Do(func() {
+		c.rollups = NewRollupRegistry(c.FullNodeRPCs, c.ProofSigners)
+	})
+
+	return c.rollups
+}
+
+// FullNodeRPCs returns the URLs of the full nodes of the rollup
+func (r *RollupRegistry) FullNodeRPCs(rollupID uint32) ([]string, bool) {
+	urls, ok := r.snapshot.Load().fullNodeRPCs[rollupID]
+	return urls, ok
+}
+
+// ProofSigner returns the address authorized to sign the proofs of the rollup
+func (r *RollupRegistry) ProofSigner(rollupID uint32) (common.Address, bool) {
+	signer, ok := r.snapshot.Load().proofSigners[rollupID]
+	return signer, ok
+}
+
+// Update validates and replaces the rollups of the registry, each change is audited
+// along with the source it comes from. On error the registry is left untouched
+func (r *RollupRegistry) Update(source string, fullNodeRPCs FullNodeRPCs, proofSigners ProofSigners) error {
+	if err := validateRollups(fullNodeRPCs, proofSigners); err != nil {
+		return fmt.Errorf("invalid rollups from %s: %w", source, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next := &rollups{
+		fullNodeRPCs: cloneFullNodeRPCs(fullNodeRPCs),
+		proofSigners: cloneProofSigners(proofSigners),
+	}
+	audit(source, r.snapshot.Load(), next)
+	r.snapshot.Store(next)
+
+	return nil
+}
+
+func validateRollups(fullNodeRPCs FullNodeRPCs, proofSigners ProofSigners) error {
+	var errs []error
+	for rollupID, urls := range fullNodeRPCs {
+		if len(urls) == 0 {
+			errs = append(errs, fmt.Errorf("rollup %d has no full node", rollupID))
+		}
+		for _, rpc := range urls {
+			u, err := url.ParseRequestURI(rpc)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, fmt.Errorf("rollup %d has an invalid full node URL %q", rollupID, rpc))
+			}
+		}
+	}
+	for rollupID, signer := range proofSigners {
+		if signer == (common.Address{}) {
+			errs = append(errs, fmt.Errorf("rollup %d has the zero address as proof signer", rollupID))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// audit logs an entry for each rollup whose full nodes or proof signer change
+func audit(source string, prev, next *rollups) {
+	logger := log.WithFields("module", "registry", "audit", true, "source", source)
+
+	for _, rollupID := range rollupIDs(prev.fullNodeRPCs, next.fullNodeRPCs) {
+		before, after := prev.fullNodeRPCs[rollupID], next.fullNodeRPCs[rollupID]
+		if !slices.Equal(before, after) {
+			logger.Infof("full nodes of rollup %d changed from %v to %v", rollupID, before, after)
+		}
+	}
+	for _, rollupID := range rollupIDs(prev.proofSigners, next.proofSigners) {
+		before, beforeOk := prev.proofSigners[rollupID]
+		after, afterOk := next.proofSigners[rollupID]
+		switch {
+		case !beforeOk:
+			logger.Infof("proof signer of rollup %d set to %s", rollupID, after)
+		case !afterOk:
+			logger.Infof("proof signer %s of rollup %d removed", before, rollupID)
+		case before != after:
+			logger.Infof("proof signer of rollup %d changed from %s to %s", rollupID, before, after)
+		}
+	}
+}
+
+// rollupIDs returns the sorted union of the rollup IDs of both maps
+func rollupIDs[V any](prev, next map[uint32]V) []uint32 {
+	ids := make([]uint32, 0, len(next))
+	for rollupID := range prev {
+		ids = append(ids, rollupID)
+	}
+	for rollupID := range next {
+		if _, ok := prev[rollupID]; !ok {
+			ids = append(ids, rollupID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+func cloneFullNodeRPCs(fullNodeRPCs FullNodeRPCs) FullNodeRPCs {
+	clone := make(FullNodeRPCs, len(fullNodeRPCs))
+	for rollupID, urls := range fullNodeRPCs {
+		clone[rollupID] = slices.Clone(urls)
+	}
+
+	return clone
+}
+
+func cloneProofSigners(proofSigners ProofSigners) ProofSigners {
+	clone := make(ProofSigners, len(proofSigners))
+	for rollupID, signer := range proofSigners {
+		clone[rollupID] = signer
+	}
+
+	return clone
+}
+
+// WatchFile reloads the rollups of the registry whenever the config file loaded by
+// Load changes. Invalid changes are logged and ignored
+func (c *Config) WatchFile() {
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		if err := c.reloadFile(viper.GetViper()); err != nil {
+			log.Errorf("failed to reload the rollups from %s: %s", e.Name, err)
+		}
+	})
+	viper.WatchConfig()
+}
+
+func (c *Config) reloadFile(v *viper.Viper) error {
+	// the file is unmarshaled on top of the defaults, as Load does
+	defaults, err := Default()
+	if err != nil {
+		return err
+	}
+
+	reloaded := struct {
+		FullNodeRPCs FullNodeRPCs `mapstructure:"FullNodeRPCs"`
+		ProofSigners ProofSigners `mapstructure:"ProofSigners"`
+	}{
+		FullNodeRPCs: defaults.FullNodeRPCs,
+		ProofSigners: defaults.ProofSigners,
+	}
+
+	err = v.Unmarshal(&reloaded, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.TextUnmarshallerHookFunc(), mapstructure.StringToSliceHookFunc(","),
+	)))
+	if err != nil {
+		return err
+	}
+
+	return c.Rollups().Update(string(RegistrySourceFile), reloaded.FullNodeRPCs, reloaded.ProofSigners)
+}
+
+// PollDB reloads the rollups of the registry from the store until the context is done.
+// The rollups of the store override the ones of the config file
+func (c *Config) PollDB(ctx context.Context, store RollupStore) {
+	ticker := time.NewTicker(c.Registry.FrequencyToPoll.Duration)
+	defer ticker.Stop()
+
+	for {
+		if err := c.reloadDB(ctx, store); err != nil {
+			log.Errorf("failed to reload the rollups from the db: %s", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Config) reloadDB(ctx context.Context, store RollupStore) error {
+	fullNodeRPCs, proofSigners, err := store.GetRollups(ctx)
+	if err != nil {
+		return err
+	}
+
+	merged := cloneFullNodeRPCs(c.FullNodeRPCs)
+	for rollupID, urls := range fullNodeRPCs {
+		merged[rollupID] = urls
+	}
+	signers := cloneProofSigners(c.ProofSigners)
+	for rollupID, signer := range proofSigners {
+		signers[rollupID] = signer
+	}
+
+	if !c.Rollups().changed(merged, signers) {
+		return nil
+	}
+
+	return c.Rollups().Update(string(RegistrySourceDB), merged, signers)
+}
+
+// changed returns whether the given rollups differ from the ones of the registry
+func (r *RollupRegistry) changed(fullNodeRPCs FullNodeRPCs, proofSigners ProofSigners) bool {
+	current := r.snapshot.Load()
+	if len(current.fullNodeRPCs) != len(fullNodeRPCs) || len(current.proofSigners) != len(proofSigners) {
+		return true
+	}
+	for rollupID, urls := range fullNodeRPCs {
+		if !slices.Equal(current.fullNodeRPCs[rollupID], urls) {
+			return true
+		}
+	}
+	for rollupID, signer := range proofSigners {
+		if current, ok := current.proofSigners[rollupID]; !ok || current != signer {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+type rollupStoreMock struct {
+	fullNodeRPCs FullNodeRPCs
+	proofSigners ProofSigners
+	err          error
+}
+
+func (s *rollupStoreMock) GetRollups(ctx context.Context) (FullNodeRPCs, ProofSigners, error) {
+	return s.fullNodeRPCs, s.proofSigners, s.err
+}
+
+func TestRollupRegistry(t *testing.T) {
+	t.Run("seeded with the config", func(t *testing.T) {
+		cfg := &Config{
+			FullNodeRPCs: FullNodeRPCs{1: {"http://a"}},
+			ProofSigners: ProofSigners{1: common.HexToAddress("0x1")},
+		}
+
+		urls, ok := cfg.Rollups().FullNodeRPCs(1)
+		require.True(t, ok)
+		require.Equal(t, []string{"http://a"}, urls)
+
+		signer, ok := cfg.Rollups().ProofSigner(1)
+		require.True(t, ok)
+		require.Equal(t, common.HexToAddress("0x1"), signer)
+
+		_, ok = cfg.Rollups().FullNodeRPCs(2)
+		require.False(t, ok)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		r := NewRollupRegistry(FullNodeRPCs{1: {"http://a"}}, ProofSigners{1: common.HexToAddress("0x1")})
+
+		err := r.Update("test", FullNodeRPCs{2: {"http://b", "https://c"}}, ProofSigners{2: common.HexToAddress("0x2")})
+		require.NoError(t, err)
+
+		_, ok := r.FullNodeRPCs(1)
+		require.False(t, ok)
+		_, ok = r.ProofSigner(1)
+		require.False(t, ok)
+
+		urls, ok := r.FullNodeRPCs(2)
+		require.True(t, ok)
+		require.Equal(t, []string{"http://b", "https://c"}, urls)
+	})
+
+	t.Run("invalid update is rejected as a whole", func(t *testing.T) {
+		r := NewRollupRegistry(FullNodeRPCs{1: {"http://a"}}, nil)
+
+		for _, tc := range []struct {
+			name         string
+			fullNodeRPCs FullNodeRPCs
+			proofSigners ProofSigners
+			err          string
+		}{
+			{"no full node", FullNodeRPCs{2: {}}, nil, "rollup 2 has no full node"},
+			{"invalid URL", FullNodeRPCs{2: {"http://b", "zkevm-node:8123"}}, nil, `rollup 2 has an invalid full node URL "zkevm-node:8123"`},
+			{"zero proof signer", nil, ProofSigners{2: {}}, "rollup 2 has the zero address as proof signer"},
+		} {
+			err := r.Update("test", tc.fullNodeRPCs, tc.proofSigners)
+			require.ErrorContains(t, err, tc.err, tc.name)
+
+			urls, ok := r.FullNodeRPCs(1)
+			require.True(t, ok, tc.name)
+			require.Equal(t, []string{"http://a"}, urls, tc.name)
+		}
+	})
+
+	t.Run("reload from the config file", func(t *testing.T) {
+		cfg := &Config{FullNodeRPCs: FullNodeRPCs{1: {"http://a"}}}
+
+		v := viper.New()
+		v.SetConfigType("toml")
+		require.NoError(t, v.ReadConfig(strings.NewReader(`
+[FullNodeRPCs]
+	1 = "http://a"
+	2 = ["http://b", "http://c"]
+
+[ProofSigners]
+	2 = "0x0000000000000000000000000000000000000002"
+`)))
+
+		require.NoError(t, cfg.reloadFile(v))
+
+		urls, ok := cfg.Rollups().FullNodeRPCs(2)
+		require.True(t, ok)
+		require.Equal(t, []string{"http://b", "http://c"}, urls)
+
+		signer, ok := cfg.Rollups().ProofSigner(2)
+		require.True(t, ok)
+		require.Equal(t, common.HexToAddress("0x2"), signer)
+	})
+
+	t.Run("reload from the config file keeps the defaults", func(t *testing.T) {
+		defaults, err := Default()
+		require.NoError(t, err)
+		defaultURLs := defaults.FullNodeRPCs[1]
+		require.NotEmpty(t, defaultURLs)
+
+		cfg := &Config{FullNodeRPCs: defaults.FullNodeRPCs}
+
+		v := viper.New()
+		v.SetConfigType("toml")
+		require.NoError(t, v.ReadConfig(strings.NewReader(`
+[FullNodeRPCs]
+	2 = "http://b"
+`)))
+
+		require.NoError(t, cfg.reloadFile(v))
+
+		urls, ok := cfg.Rollups().FullNodeRPCs(1)
+		require.True(t, ok)
+		require.Equal(t, defaultURLs, urls)
+
+		urls, ok = cfg.Rollups().FullNodeRPCs(2)
+		require.True(t, ok)
+		require.Equal(t, []string{"http://b"}, urls)
+	})
+
+	t.Run("reload from the db overrides the config file", func(t *testing.T) {
+		cfg := &Config{
+			FullNodeRPCs: FullNodeRPCs{1: {"http://a"}, 2: {"http://b"}},
+			ProofSigners: ProofSigners{1: common.HexToAddress("0x1")},
+		}
+		store := &rollupStoreMock{
+			fullNodeRPCs: FullNodeRPCs{2: {"http://c"}},
+			proofSigners: ProofSigners{2: common.HexToAddress("0x2")},
+		}
+
+		require.NoError(t, cfg.reloadDB(context.Background(), store))
+
+		urls, _ := cfg.Rollups().FullNodeRPCs(1)
+		require.Equal(t, []string{"http://a"}, urls)
+		urls, _ = cfg.Rollups().FullNodeRPCs(2)
+		require.Equal(t, []string{"http://c"}, urls)
+
+		signer, _ := cfg.Rollups().ProofSigner(1)
+		require.Equal(t, common.HexToAddress("0x1"), signer)
+		signer, _ = cfg.Rollups().ProofSigner(2)
+		require.Equal(t, common.HexToAddress("0x2"), signer)
+
+		require.False(t, cfg.Rollups().changed(
+			FullNodeRPCs{1: {"http://a"}, 2: {"http://c"}},
+			ProofSigners{1: common.HexToAddress("0x1"), 2: common.HexToAddress("0x2")},
+		))
+	})
+
+	t.Run("db unavailable", func(t *testing.T) {
+		cfg := &Config{FullNodeRPCs: FullNodeRPCs{1: {"http://a"}}}
+
+		err := cfg.reloadDB(context.Background(), &rollupStoreMock{err: errors.New("error")})
+		require.ErrorContains(t, err, "error")
+
+		urls, _ := cfg.Rollups().FullNodeRPCs(1)
+		require.Equal(t, []string{"http://a"}, urls)
+	})
+}
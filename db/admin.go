@@ -0,0 +1,34 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygon/agglayer/types"
+	"github.com/jackc/pgx/v4"
+)
+
+// AddAdminAuditEntry persists an action performed through the admin namespace
+func (db *DB) AddAdminAuditEntry(ctx context.Context, entry types.AdminAuditEntry, dbTx pgx.Tx) error {
+	conn := db.dbConn(dbTx)
+	cmd := `
+        INSERT INTO state.admin_audit (actor, remote_addr, action, monitored_tx_id, params, error, created_at)
+                               VALUES (   $1,          $2,     $3,              $4,     $5,    $6,         $7)`
+
+	var monitoredTxID, params, errMsg *string
+	if entry.MonitoredTxID != "" {
+		monitoredTxID = &entry.MonitoredTxID
+	}
+	if len(entry.Params) > 0 {
+		p := string(entry.Params)
+		params = &p
+	}
+	if entry.Error != "" {
+		errMsg = &entry.Error
+	}
+
+	_, err := conn.Exec(ctx, cmd, entry.Actor, entry.RemoteAddr, entry.Action, monitoredTxID, params, errMsg,
+		time.Now().UTC().Round(time.Microsecond))
+
+	return err
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygon/agglayer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v4"
+)
+
+// UpsertDiscoveredRollup persists a rollup discovered from the rollup manager,
+// replacing the previous version of it
+func (db *DB) UpsertDiscoveredRollup(ctx context.Context, rollup types.Rollup, dbTx pgx.Tx) error {
+	conn := db.dbConn(dbTx)
+	cmd := `
+        INSERT INTO state.discovered_rollups (rollup_id, contract, chain_id, verifier, fork_id, rollup_type_id, rollup_compatibility_id, updated_at)
+                                      VALUES (       $1,       $2,       $3,       $4,      $5,             $6,                      $7,         $8)
+        ON CONFLICT (rollup_id) DO UPDATE
+           SET contract = EXCLUDED.contract
+             , chain_id = EXCLUDED.chain_id
+             , verifier = EXCLUDED.verifier
+             , fork_id = EXCLUDED.fork_id
+             , rollup_type_id = EXCLUDED.rollup_type_id
+             , rollup_compatibility_id = EXCLUDED.rollup_compatibility_id
+             , updated_at = EXCLUDED.updated_at`
+
+	_, err := conn.Exec(ctx, cmd, rollup.ID, rollup.Contract.String(), rollup.ChainID, rollup.Verifier.String(),
+		rollup.ForkID, rollup.RollupTypeID, rollup.RollupCompatibilityID, time.Now().UTC().Round(time.Microsecond))
+
+	return err
+}
+
+// GetDiscoveredRollups loads the rollups discovered from the rollup manager
+func (db *DB) GetDiscoveredRollups(ctx context.Context, dbTx pgx.Tx) ([]types.Rollup, error) {
+	conn := db.dbConn(dbTx)
+	cmd := `
+        SELECT rollup_id, contract, chain_id, verifier, fork_id, rollup_type_id, rollup_compatibility_id, updated_at
+          FROM state.discovered_rollups
+         ORDER BY rollup_id`
+
+	rows, err := conn.Query(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rollups := []types.Rollup{}
+	for rows.Next() {
+		var (
+			rollup             types.Rollup
+			contract, verifier string
+		)
+		err := rows.Scan(&rollup.ID, &contract, &rollup.ChainID, &verifier, &rollup.ForkID,
+			&rollup.RollupTypeID, &rollup.RollupCompatibilityID, &rollup.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		rollup.Contract = common.HexToAddress(contract)
+		rollup.Verifier = common.HexToAddress(verifier)
+		rollups = append(rollups, rollup)
+	}
+
+	return rollups, rows.Err()
+}
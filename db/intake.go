@@ -0,0 +1,197 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/0xPolygon/agglayer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// AddIntakeTx persists a tx in the intake queue. A previously rejected tx with the
+// same hash is queued again, otherwise ErrIntakeTxAlreadyExists is returned
+func (db *DB) AddIntakeTx(ctx context.Context, itx types.IntakeTx, dbTx pgx.Tx) error {
+	signedTx, err := json.Marshal(itx.SignedTx)
+	if err != nil {
+		return err
+	}
+
+	conn := db.dbConn(dbTx)
+	cmd := `
+        INSERT INTO state.intake_txs (hash, rollup_id, signed_tx, status, error, error_code, signer, signature_scheme, attempts, next_attempt_at, received_at, verified_at, settling_at, rejected_at, updated_at)
+                              VALUES (  $1,        $2,        $3,     $4, NULL,       NULL,   NULL,             NULL,        0,            NULL,          $5,        NULL,        NULL,        NULL,         $6)
+        ON CONFLICT (hash) DO UPDATE
+           SET signed_tx = EXCLUDED.signed_tx
+             , status = EXCLUDED.status
+             , error = NULL
+             , error_code = NULL
+             , signer = NULL
+             , signature_scheme = NULL
+             , attempts = 0
+             , next_attempt_at = NULL
+             , received_at = EXCLUDED.received_at
+             , verified_at = NULL
+             , settling_at = NULL
+             , rejected_at = NULL
+             , updated_at = EXCLUDED.updated_at
+         WHERE state.intake_txs.status = $7`
+
+	tag, err := conn.Exec(ctx, cmd, itx.Hash.String(), itx.SignedTx.Tx.RollupID, signedTx,
+		itx.Status.String(), itx.ReceivedAt, itx.UpdatedAt, types.IntakeTxStatusRejected.String())
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return types.ErrIntakeTxAlreadyExists
+	}
+
+	return nil
+}
+
+// GetIntakeTx loads a tx from the intake queue
+func (db *DB) GetIntakeTx(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (types.IntakeTx, error) {
+	conn := db.dbConn(dbTx)
+	cmd := `
+        SELECT hash, signed_tx, status, error, error_code, signer, signature_scheme, attempts, next_attempt_at, received_at, verified_at, settling_at, rejected_at, updated_at
+          FROM state.intake_txs
+         WHERE hash = $1`
+
+	itx := types.IntakeTx{}
+
+	row := conn.QueryRow(ctx, cmd, hash.String())
+	err := scanIntakeTx(row, &itx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return itx, types.ErrIntakeTxNotFound
+	} else if err != nil {
+		return itx, err
+	}
+
+	return itx, nil
+}
+
+// GetIntakeTxsByStatus loads the txs in the intake queue that match the provided
+// statuses, oldest first
+func (db *DB) GetIntakeTxsByStatus(ctx context.Context, statuses []types.IntakeTxStatus, dbTx pgx.Tx) ([]types.IntakeTx, error) {
+	conn := db.dbConn(dbTx)
+	cmd := `
+        SELECT hash, signed_tx, status, error, error_code, signer, signature_scheme, attempts, next_attempt_at, received_at, verified_at, settling_at, rejected_at, updated_at
+          FROM state.intake_txs
+         WHERE status = ANY($1)
+         ORDER BY received_at`
+
+	values := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		values = append(values, status.String())
+	}
+
+	rows, err := conn.Query(ctx, cmd, values)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	itxs := []types.IntakeTx{}
+	for rows.Next() {
+		itx := types.IntakeTx{}
+		if err := scanIntakeTx(rows, &itx); err != nil {
+			return nil, err
+		}
+		itxs = append(itxs, itx)
+	}
+
+	return itxs, rows.Err()
+}
+
+// UpdateIntakeTx updates the status of a tx in the intake queue
+func (db *DB) UpdateIntakeTx(ctx context.Context, itx types.IntakeTx, dbTx pgx.Tx) error {
+	conn := db.dbConn(dbTx)
+	cmd := `
+        UPDATE state.intake_txs
+           SET status = $2
+             , error = $3
+             , error_code = $4
+             , signer = $5
+             , signature_scheme = $6
+             , attempts = $7
+             , next_attempt_at = $8
+             , verified_at = $9
+             , settling_at = $10
+             , rejected_at = $11
+             , updated_at = $12
+         WHERE hash = $1`
+
+	var errMsg *string
+	if itx.Error != "" {
+		errMsg = &itx.Error
+	}
+
+	var errCode *int
+	if itx.ErrorCode != 0 {
+		errCode = &itx.ErrorCode
+	}
+
+	var signer, scheme *string
+	if itx.SignatureScheme != "" {
+		signerHex := itx.Signer.Hex()
+		signer, scheme = &signerHex, &itx.SignatureScheme
+	}
+
+	_, err := conn.Exec(ctx, cmd, itx.Hash.String(), itx.Status.String(), errMsg, errCode, signer, scheme,
+		itx.Attempts, itx.NextAttemptAt, itx.VerifiedAt, itx.SettlingAt, itx.RejectedAt, time.Now().UTC().Round(time.Microsecond))
+
+	return err
+}
+
+// scanIntakeTx scans a row and fill the provided instance of intake tx with the row data
+func scanIntakeTx(row pgx.Row, itx *types.IntakeTx) error {
+	var hash, status string
+	var signedTx []byte
+	var errMsg, signer, scheme *string
+	var errCode *int
+
+	err := row.Scan(&hash, &signedTx, &status, &errMsg, &errCode, &signer, &scheme, &itx.Attempts,
+		&itx.NextAttemptAt, &itx.ReceivedAt, &itx.VerifiedAt, &itx.SettlingAt, &itx.RejectedAt, &itx.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(signedTx, &itx.SignedTx); err != nil {
+		return err
+	}
+
+	itx.Hash = common.HexToHash(hash)
+	itx.Status = types.IntakeTxStatus(status)
+	if errMsg != nil {
+		itx.Error = *errMsg
+	}
+	if errCode != nil {
+		itx.ErrorCode = *errCode
+	}
+	if signer != nil && scheme != nil {
+		itx.Signer = common.HexToAddress(*signer)
+		itx.SignatureScheme = *scheme
+	}
+
+	return nil
+}
+
+// dbConn represents an instance of an object that can
+// connect to a postgres db to execute sql commands and query data
+type dbConn interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (commandTag pgconn.CommandTag, err error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// dbConn determines which db connection to use, dbTx or the main pgxpool
+func (db *DB) dbConn(dbTx pgx.Tx) dbConn {
+	if dbTx != nil {
+		return dbTx
+	}
+	return db.pg
+}
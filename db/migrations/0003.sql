@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE state.intake_txs
+(
+    hash        VARCHAR NOT NULL,
+    rollup_id   BIGINT NOT NULL,
+    signed_tx   JSONB NOT NULL,
+    status      VARCHAR NOT NULL,
+    error       VARCHAR,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    settling_at TIMESTAMP WITH TIME ZONE,
+    rejected_at TIMESTAMP WITH TIME ZONE,
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (hash)
+);
+
+CREATE INDEX intake_txs_status_received_at_idx ON state.intake_txs (status, received_at);
+
+-- +migrate Down
+DROP TABLE state.intake_txs;
@@ -0,0 +1,12 @@
+-- +migrate Up
+CREATE TABLE state.rollups
+(
+    rollup_id      BIGINT NOT NULL,
+    full_node_rpcs VARCHAR[] NOT NULL DEFAULT '{}',
+    proof_signer   VARCHAR,
+    updated_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (rollup_id)
+);
+
+-- +migrate Down
+DROP TABLE state.rollups;
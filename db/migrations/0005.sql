@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE state.discovered_rollups
+(
+    rollup_id               BIGINT NOT NULL,
+    contract                VARCHAR NOT NULL,
+    chain_id                BIGINT NOT NULL,
+    verifier                VARCHAR NOT NULL,
+    fork_id                 BIGINT NOT NULL,
+    rollup_type_id          BIGINT NOT NULL,
+    rollup_compatibility_id SMALLINT NOT NULL,
+    updated_at              TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (rollup_id)
+);
+
+-- +migrate Down
+DROP TABLE state.discovered_rollups;
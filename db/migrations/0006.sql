@@ -0,0 +1,25 @@
+-- +migrate Up
+ALTER TABLE state.monitored_txs
+ADD COLUMN rollup_id BIGINT,
+ADD COLUMN last_verified_batch BIGINT,
+ADD COLUMN new_verified_batch BIGINT;
+
+-- The monitored txs settling a tx received through the intake queue are identified by its hash
+UPDATE state.monitored_txs m
+   SET rollup_id = i.rollup_id
+     , last_verified_batch = ('x' || lpad(substring(i.signed_tx->'tx'->>'lastVerifiedBatch' FROM 3), 16, '0'))::bit(64)::bigint
+     , new_verified_batch = ('x' || lpad(substring(i.signed_tx->'tx'->>'newVerifiedBatch' FROM 3), 16, '0'))::bit(64)::bigint
+  FROM state.intake_txs i
+ WHERE m.id = i.hash;
+
+CREATE INDEX monitored_txs_owner_created_at_idx ON state.monitored_txs (owner, created_at, id);
+CREATE INDEX monitored_txs_owner_rollup_id_created_at_idx ON state.monitored_txs (owner, rollup_id, created_at, id);
+
+-- +migrate Down
+DROP INDEX state.monitored_txs_owner_rollup_id_created_at_idx;
+DROP INDEX state.monitored_txs_owner_created_at_idx;
+
+ALTER TABLE state.monitored_txs
+DROP COLUMN rollup_id,
+DROP COLUMN last_verified_batch,
+DROP COLUMN new_verified_batch;
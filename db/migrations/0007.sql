@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE state.admin_audit
+(
+    id              BIGSERIAL PRIMARY KEY,
+    actor           VARCHAR NOT NULL,
+    remote_addr     VARCHAR NOT NULL,
+    action          VARCHAR NOT NULL,
+    monitored_tx_id VARCHAR,
+    params          JSONB,
+    error           VARCHAR,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX admin_audit_monitored_tx_id_idx ON state.admin_audit (monitored_tx_id);
+
+-- +migrate Down
+DROP TABLE state.admin_audit;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE state.intake_txs ADD COLUMN error_code INTEGER;
+
+-- +migrate Down
+ALTER TABLE state.intake_txs DROP COLUMN error_code;
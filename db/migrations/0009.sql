@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE state.intake_txs ADD COLUMN signer VARCHAR;
+ALTER TABLE state.intake_txs ADD COLUMN signature_scheme VARCHAR;
+
+-- +migrate Down
+ALTER TABLE state.intake_txs DROP COLUMN signature_scheme;
+ALTER TABLE state.intake_txs DROP COLUMN signer;
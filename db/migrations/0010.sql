@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE state.intake_txs ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE state.intake_txs ADD COLUMN next_attempt_at TIMESTAMP WITH TIME ZONE;
+
+-- +migrate Down
+ALTER TABLE state.intake_txs DROP COLUMN next_attempt_at;
+ALTER TABLE state.intake_txs DROP COLUMN attempts;
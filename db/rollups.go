@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ config.RollupStore = (*DB)(nil)
+
+// GetRollups loads the full nodes and proof signers of the rollups registered in the
+// database. Rollups without full nodes or without a proof signer are left out of the
+// corresponding map so the config file still applies to them
+func (db *DB) GetRollups(ctx context.Context) (config.FullNodeRPCs, config.ProofSigners, error) {
+	conn := db.dbConn(nil)
+	cmd := `
+        SELECT rollup_id, full_node_rpcs, proof_signer
+          FROM state.rollups
+         ORDER BY rollup_id`
+
+	rows, err := conn.Query(ctx, cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	fullNodeRPCs := config.FullNodeRPCs{}
+	proofSigners := config.ProofSigners{}
+	for rows.Next() {
+		var (
+			rollupID    uint32
+			urls        []string
+			proofSigner *string
+		)
+		if err := rows.Scan(&rollupID, &urls, &proofSigner); err != nil {
+			return nil, nil, err
+		}
+
+		if len(urls) > 0 {
+			fullNodeRPCs[rollupID] = urls
+		}
+		if proofSigner != nil {
+			proofSigners[rollupID] = common.HexToAddress(*proofSigner)
+		}
+	}
+
+	return fullNodeRPCs, proofSigners, rows.Err()
+}
@@ -0,0 +1,239 @@
+package etherman
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/agglayer/config"
+	agglayerTypes "github.com/0xPolygon/agglayer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+var _ agglayerTypes.IRollupDiscovery = (*RollupDiscovery)(nil)
+
+// RollupDiscovery keeps track of the rollups registered in the rollup manager. All of
+// them are enumerated on start and then kept up to date from the rollup manager events,
+// each change is persisted so known rollups are available before the first sync
+type RollupDiscovery struct {
+	logger        *zap.SugaredLogger
+	cfg           config.RollupDiscoveryConfig
+	rollupManager common.Address
+	etherman      agglayerTypes.IEtherman
+	db            agglayerTypes.IDB
+
+	mu        sync.RWMutex
+	rollups   map[uint32]agglayerTypes.Rollup
+	count     uint32
+	synced    bool
+	lastBlock uint64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewRollupDiscovery returns a discovery of the rollups of the configured rollup manager
+func NewRollupDiscovery(
+	logger *zap.SugaredLogger,
+	cfg *config.Config,
+	etherman agglayerTypes.IEtherman,
+	db agglayerTypes.IDB,
+) *RollupDiscovery {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &RollupDiscovery{
+		logger:        logger,
+		cfg:           cfg.Discovery,
+		rollupManager: cfg.L1.RollupManagerContract,
+		etherman:      etherman,
+		db:            db,
+		rollups:       make(map[uint32]agglayerTypes.Rollup),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start loads the persisted rollups, enumerates the ones of the rollup manager and
+// then polls its events until the discovery is stopped
+func (d *RollupDiscovery) Start() {
+	if err := d.load(); err != nil {
+		d.logger.Errorf("failed to load the discovered rollups: %s", err)
+	}
+
+	for {
+		var err error
+		if d.isSynced() {
+			err = d.poll()
+		} else {
+			err = d.sync()
+		}
+		if err != nil {
+			d.logger.Errorf("failed to discover rollups: %s", err)
+		}
+
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-time.After(d.cfg.FrequencyToPoll.Duration):
+		}
+	}
+}
+
+// Stop stops polling the rollup manager
+func (d *RollupDiscovery) Stop() {
+	d.cancel()
+}
+
+// Rollup returns the rollup registered in the rollup manager with the given ID
+func (d *RollupDiscovery) Rollup(rollupID uint32) (agglayerTypes.Rollup, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if rollup, ok := d.rollups[rollupID]; ok {
+		return rollup, nil
+	}
+
+	if !d.synced {
+		return agglayerTypes.Rollup{}, fmt.Errorf("%w, rollup %d can't be checked", agglayerTypes.ErrRollupsNotSynced, rollupID)
+	}
+
+	return agglayerTypes.Rollup{}, fmt.Errorf(
+		"%w: rollup %d is not registered in the rollup manager %s, which has %d rollups as of block %d",
+		agglayerTypes.ErrUnknownRollup,
+		rollupID,
+		d.rollupManager,
+		d.count,
+		d.lastBlock,
+	)
+}
+
+func (d *RollupDiscovery) isSynced() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.synced
+}
+
+// load fills the rollups with the ones persisted by previous runs
+func (d *RollupDiscovery) load() error {
+	rollups, err := d.db.GetDiscoveredRollups(d.ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, rollup := range rollups {
+		d.store(rollup)
+	}
+
+	return nil
+}
+
+// sync enumerates all the rollups of the rollup manager
+func (d *RollupDiscovery) sync() error {
+	// the block is taken first so the events emitted during the enumeration are polled afterwards
+	block, err := d.etherman.GetFinalizedBlock(d.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the finalized L1 block: %w", err)
+	}
+
+	count, err := d.etherman.GetRollupCount()
+	if err != nil {
+		return err
+	}
+
+	for rollupID := uint32(1); rollupID <= count; rollupID++ {
+		if err := d.refresh(rollupID); err != nil {
+			return err
+		}
+	}
+
+	d.mu.Lock()
+	d.synced = true
+	d.lastBlock = block.BlockNumber
+	d.mu.Unlock()
+
+	d.logger.Infof("discovered %d rollups as of block %d", count, block.BlockNumber)
+
+	return nil
+}
+
+// poll refreshes the rollups the rollup manager emitted events for since the last poll, up to
+// the finalized block so the events of a reorged block are never missed
+func (d *RollupDiscovery) poll() error {
+	block, err := d.etherman.GetFinalizedBlock(d.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the finalized L1 block: %w", err)
+	}
+
+	d.mu.RLock()
+	fromBlock := d.lastBlock + 1
+	d.mu.RUnlock()
+
+	if block.BlockNumber < fromBlock {
+		return nil
+	}
+
+	toBlock := block.BlockNumber
+	if d.cfg.MaxBlockRange > 0 && toBlock-fromBlock+1 > d.cfg.MaxBlockRange {
+		toBlock = fromBlock + d.cfg.MaxBlockRange - 1
+	}
+
+	rollupIDs, err := d.etherman.GetUpdatedRollups(d.ctx, fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+
+	for _, rollupID := range rollupIDs {
+		if err := d.refresh(rollupID); err != nil {
+			return err
+		}
+	}
+
+	d.mu.Lock()
+	d.lastBlock = toBlock
+	d.mu.Unlock()
+
+	return nil
+}
+
+// refresh reads the rollup from the rollup manager and persists it
+func (d *RollupDiscovery) refresh(rollupID uint32) error {
+	rollup, err := d.etherman.GetRollup(rollupID)
+	if err != nil {
+		return err
+	}
+
+	if err := d.db.UpsertDiscoveredRollup(d.ctx, rollup, nil); err != nil {
+		return fmt.Errorf("failed to persist rollup %d: %w", rollupID, err)
+	}
+
+	if previous, ok := d.lookup(rollupID); !ok {
+		d.logger.Infof("discovered rollup %d, contract %s, chain ID %d, fork ID %d", rollup.ID, rollup.Contract, rollup.ChainID, rollup.ForkID)
+	} else if previous.ForkID != rollup.ForkID || previous.RollupTypeID != rollup.RollupTypeID || previous.Verifier != rollup.Verifier {
+		d.logger.Infof("rollup %d upgraded to rollup type %d, fork ID %d", rollup.ID, rollup.RollupTypeID, rollup.ForkID)
+	}
+
+	d.store(rollup)
+
+	return nil
+}
+
+func (d *RollupDiscovery) lookup(rollupID uint32) (agglayerTypes.Rollup, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	rollup, ok := d.rollups[rollupID]
+	return rollup, ok
+}
+
+func (d *RollupDiscovery) store(rollup agglayerTypes.Rollup) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.rollups[rollup.ID] = rollup
+	if rollup.ID > d.count {
+		d.count = rollup.ID
+	}
+}
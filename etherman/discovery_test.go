@@ -0,0 +1,114 @@
+package etherman
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	agglayerTypes "github.com/0xPolygon/agglayer/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupDiscovery(t *testing.T) {
+	t.Parallel()
+
+	newDiscovery := func(t *testing.T) (*RollupDiscovery, *mocks.EthermanMock, *mocks.DBMock) {
+		t.Helper()
+
+		etherman := mocks.NewEthermanMock(t)
+		db := mocks.NewDBMock(t)
+		cfg := &config.Config{Discovery: config.RollupDiscoveryConfig{Enabled: true, MaxBlockRange: 100}}
+
+		return NewRollupDiscovery(log.WithFields("test", "test"), cfg, etherman, db), etherman, db
+	}
+
+	rollup := func(rollupID uint32, forkID uint64) agglayerTypes.Rollup {
+		return agglayerTypes.Rollup{ID: rollupID, Contract: common.BigToAddress(common.Big1), ChainID: 1000 + uint64(rollupID), ForkID: forkID}
+	}
+
+	t.Run("not synced", func(t *testing.T) {
+		t.Parallel()
+
+		d, _, _ := newDiscovery(t)
+
+		_, err := d.Rollup(1)
+		require.ErrorIs(t, err, agglayerTypes.ErrRollupsNotSynced)
+	})
+
+	t.Run("persisted rollups are known before the sync", func(t *testing.T) {
+		t.Parallel()
+
+		d, _, db := newDiscovery(t)
+		db.On("GetDiscoveredRollups", mock.Anything, nil).Return([]agglayerTypes.Rollup{rollup(1, 7)}, nil).Once()
+
+		require.NoError(t, d.load())
+
+		result, err := d.Rollup(1)
+		require.NoError(t, err)
+		require.Equal(t, rollup(1, 7), result)
+	})
+
+	t.Run("sync enumerates the rollups", func(t *testing.T) {
+		t.Parallel()
+
+		d, etherman, db := newDiscovery(t)
+		etherman.On("GetFinalizedBlock", mock.Anything).Return(&state.Block{BlockNumber: 50}, nil).Once()
+		etherman.On("GetRollupCount").Return(uint32(2), nil).Once()
+		etherman.On("GetRollup", uint32(1)).Return(rollup(1, 7), nil).Once()
+		etherman.On("GetRollup", uint32(2)).Return(rollup(2, 8), nil).Once()
+		db.On("UpsertDiscoveredRollup", mock.Anything, mock.Anything, nil).Return(nil).Twice()
+
+		require.NoError(t, d.sync())
+
+		result, err := d.Rollup(2)
+		require.NoError(t, err)
+		require.Equal(t, rollup(2, 8), result)
+
+		_, err = d.Rollup(3)
+		require.ErrorIs(t, err, agglayerTypes.ErrUnknownRollup)
+		require.ErrorContains(t, err, "rollup 3 is not registered in the rollup manager")
+		require.ErrorContains(t, err, "which has 2 rollups as of block 50")
+	})
+
+	t.Run("poll refreshes the rollups with events", func(t *testing.T) {
+		t.Parallel()
+
+		d, etherman, db := newDiscovery(t)
+		d.synced, d.lastBlock = true, 50
+		d.store(rollup(1, 7))
+
+		etherman.On("GetFinalizedBlock", mock.Anything).Return(&state.Block{BlockNumber: 500}, nil).Once()
+		etherman.On("GetUpdatedRollups", mock.Anything, uint64(51), uint64(150)).Return([]uint32{1, 2}, nil).Once()
+		etherman.On("GetRollup", uint32(1)).Return(rollup(1, 9), nil).Once()
+		etherman.On("GetRollup", uint32(2)).Return(rollup(2, 9), nil).Once()
+		db.On("UpsertDiscoveredRollup", mock.Anything, mock.Anything, nil).Return(nil).Twice()
+
+		require.NoError(t, d.poll())
+		require.Equal(t, uint64(150), d.lastBlock)
+
+		result, err := d.Rollup(1)
+		require.NoError(t, err)
+		require.Equal(t, uint64(9), result.ForkID)
+
+		_, err = d.Rollup(2)
+		require.NoError(t, err)
+	})
+
+	t.Run("failed poll is retried from the same block", func(t *testing.T) {
+		t.Parallel()
+
+		d, etherman, _ := newDiscovery(t)
+		d.synced, d.lastBlock = true, 50
+
+		etherman.On("GetFinalizedBlock", mock.Anything).Return(&state.Block{BlockNumber: 60}, nil).Once()
+		etherman.On("GetUpdatedRollups", mock.Anything, uint64(51), uint64(60)).Return(nil, errors.New("error")).Once()
+
+		require.Error(t, d.poll())
+		require.Equal(t, uint64(50), d.lastBlock)
+	})
+}
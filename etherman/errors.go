@@ -21,4 +21,8 @@ var (
 	ErrNoSigner = errors.New("no signer to authorize the transaction with")
 	// ErrMissingTrieNode means that a node is missing on the trie
 	ErrMissingTrieNode = errors.New("missing trie node")
+	// ErrPendingStateNotFound the pending state doesn't exist on L1
+	ErrPendingStateNotFound = errors.New("pending state not found")
+	// ErrPendingStateBatchMismatch the pending state doesn't end at the last verified batch of the tx
+	ErrPendingStateBatchMismatch = errors.New("pending state doesn't match the last verified batch")
 )
@@ -9,6 +9,7 @@ import (
 
 	"github.com/0xPolygon/agglayer/config"
 	"github.com/0xPolygon/agglayer/tx"
+	agglayerTypes "github.com/0xPolygon/agglayer/types"
 
 	"github.com/0xPolygon/agglayer/log"
 	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygonrollupmanager"
@@ -18,7 +19,9 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/jackc/pgx/v4"
 )
 
@@ -28,19 +31,22 @@ const (
 )
 
 type Etherman struct {
-	ethClient IEthereumClient
-	auth      bind.TransactOpts
-	config    *config.Config
+	ethClient  IEthereumClient
+	auth       bind.TransactOpts
+	config     *config.Config
+	sequencers *sequencerCache
 }
 
 func New(ethClient IEthereumClient, auth bind.TransactOpts, cfg *config.Config) (Etherman, error) {
 	return Etherman{
-		ethClient: ethClient,
-		auth:      auth,
-		config:    cfg,
+		ethClient:  ethClient,
+		auth:       auth,
+		config:     cfg,
+		sequencers: newSequencerCache(cfg.SequencerCache.TTL.Duration),
 	}, nil
 }
 
+// GetSequencerAddr returns the trusted sequencer of the rollup, which may come from the cache
 func (e *Etherman) GetSequencerAddr(rollupId uint32) (common.Address, error) {
 	address, err := e.getTrustedSequencerAddress(rollupId)
 	if err != nil {
@@ -51,11 +57,30 @@ func (e *Etherman) GetSequencerAddr(rollupId uint32) (common.Address, error) {
 	return address, nil
 }
 
+// GetCachedSequencerAddr returns the trusted sequencer of the rollup only if it's cached,
+// for the checks that must not query L1
+func (e *Etherman) GetCachedSequencerAddr(rollupId uint32) (common.Address, bool) {
+	return e.sequencers.get(rollupId)
+}
+
+// GetFreshSequencerAddr returns the trusted sequencer of the rollup as currently set on L1,
+// for the checks that can't rely on a sequencer which may have been rotated meanwhile
+func (e *Etherman) GetFreshSequencerAddr(rollupId uint32) (common.Address, error) {
+	address, err := e.readTrustedSequencerAddress(rollupId)
+	if err != nil {
+		log.Errorf("error requesting the 'TrustedSequencer' address: %s", err)
+		return common.Address{}, err
+	}
+
+	return address, nil
+}
+
 func (e *Etherman) BuildTrustedVerifyBatchesTxData(
 	lastVerifiedBatch,
 	newVerifiedBatch uint64,
 	proof tx.ZKP,
 	rollupId uint32,
+	pendingStateNum uint64,
 ) (data []byte, err error) {
 	var newLocalExitRoot [HashLength]byte
 	copy(newLocalExitRoot[:], proof.NewLocalExitRoot.Bytes())
@@ -67,7 +92,13 @@ func (e *Etherman) BuildTrustedVerifyBatchesTxData(
 		return nil, err
 	}
 
-	const pendStateNum uint64 = 0 // TODO hardcoded for now until we implement the pending state feature
+	if pendingStateNum != 0 {
+		if err = e.checkPendingState(rollupId, pendingStateNum, lastVerifiedBatch); err != nil {
+			log.Errorf("error checking pending state: %v", err)
+			return nil, err
+		}
+	}
+
 	abi, err := polygonrollupmanager.PolygonrollupmanagerMetaData.GetAbi()
 	if err != nil {
 		log.Errorf("error geting ABI: %v, Proof: %s", err)
@@ -83,7 +114,7 @@ func (e *Etherman) BuildTrustedVerifyBatchesTxData(
 	return abi.Pack(
 		"verifyBatchesTrustedAggregator",
 		rollupId,
-		pendStateNum,
+		pendingStateNum,
 		lastVerifiedBatch,
 		newVerifiedBatch,
 		newLocalExitRoot,
@@ -93,8 +124,182 @@ func (e *Etherman) BuildTrustedVerifyBatchesTxData(
 	)
 }
 
+// checkPendingState makes sure the batches can be verified from the given pending state, which has
+// to exist on L1, consolidated or not as the rollup manager accepts both, and end at the last verified batch of the tx
+func (e *Etherman) checkPendingState(rollupId uint32, pendingStateNum, lastVerifiedBatch uint64) error {
+	contract, err := polygonrollupmanager.NewPolygonrollupmanager(e.config.L1.RollupManagerContract, e.ethClient)
+	if err != nil {
+		return fmt.Errorf("error instantiating 'PolygonRollupManager' contract: %w", err)
+	}
+
+	rollupData, err := contract.RollupIDToRollupData(&bind.CallOpts{Pending: false}, rollupId)
+	if err != nil {
+		return fmt.Errorf("error receiving the 'RollupData' struct: %w", err)
+	}
+
+	if pendingStateNum > rollupData.LastPendingState {
+		return fmt.Errorf(
+			"%w: pending state %d, last pending state %d",
+			ErrPendingStateNotFound,
+			pendingStateNum,
+			rollupData.LastPendingState,
+		)
+	}
+
+	transition, err := contract.GetRollupPendingStateTransitions(&bind.CallOpts{Pending: false}, rollupId, pendingStateNum)
+	if err != nil {
+		return fmt.Errorf("error receiving the pending state transition: %w", err)
+	}
+
+	if transition.LastVerifiedBatch != lastVerifiedBatch {
+		return fmt.Errorf(
+			"%w: pending state %d ends at batch %d, tx verifies from batch %d",
+			ErrPendingStateBatchMismatch,
+			pendingStateNum,
+			transition.LastVerifiedBatch,
+			lastVerifiedBatch,
+		)
+	}
+
+	return nil
+}
+
+// CallContract executes the L1 call, the reverts with decodable revert data are returned as *RevertError
 func (e *Etherman) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	return e.ethClient.CallContract(ctx, call, blockNumber)
+	res, err := e.ethClient.CallContract(ctx, call, blockNumber)
+
+	return res, DecodeRevert(err)
+}
+
+// GetLastVerifiedBatch returns the last batch of the rollup verified on L1
+func (e *Etherman) GetLastVerifiedBatch(rollupId uint32) (uint64, error) {
+	contract, err := polygonrollupmanager.NewPolygonrollupmanager(e.config.L1.RollupManagerContract, e.ethClient)
+	if err != nil {
+		return 0, fmt.Errorf("error instantiating 'PolygonRollupManager' contract: %w", err)
+	}
+
+	lastVerifiedBatch, err := contract.GetLastVerifiedBatch(&bind.CallOpts{Pending: false}, rollupId)
+	if err != nil {
+		return 0, fmt.Errorf("error requesting the last verified batch from 'PolygonRollupManager': %w", err)
+	}
+
+	return lastVerifiedBatch, nil
+}
+
+// GetRollupCount returns the number of rollups registered in the rollup manager,
+// rollup IDs go from 1 to the count
+func (e *Etherman) GetRollupCount() (uint32, error) {
+	contract, err := polygonrollupmanager.NewPolygonrollupmanager(e.config.L1.RollupManagerContract, e.ethClient)
+	if err != nil {
+		return 0, fmt.Errorf("error instantiating 'PolygonRollupManager' contract: %w", err)
+	}
+
+	count, err := contract.RollupCount(&bind.CallOpts{Pending: false})
+	if err != nil {
+		return 0, fmt.Errorf("error requesting the rollup count from 'PolygonRollupManager': %w", err)
+	}
+
+	return count, nil
+}
+
+// GetRollup returns the rollup as registered in the rollup manager
+func (e *Etherman) GetRollup(rollupId uint32) (agglayerTypes.Rollup, error) {
+	contract, err := polygonrollupmanager.NewPolygonrollupmanager(e.config.L1.RollupManagerContract, e.ethClient)
+	if err != nil {
+		return agglayerTypes.Rollup{}, fmt.Errorf("error instantiating 'PolygonRollupManager' contract: %w", err)
+	}
+
+	rollupData, err := contract.RollupIDToRollupData(&bind.CallOpts{Pending: false}, rollupId)
+	if err != nil {
+		return agglayerTypes.Rollup{}, fmt.Errorf("error receiving the 'RollupData' struct: %w", err)
+	}
+
+	// the rollup manager returns an empty struct for the rollup IDs it doesn't know
+	if rollupData.RollupContract == (common.Address{}) {
+		return agglayerTypes.Rollup{}, fmt.Errorf("%w: rollup %d", agglayerTypes.ErrUnknownRollup, rollupId)
+	}
+
+	return agglayerTypes.Rollup{
+		ID:                    rollupId,
+		Contract:              rollupData.RollupContract,
+		ChainID:               rollupData.ChainID,
+		Verifier:              rollupData.Verifier,
+		ForkID:                rollupData.ForkID,
+		RollupTypeID:          rollupData.RollupTypeID,
+		RollupCompatibilityID: rollupData.RollupCompatibilityID,
+	}, nil
+}
+
+// GetRollupL1State returns the verification state of the rollup in the rollup manager
+func (e *Etherman) GetRollupL1State(rollupId uint32) (agglayerTypes.RollupL1State, error) {
+	contract, err := polygonrollupmanager.NewPolygonrollupmanager(e.config.L1.RollupManagerContract, e.ethClient)
+	if err != nil {
+		return agglayerTypes.RollupL1State{}, fmt.Errorf("error instantiating 'PolygonRollupManager' contract: %w", err)
+	}
+
+	rollupData, err := contract.RollupIDToRollupData(&bind.CallOpts{Pending: false}, rollupId)
+	if err != nil {
+		return agglayerTypes.RollupL1State{}, fmt.Errorf("error receiving the 'RollupData' struct: %w", err)
+	}
+
+	if rollupData.RollupContract == (common.Address{}) {
+		return agglayerTypes.RollupL1State{}, fmt.Errorf("%w: rollup %d", agglayerTypes.ErrUnknownRollup, rollupId)
+	}
+
+	stateRoot, err := contract.GetRollupBatchNumToStateRoot(&bind.CallOpts{Pending: false}, rollupId, rollupData.LastVerifiedBatch)
+	if err != nil {
+		return agglayerTypes.RollupL1State{}, fmt.Errorf("error requesting the state root of batch %d from 'PolygonRollupManager': %w", rollupData.LastVerifiedBatch, err)
+	}
+
+	return agglayerTypes.RollupL1State{
+		LastVerifiedBatch:            hexutil.Uint64(rollupData.LastVerifiedBatch),
+		StateRoot:                    stateRoot,
+		LocalExitRoot:                rollupData.LastLocalExitRoot,
+		LastBatchSequenced:           hexutil.Uint64(rollupData.LastBatchSequenced),
+		LastPendingState:             hexutil.Uint64(rollupData.LastPendingState),
+		LastPendingStateConsolidated: hexutil.Uint64(rollupData.LastPendingStateConsolidated),
+	}, nil
+}
+
+// GetUpdatedRollups returns the IDs of the rollups created, added or upgraded in the
+// rollup manager within the block range. The rollup manager emits CreateNewRollup for
+// the rollups it deploys and AddExistingRollup for the ones deployed beforehand
+func (e *Etherman) GetUpdatedRollups(ctx context.Context, fromBlock, toBlock uint64) ([]uint32, error) {
+	abi, err := polygonrollupmanager.PolygonrollupmanagerMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("error getting 'PolygonRollupManager' ABI: %w", err)
+	}
+
+	logs, err := e.ethClient.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{e.config.L1.RollupManagerContract},
+		Topics: [][]common.Hash{{
+			abi.Events["CreateNewRollup"].ID,
+			abi.Events["AddExistingRollup"].ID,
+			abi.Events["UpdateRollup"].ID,
+		}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error filtering 'PolygonRollupManager' rollup events: %w", err)
+	}
+
+	seen := make(map[uint32]bool, len(logs))
+	rollupIds := make([]uint32, 0, len(logs))
+	for _, l := range logs {
+		// the rollup ID is the first indexed argument of the three events
+		if len(l.Topics) < 2 {
+			continue
+		}
+
+		rollupId := uint32(new(big.Int).SetBytes(l.Topics[1].Bytes()).Uint64())
+		if !seen[rollupId] {
+			seen[rollupId] = true
+			rollupIds = append(rollupIds, rollupId)
+		}
+	}
+
+	return rollupIds, nil
 }
 
 func (e *Etherman) getRollupContractAddress(rollupId uint32) (common.Address, error) {
@@ -112,6 +317,17 @@ func (e *Etherman) getRollupContractAddress(rollupId uint32) (common.Address, er
 }
 
 func (e *Etherman) getTrustedSequencerAddress(rollupId uint32) (common.Address, error) {
+	if address, ok := e.sequencers.get(rollupId); ok {
+		return address, nil
+	}
+
+	return e.readTrustedSequencerAddress(rollupId)
+}
+
+// readTrustedSequencerAddress reads the trusted sequencer from L1 and caches it
+func (e *Etherman) readTrustedSequencerAddress(rollupId uint32) (common.Address, error) {
+	fetchedAt := time.Now()
+
 	rollupContractAddress, err := e.getRollupContractAddress(rollupId)
 	if err != nil {
 		return common.Address{}, fmt.Errorf("error requesting the 'PolygonZkEvm' contract address from 'PolygonRollupManager': %w", err)
@@ -122,7 +338,13 @@ func (e *Etherman) getTrustedSequencerAddress(rollupId uint32) (common.Address,
 		return common.Address{}, fmt.Errorf("error instantiating 'PolygonZkEvm' contract: %w", err)
 	}
 
-	return contract.TrustedSequencer(&bind.CallOpts{Pending: false})
+	sequencer, err := contract.TrustedSequencer(&bind.CallOpts{Pending: false})
+	if err != nil {
+		return common.Address{}, err
+	}
+	e.sequencers.set(rollupId, rollupContractAddress, sequencer, fetchedAt)
+
+	return sequencer, nil
 }
 
 // CheckTxWasMined check if a tx was already mined
@@ -206,18 +428,22 @@ func (e *Etherman) GetRevertMessage(ctx context.Context, tx *types.Transaction)
 	}
 
 	if receipt.Status == types.ReceiptStatusFailed {
-		revertMessage, err := operations.RevertReason(ctx, e.ethClient, tx, receipt.BlockNumber)
-
-		if err != nil {
-			return "", err
-		}
-		return revertMessage, nil
+		return e.replayRevert(ctx, tx, receipt.BlockNumber)
 	}
 	return "", nil
 }
 
 func (e *Etherman) GetLastBlock(ctx context.Context, dbTx pgx.Tx) (*state.Block, error) {
-	block, err := e.ethClient.BlockByNumber(ctx, nil)
+	return e.getBlock(ctx, nil)
+}
+
+// GetFinalizedBlock returns the last finalized L1 block, which can't be reorged
+func (e *Etherman) GetFinalizedBlock(ctx context.Context) (*state.Block, error) {
+	return e.getBlock(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+}
+
+func (e *Etherman) getBlock(ctx context.Context, number *big.Int) (*state.Block, error) {
+	block, err := e.ethClient.BlockByNumber(ctx, number)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package etherman
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"math/big"
@@ -9,6 +10,9 @@ import (
 	"github.com/0xPolygon/agglayer/config"
 	cdkTypes "github.com/0xPolygon/agglayer/rpc/types"
 	"github.com/0xPolygon/agglayer/tx"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	agglayerTypes "github.com/0xPolygon/agglayer/types"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygonrollupmanager"
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/0xPolygon/agglayer/mocks"
@@ -16,8 +20,10 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func signer(from common.Address, tx *types.Transaction) (*types.Transaction, error) {
@@ -176,6 +182,48 @@ func TestGetSequencerAddr(t *testing.T) {
 	})
 }
 
+func TestGetLastVerifiedBatch(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	callMsg := ethereum.CallMsg{
+		From: common.HexToAddress("0x0000000000000000000000000000000000000000"),
+		To:   &common.Address{},
+		Data: common.Hex2Bytes("11f6b2870000000000000000000000000000000000000000000000000000000000000001"),
+	}
+
+	t.Run("Returns expected error on 'GetLastVerifiedBatch' call", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		ethClient.On("CallContract", mock.Anything, callMsg, (*big.Int)(nil)).
+			Return([]byte{}, errors.New("error")).Once()
+
+		_, err := ethman.GetLastVerifiedBatch(1)
+
+		assert.ErrorContains(err, "error requesting the last verified batch")
+		ethClient.AssertExpectations(t)
+	})
+
+	t.Run("Returns expected last verified batch", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		ethClient.On("CallContract", mock.Anything, callMsg, (*big.Int)(nil)).
+			Return(common.LeftPadBytes([]byte{42}, 32), nil).Once()
+
+		lastVerifiedBatch, err := ethman.GetLastVerifiedBatch(1)
+
+		assert.NoError(err)
+		assert.Equal(uint64(42), lastVerifiedBatch)
+		ethClient.AssertExpectations(t)
+	})
+}
+
 func TestBuildTrustedVerifyBatches(t *testing.T) {
 	t.Parallel()
 	assert := assert.New(t)
@@ -193,6 +241,7 @@ func TestBuildTrustedVerifyBatches(t *testing.T) {
 				Proof:            cdkTypes.ArgBytes("0x30030030030003003300300030033003000300330030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030003003003000300300300030030030"),
 			},
 			1,
+			0,
 		)
 
 		assert.ErrorContains(err, "invalid proof length. Expected length: 1538, Actual length 1534")
@@ -200,6 +249,228 @@ func TestBuildTrustedVerifyBatches(t *testing.T) {
 	})
 }
 
+func TestCheckPendingState(t *testing.T) {
+	t.Parallel()
+
+	abi, err := polygonrollupmanager.PolygonrollupmanagerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	callTo := func(method string) interface{} {
+		return mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+			return bytes.HasPrefix(msg.Data, abi.Methods[method].ID)
+		})
+	}
+
+	rollupData := func(t *testing.T, lastPendingState, lastPendingStateConsolidated uint64) []byte {
+		t.Helper()
+
+		data, err := abi.Methods["rollupIDToRollupData"].Outputs.Pack(
+			common.Address{}, uint64(1), common.Address{}, uint64(7), [32]byte{}, uint64(20), uint64(10),
+			lastPendingState, lastPendingStateConsolidated, uint64(0), uint64(1), uint8(0),
+		)
+		require.NoError(t, err)
+
+		return data
+	}
+
+	pendingStateTransition := func(t *testing.T, lastVerifiedBatch uint64) []byte {
+		t.Helper()
+
+		data, err := abi.Methods["getRollupPendingStateTransitions"].Outputs.Pack(
+			polygonrollupmanager.LegacyZKEVMStateVariablesPendingState{
+				Timestamp:         1,
+				LastVerifiedBatch: lastVerifiedBatch,
+			},
+		)
+		require.NoError(t, err)
+
+		return data
+	}
+
+	t.Run("consolidated pending state", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		ethClient.On("CallContract", mock.Anything, callTo("rollupIDToRollupData"), (*big.Int)(nil)).
+			Return(rollupData(t, 5, 3), nil).Once()
+		ethClient.On("CallContract", mock.Anything, callTo("getRollupPendingStateTransitions"), (*big.Int)(nil)).
+			Return(pendingStateTransition(t, 10), nil).Once()
+
+		// the rollup manager settles from a consolidated pending state too
+		err := ethman.checkPendingState(1, 3, 10)
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown pending state", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		ethClient.On("CallContract", mock.Anything, callTo("rollupIDToRollupData"), (*big.Int)(nil)).
+			Return(rollupData(t, 5, 3), nil).Once()
+
+		err := ethman.checkPendingState(1, 6, 10)
+		assert.ErrorIs(t, err, ErrPendingStateNotFound)
+	})
+
+	t.Run("pending state ends at another batch", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		ethClient.On("CallContract", mock.Anything, callTo("rollupIDToRollupData"), (*big.Int)(nil)).
+			Return(rollupData(t, 5, 3), nil).Once()
+		ethClient.On("CallContract", mock.Anything, callTo("getRollupPendingStateTransitions"), (*big.Int)(nil)).
+			Return(pendingStateTransition(t, 12), nil).Once()
+
+		err := ethman.checkPendingState(1, 4, 10)
+		assert.ErrorIs(t, err, ErrPendingStateBatchMismatch)
+	})
+
+	t.Run("valid pending state", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		ethClient.On("CallContract", mock.Anything, callTo("rollupIDToRollupData"), (*big.Int)(nil)).
+			Return(rollupData(t, 5, 3), nil).Once()
+		ethClient.On("CallContract", mock.Anything, callTo("getRollupPendingStateTransitions"), (*big.Int)(nil)).
+			Return(pendingStateTransition(t, 10), nil).Once()
+
+		err := ethman.checkPendingState(1, 4, 10)
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetRollup(t *testing.T) {
+	t.Parallel()
+
+	abi, err := polygonrollupmanager.PolygonrollupmanagerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	callTo := func(method string) interface{} {
+		return mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+			return bytes.HasPrefix(msg.Data, abi.Methods[method].ID)
+		})
+	}
+
+	t.Run("rollup count", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		data, err := abi.Methods["rollupCount"].Outputs.Pack(uint32(3))
+		require.NoError(t, err)
+		ethClient.On("CallContract", mock.Anything, callTo("rollupCount"), (*big.Int)(nil)).Return(data, nil).Once()
+
+		count, err := ethman.GetRollupCount()
+		require.NoError(t, err)
+		require.Equal(t, uint32(3), count)
+	})
+
+	t.Run("registered rollup", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		data, err := abi.Methods["rollupIDToRollupData"].Outputs.Pack(
+			common.HexToAddress("0x1"), uint64(1001), common.HexToAddress("0x2"), uint64(7), [32]byte{}, uint64(20),
+			uint64(10), uint64(0), uint64(0), uint64(0), uint64(3), uint8(1),
+		)
+		require.NoError(t, err)
+		ethClient.On("CallContract", mock.Anything, callTo("rollupIDToRollupData"), (*big.Int)(nil)).Return(data, nil).Once()
+
+		rollup, err := ethman.GetRollup(2)
+		require.NoError(t, err)
+		require.Equal(t, agglayerTypes.Rollup{
+			ID:                    2,
+			Contract:              common.HexToAddress("0x1"),
+			ChainID:               1001,
+			Verifier:              common.HexToAddress("0x2"),
+			ForkID:                7,
+			RollupTypeID:          3,
+			RollupCompatibilityID: 1,
+		}, rollup)
+	})
+
+	t.Run("unknown rollup", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		data, err := abi.Methods["rollupIDToRollupData"].Outputs.Pack(
+			common.Address{}, uint64(0), common.Address{}, uint64(0), [32]byte{}, uint64(0),
+			uint64(0), uint64(0), uint64(0), uint64(0), uint64(0), uint8(0),
+		)
+		require.NoError(t, err)
+		ethClient.On("CallContract", mock.Anything, callTo("rollupIDToRollupData"), (*big.Int)(nil)).Return(data, nil).Once()
+
+		_, err = ethman.GetRollup(5)
+		require.ErrorIs(t, err, agglayerTypes.ErrUnknownRollup)
+	})
+
+	t.Run("rollup L1 state", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		data, err := abi.Methods["rollupIDToRollupData"].Outputs.Pack(
+			common.HexToAddress("0x1"), uint64(1001), common.HexToAddress("0x2"), uint64(7), common.HexToHash("0xb"), uint64(20),
+			uint64(10), uint64(2), uint64(1), uint64(0), uint64(3), uint8(1),
+		)
+		require.NoError(t, err)
+		ethClient.On("CallContract", mock.Anything, callTo("rollupIDToRollupData"), (*big.Int)(nil)).Return(data, nil).Once()
+
+		data, err = abi.Methods["getRollupBatchNumToStateRoot"].Outputs.Pack(common.HexToHash("0xa"))
+		require.NoError(t, err)
+		ethClient.On("CallContract", mock.Anything, callTo("getRollupBatchNumToStateRoot"), (*big.Int)(nil)).Return(data, nil).Once()
+
+		state, err := ethman.GetRollupL1State(2)
+		require.NoError(t, err)
+		require.Equal(t, agglayerTypes.RollupL1State{
+			LastVerifiedBatch:            10,
+			StateRoot:                    common.HexToHash("0xa"),
+			LocalExitRoot:                common.HexToHash("0xb"),
+			LastBatchSequenced:           20,
+			LastPendingState:             2,
+			LastPendingStateConsolidated: 1,
+		}, state)
+	})
+
+	t.Run("updated rollups", func(t *testing.T) {
+		t.Parallel()
+
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		rollupLog := func(event string, rollupID uint32) types.Log {
+			return types.Log{Topics: []common.Hash{abi.Events[event].ID, common.BigToHash(big.NewInt(int64(rollupID)))}}
+		}
+
+		ethClient.On("FilterLogs", mock.Anything, mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+			return q.FromBlock.Uint64() == 10 && q.ToBlock.Uint64() == 20 && len(q.Topics) == 1 && len(q.Topics[0]) == 3
+		})).Return([]types.Log{
+			rollupLog("CreateNewRollup", 3),
+			rollupLog("UpdateRollup", 1),
+			rollupLog("UpdateRollup", 3),
+			rollupLog("AddExistingRollup", 4),
+		}, nil).Once()
+
+		rollupIDs, err := ethman.GetUpdatedRollups(context.Background(), 10, 20)
+		require.NoError(t, err)
+		require.Equal(t, []uint32{3, 1, 4}, rollupIDs)
+	})
+}
+
 func TestCallContract(t *testing.T) {
 	t.Parallel()
 	assert := assert.New(t)
@@ -823,6 +1094,42 @@ func TestGetRevertMessage(t *testing.T) {
 		assert.Equal("HELLO", result)
 		assert.Nil(err)
 	})
+
+	t.Run("Returns the custom error of the rollup manager", func(t *testing.T) {
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		key, _ := crypto.GenerateKey()
+		signedTx, _ := types.SignTx(txData, types.NewEIP155Signer(big.NewInt(1)), key)
+
+		ethClient.On("TransactionReceipt", context.TODO(), signedTx.Hash()).
+			Return(&types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(1)}, nil).Once()
+		ethClient.On("CallContract", context.TODO(), mock.Anything, big.NewInt(1)).
+			Return(nil, dataError{"0x09bde339"}).Once()
+
+		result, err := ethman.GetRevertMessage(context.TODO(), signedTx)
+
+		assert.Equal("InvalidProof()", result)
+		assert.Nil(err)
+	})
+
+	t.Run("Returns an execution reverted error when the revert can't be decoded", func(t *testing.T) {
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman := getEtherman(ethClient)
+
+		key, _ := crypto.GenerateKey()
+		signedTx, _ := types.SignTx(txData, types.NewEIP155Signer(big.NewInt(1)), key)
+
+		ethClient.On("TransactionReceipt", context.TODO(), signedTx.Hash()).
+			Return(&types.Receipt{Status: types.ReceiptStatusFailed, BlockNumber: big.NewInt(1)}, nil).Once()
+		ethClient.On("CallContract", context.TODO(), mock.Anything, big.NewInt(1)).
+			Return([]byte{}, nil).Once()
+
+		result, err := ethman.GetRevertMessage(context.TODO(), signedTx)
+
+		assert.Equal("", result)
+		assert.ErrorIs(err, txmTypes.ErrExecutionReverted)
+	})
 }
 
 func TestGetLastBlock(t *testing.T) {
@@ -875,3 +1182,22 @@ func TestGetLastBlock(t *testing.T) {
 		assert.Nil(result)
 	})
 }
+
+func TestGetFinalizedBlock(t *testing.T) {
+	t.Parallel()
+
+	ethClient := mocks.NewEthereumClientMock(t)
+	ethman := getEtherman(ethClient)
+
+	header := &types.Header{Number: big.NewInt(10)}
+	ethClient.On(
+		"BlockByNumber",
+		context.TODO(),
+		big.NewInt(int64(rpc.FinalizedBlockNumber)),
+	).Return(types.NewBlockWithHeader(header), nil).Once()
+
+	result, err := ethman.GetFinalizedBlock(context.TODO())
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), result.BlockNumber)
+	require.Equal(t, header.Hash(), result.BlockHash)
+}
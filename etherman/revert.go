@@ -0,0 +1,157 @@
+package etherman
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygonrollupmanager"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RevertErrorString is the name of the reverts with a revert string rather than a custom error
+const RevertErrorString = "Error"
+
+// RevertError is an L1 call reverted by the rollup manager, with its revert data decoded
+type RevertError struct {
+	// Name is the custom error of the rollup manager, such as InvalidProof, or RevertErrorString
+	Name string
+	// Reason is the revert string, or the custom error with its arguments
+	Reason string
+
+	err error
+}
+
+// Error returns the decoded reason of the revert
+func (e *RevertError) Error() string {
+	return "execution reverted: " + e.Reason
+}
+
+// Unwrap returns the error of the L1 call
+func (e *RevertError) Unwrap() error {
+	return e.err
+}
+
+// DecodeRevert returns the error of a reverted L1 call as a *RevertError when its revert data
+// can be decoded, and the error as is otherwise
+func DecodeRevert(err error) error {
+	var revertErr *RevertError
+	if err == nil || errors.As(err, &revertErr) {
+		return err
+	}
+
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return err
+	}
+
+	encoded, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err
+	}
+
+	data, decodeErr := hexutil.Decode(encoded)
+	if decodeErr != nil {
+		return err
+	}
+
+	revertErr = decodeRevertData(data)
+	if revertErr == nil {
+		return err
+	}
+	revertErr.err = err
+
+	return revertErr
+}
+
+// DecodeRevertReason returns the reason of an L1 call that reverted, decoding either a revert
+// string or a custom error of the rollup manager. It returns an empty string when the error
+// carries no revert data
+func DecodeRevertReason(err error) string {
+	var revertErr *RevertError
+	if !errors.As(DecodeRevert(err), &revertErr) {
+		return ""
+	}
+
+	return revertErr.Reason
+}
+
+// decodeRevertData decodes the revert data of a call, or returns nil if it's neither
+// a revert string nor a custom error of the rollup manager
+func decodeRevertData(data []byte) *RevertError {
+	if len(data) < 4 {
+		return nil
+	}
+
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return &RevertError{Name: RevertErrorString, Reason: reason}
+	}
+
+	rollupManagerABI, err := polygonrollupmanager.PolygonrollupmanagerMetaData.GetAbi()
+	if err != nil {
+		return nil
+	}
+
+	for name, customErr := range rollupManagerABI.Errors {
+		if !bytes.Equal(customErr.ID[:4], data[:4]) {
+			continue
+		}
+
+		unpacked, err := customErr.Unpack(data)
+		args, ok := unpacked.([]interface{})
+		if err != nil || !ok {
+			return &RevertError{Name: name, Reason: name + "()"}
+		}
+
+		formatted := make([]string, len(args))
+		for i, arg := range args {
+			formatted[i] = fmt.Sprint(arg)
+		}
+
+		return &RevertError{Name: name, Reason: fmt.Sprintf("%s(%s)", name, strings.Join(formatted, ", "))}
+	}
+
+	return nil
+}
+
+// replayRevert replays the tx at the block it was mined in, returning its decoded revert reason.
+// It returns txmTypes.ErrExecutionReverted when the revert data can't be decoded
+func (e *Etherman) replayRevert(ctx context.Context, tx *types.Transaction, blockNumber *big.Int) (string, error) {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return "", err
+	}
+
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+
+	// Some nodes return the revert data as the result of the call rather than as an error
+	data, err := e.ethClient.CallContract(ctx, msg, blockNumber)
+	if err != nil {
+		var revertErr *RevertError
+		if errors.As(DecodeRevert(err), &revertErr) {
+			return revertErr.Reason, nil
+		}
+
+		return "", err
+	}
+
+	if revertErr := decodeRevertData(data); revertErr != nil {
+		return revertErr.Reason, nil
+	}
+
+	return "", txmTypes.ErrExecutionReverted
+}
@@ -0,0 +1,85 @@
+package etherman
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygonrollupmanager"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// dataError is an error of a reverted L1 call, as returned by the RPC client
+type dataError struct {
+	data interface{}
+}
+
+func (e dataError) Error() string          { return "execution reverted" }
+func (e dataError) ErrorCode() int         { return 3 }
+func (e dataError) ErrorData() interface{} { return e.data }
+
+func TestDecodeRevertReason(t *testing.T) {
+	t.Parallel()
+
+	rollupManagerABI, err := polygonrollupmanager.PolygonrollupmanagerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	selector := func(name string) string {
+		id := rollupManagerABI.Errors[name].ID
+		return hexutil.Encode(id[:4])
+	}
+
+	errorStringSelector := crypto.Keccak256([]byte("Error(string)"))[:4]
+	// abi encoding of the "invalid range" string
+	encodedString := append(append([]byte{}, errorStringSelector...), hexutil.MustDecode(
+		"0x0000000000000000000000000000000000000000000000000000000000000020"+
+			"000000000000000000000000000000000000000000000000000000000000000d"+
+			"696e76616c69642072616e676500000000000000000000000000000000000000",
+	)...)
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"revert string", dataError{hexutil.Encode(encodedString)}, "invalid range"},
+		{"custom error", dataError{selector("InvalidProof")}, "InvalidProof()"},
+		{"wrapped custom error", fmt.Errorf("failed: %w", dataError{selector("RollupMustExist")}), "RollupMustExist()"},
+		{"unknown selector", dataError{"0x12345678"}, ""},
+		{"no data", dataError{nil}, ""},
+		{"not a data error", errors.New("execution reverted"), ""},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, DecodeRevertReason(tc.err), tc.name)
+	}
+}
+
+func TestDecodeRevert(t *testing.T) {
+	t.Parallel()
+
+	callErr := fmt.Errorf("failed: %w", dataError{"0x09bde339"})
+
+	err := DecodeRevert(callErr)
+
+	var revertErr *RevertError
+	require.ErrorAs(t, err, &revertErr)
+	require.Equal(t, "InvalidProof", revertErr.Name)
+	require.Equal(t, "InvalidProof()", revertErr.Reason)
+	require.Equal(t, "execution reverted: InvalidProof()", err.Error())
+
+	// the error of the call is kept
+	var rpcErr rpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, 3, rpcErr.ErrorCode())
+
+	// decoding twice doesn't wrap the error again
+	require.Equal(t, err, DecodeRevert(err))
+
+	undecodable := errors.New("execution reverted")
+	require.Equal(t, undecodable, DecodeRevert(undecodable))
+	require.Nil(t, DecodeRevert(nil))
+}
@@ -0,0 +1,251 @@
+package etherman
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygonzkevm"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// sequencerEntry is the trusted sequencer of a rollup as read from its contract
+type sequencerEntry struct {
+	sequencer common.Address
+	contract  common.Address
+	fetchedAt time.Time
+}
+
+// sequencerCache holds the trusted sequencers of the rollups for up to ttl, entries
+// are dropped as soon as their rollup contract emits SetTrustedSequencer
+type sequencerCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[uint32]sequencerEntry
+	// invalidatedAt and clearedAt discard the reads that were in flight while the
+	// sequencer changed, as they may return the previous one
+	invalidatedAt map[common.Address]time.Time
+	clearedAt     time.Time
+	// watched and watchedAt discard the reads that were in flight while the contracts whose
+	// events are polled were listed, as the changes of their contract aren't polled
+	watched   map[common.Address]bool
+	watchedAt time.Time
+}
+
+func newSequencerCache(ttl time.Duration) *sequencerCache {
+	return &sequencerCache{
+		ttl:           ttl,
+		entries:       make(map[uint32]sequencerEntry),
+		invalidatedAt: make(map[common.Address]time.Time),
+	}
+}
+
+func (c *sequencerCache) get(rollupId uint32) (common.Address, bool) {
+	if c.ttl <= 0 {
+		return common.Address{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[rollupId]
+	if !ok || time.Since(entry.fetchedAt) >= c.ttl {
+		return common.Address{}, false
+	}
+
+	return entry.sequencer, true
+}
+
+func (c *sequencerCache) set(rollupId uint32, contract, sequencer common.Address, fetchedAt time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !fetchedAt.After(c.clearedAt) || !fetchedAt.After(c.invalidatedAt[contract]) {
+		return
+	}
+	if fetchedAt.Before(c.watchedAt) && !c.watched[contract] {
+		return
+	}
+
+	c.entries[rollupId] = sequencerEntry{sequencer: sequencer, contract: contract, fetchedAt: fetchedAt}
+}
+
+// invalidate drops the entries of the rollups using the given contracts
+func (c *sequencerCache) invalidate(contracts ...common.Address) []uint32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var rollupIds []uint32
+	for _, contract := range contracts {
+		c.invalidatedAt[contract] = now
+		for rollupId, entry := range c.entries {
+			if entry.contract == contract {
+				delete(c.entries, rollupId)
+				rollupIds = append(rollupIds, rollupId)
+			}
+		}
+	}
+
+	return rollupIds
+}
+
+// watch returns the contracts of the cached entries, the ones whose events must be polled
+func (c *sequencerCache) watch() []common.Address {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.watched = make(map[common.Address]bool, len(c.entries))
+	c.watchedAt = time.Now()
+
+	contracts := make([]common.Address, 0, len(c.entries))
+	for _, entry := range c.entries {
+		if !c.watched[entry.contract] {
+			c.watched[entry.contract] = true
+			contracts = append(contracts, entry.contract)
+		}
+	}
+
+	return contracts
+}
+
+func (c *sequencerCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[uint32]sequencerEntry)
+	c.clearedAt = time.Now()
+}
+
+// SequencerWatcher drops the cached trusted sequencers of the rollups whose contract
+// emits SetTrustedSequencer. If the events can't be polled the whole cache is dropped,
+// so a rotated sequencer is never read from the cache for longer than a poll
+type SequencerWatcher struct {
+	logger        *zap.SugaredLogger
+	etherman      *Etherman
+	frequency     time.Duration
+	maxBlockRange uint64
+	lastBlock     uint64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewSequencerWatcher returns a watcher of the trusted sequencers cached by the etherman
+func NewSequencerWatcher(logger *zap.SugaredLogger, etherman *Etherman) *SequencerWatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &SequencerWatcher{
+		logger:        logger,
+		etherman:      etherman,
+		frequency:     etherman.config.SequencerCache.FrequencyToPoll.Duration,
+		maxBlockRange: etherman.config.SequencerCache.MaxBlockRange,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start polls the SetTrustedSequencer events until the watcher is stopped
+func (w *SequencerWatcher) Start() {
+	for {
+		if err := w.poll(); err != nil {
+			w.logger.Errorf("failed to poll trusted sequencer changes, dropping the cached sequencers: %s", err)
+			w.etherman.sequencers.clear()
+		}
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-time.After(w.frequency):
+		}
+	}
+}
+
+// Stop stops polling the events
+func (w *SequencerWatcher) Stop() {
+	w.cancel()
+}
+
+// poll invalidates the cached rollups whose contract changed its trusted sequencer since the last poll,
+// requesting the events of their contracts by ranges of up to maxBlockRange blocks
+func (w *SequencerWatcher) poll() error {
+	block, err := w.etherman.GetLastBlock(w.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get the last L1 block: %w", err)
+	}
+
+	fromBlock := w.lastBlock + 1
+	if w.lastBlock == 0 {
+		// the sequencers cached before the first poll aren't covered by any event range
+		w.etherman.sequencers.clear()
+		w.lastBlock = block.BlockNumber
+		return nil
+	}
+	if block.BlockNumber < fromBlock {
+		return nil
+	}
+
+	// the rollups cached afterwards read their sequencer after the last block, so it's up to date
+	contracts := w.etherman.sequencers.watch()
+	if len(contracts) == 0 {
+		w.lastBlock = block.BlockNumber
+		return nil
+	}
+
+	for fromBlock <= block.BlockNumber {
+		toBlock := block.BlockNumber
+		if w.maxBlockRange > 0 && toBlock-fromBlock+1 > w.maxBlockRange {
+			toBlock = fromBlock + w.maxBlockRange - 1
+		}
+
+		changed, err := w.etherman.getTrustedSequencerChanges(w.ctx, contracts, fromBlock, toBlock)
+		if err != nil {
+			return err
+		}
+
+		if len(changed) > 0 {
+			rollupIds := w.etherman.sequencers.invalidate(changed...)
+			w.logger.Infof("trusted sequencer changed for contracts %v, cached for rollups %v", changed, rollupIds)
+		}
+
+		w.lastBlock = toBlock
+		fromBlock = toBlock + 1
+	}
+
+	return nil
+}
+
+// getTrustedSequencerChanges returns the contracts among the given ones that emitted SetTrustedSequencer
+// within the block range
+func (e *Etherman) getTrustedSequencerChanges(ctx context.Context, contracts []common.Address, fromBlock, toBlock uint64) ([]common.Address, error) {
+	abi, err := polygonzkevm.PolygonzkevmMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("error getting 'PolygonZkEvm' ABI: %w", err)
+	}
+
+	logs, err := e.ethClient.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: contracts,
+		Topics:    [][]common.Hash{{abi.Events["SetTrustedSequencer"].ID}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error filtering 'SetTrustedSequencer' events: %w", err)
+	}
+
+	changed := make([]common.Address, 0, len(logs))
+	for _, l := range logs {
+		changed = append(changed, l.Address)
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,247 @@
+package etherman
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	configTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/smartcontracts/polygonzkevm"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequencerCache(t *testing.T) {
+	t.Parallel()
+
+	contract := common.HexToAddress("0x1")
+	sequencer := common.HexToAddress("0x2")
+
+	t.Run("hit until the ttl expires", func(t *testing.T) {
+		t.Parallel()
+
+		c := newSequencerCache(time.Minute)
+		c.set(1, contract, sequencer, time.Now())
+
+		result, ok := c.get(1)
+		require.True(t, ok)
+		require.Equal(t, sequencer, result)
+
+		c.set(2, contract, sequencer, time.Now().Add(-time.Minute))
+		_, ok = c.get(2)
+		require.False(t, ok)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		c := newSequencerCache(0)
+		c.set(1, contract, sequencer, time.Now())
+
+		_, ok := c.get(1)
+		require.False(t, ok)
+	})
+
+	t.Run("invalidate by contract", func(t *testing.T) {
+		t.Parallel()
+
+		c := newSequencerCache(time.Minute)
+		c.set(1, contract, sequencer, time.Now())
+		c.set(2, common.HexToAddress("0x3"), sequencer, time.Now())
+
+		require.Equal(t, []uint32{1}, c.invalidate(contract))
+
+		_, ok := c.get(1)
+		require.False(t, ok)
+		_, ok = c.get(2)
+		require.True(t, ok)
+	})
+
+	t.Run("reads in flight while invalidated are discarded", func(t *testing.T) {
+		t.Parallel()
+
+		c := newSequencerCache(time.Minute)
+
+		fetchedAt := time.Now()
+		c.invalidate(contract)
+		c.set(1, contract, sequencer, fetchedAt)
+		_, ok := c.get(1)
+		require.False(t, ok)
+
+		fetchedAt = time.Now()
+		c.clear()
+		c.set(2, common.HexToAddress("0x3"), sequencer, fetchedAt)
+		_, ok = c.get(2)
+		require.False(t, ok)
+	})
+}
+
+func TestSequencerWatcher(t *testing.T) {
+	t.Parallel()
+
+	contract := common.HexToAddress("0x1")
+
+	newWatcher := func(t *testing.T) (*SequencerWatcher, *mocks.EthereumClientMock) {
+		t.Helper()
+
+		ethClient := mocks.NewEthereumClientMock(t)
+		ethman, err := New(ethClient, bind.TransactOpts{}, &config.Config{
+			SequencerCache: config.SequencerCacheConfig{TTL: configTypes.NewDuration(time.Minute), MaxBlockRange: 5},
+		})
+		require.NoError(t, err)
+
+		return NewSequencerWatcher(log.WithFields("test", "test"), &ethman), ethClient
+	}
+
+	head := func(number int64) *types.Block {
+		return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(number)})
+	}
+
+	t.Run("first poll drops the cache", func(t *testing.T) {
+		t.Parallel()
+
+		w, ethClient := newWatcher(t)
+		w.etherman.sequencers.set(1, contract, common.HexToAddress("0x2"), time.Now())
+
+		ethClient.On("BlockByNumber", mock.Anything, (*big.Int)(nil)).Return(head(10), nil).Once()
+
+		require.NoError(t, w.poll())
+		require.Equal(t, uint64(10), w.lastBlock)
+
+		_, ok := w.etherman.sequencers.get(1)
+		require.False(t, ok)
+	})
+
+	t.Run("set trusted sequencer events invalidate the rollups", func(t *testing.T) {
+		t.Parallel()
+
+		w, ethClient := newWatcher(t)
+		w.lastBlock = 10
+		w.etherman.sequencers.set(1, contract, common.HexToAddress("0x2"), time.Now())
+		w.etherman.sequencers.set(2, common.HexToAddress("0x3"), common.HexToAddress("0x2"), time.Now())
+
+		zkEVMABI, err := polygonzkevm.PolygonzkevmMetaData.GetAbi()
+		require.NoError(t, err)
+
+		filter := func(fromBlock, toBlock int64) interface{} {
+			return mock.MatchedBy(func(q ethereum.FilterQuery) bool {
+				return q.FromBlock.Cmp(big.NewInt(fromBlock)) == 0 && q.ToBlock.Cmp(big.NewInt(toBlock)) == 0 &&
+					assert.ElementsMatch(t, []common.Address{contract, common.HexToAddress("0x3")}, q.Addresses) &&
+					assert.Equal(t, [][]common.Hash{{zkEVMABI.Events["SetTrustedSequencer"].ID}}, q.Topics)
+			})
+		}
+
+		// the range is requested by pages of MaxBlockRange blocks
+		ethClient.On("BlockByNumber", mock.Anything, (*big.Int)(nil)).Return(head(20), nil).Once()
+		ethClient.On("FilterLogs", mock.Anything, filter(11, 15)).Return([]types.Log{{Address: contract}}, nil).Once()
+		ethClient.On("FilterLogs", mock.Anything, filter(16, 20)).Return([]types.Log{}, nil).Once()
+
+		require.NoError(t, w.poll())
+		require.Equal(t, uint64(20), w.lastBlock)
+
+		_, ok := w.etherman.sequencers.get(1)
+		require.False(t, ok)
+		_, ok = w.etherman.sequencers.get(2)
+		require.True(t, ok)
+	})
+
+	t.Run("no event is requested without cached rollups", func(t *testing.T) {
+		t.Parallel()
+
+		w, ethClient := newWatcher(t)
+		w.lastBlock = 10
+
+		ethClient.On("BlockByNumber", mock.Anything, (*big.Int)(nil)).Return(head(20), nil).Once()
+
+		require.NoError(t, w.poll())
+		require.Equal(t, uint64(20), w.lastBlock)
+	})
+
+	t.Run("reads in flight while the contracts are listed are discarded", func(t *testing.T) {
+		t.Parallel()
+
+		w, _ := newWatcher(t)
+		fetchedAt := time.Now()
+
+		w.etherman.sequencers.watch()
+		w.etherman.sequencers.set(1, contract, common.HexToAddress("0x2"), fetchedAt)
+		_, ok := w.etherman.sequencers.get(1)
+		require.False(t, ok)
+
+		w.etherman.sequencers.set(1, contract, common.HexToAddress("0x2"), time.Now())
+		_, ok = w.etherman.sequencers.get(1)
+		require.True(t, ok)
+	})
+
+	t.Run("failed poll is retried from the failed page", func(t *testing.T) {
+		t.Parallel()
+
+		w, ethClient := newWatcher(t)
+		w.lastBlock = 10
+		w.etherman.sequencers.set(1, contract, common.HexToAddress("0x2"), time.Now())
+
+		ethClient.On("BlockByNumber", mock.Anything, (*big.Int)(nil)).Return(head(20), nil).Once()
+		ethClient.On("FilterLogs", mock.Anything, mock.Anything).Return([]types.Log{}, nil).Once()
+		ethClient.On("FilterLogs", mock.Anything, mock.Anything).Return(nil, errors.New("error")).Once()
+
+		// the pages polled before the failure aren't requested again
+		require.Error(t, w.poll())
+		require.Equal(t, uint64(15), w.lastBlock)
+	})
+}
+
+func TestGetSequencerAddrCached(t *testing.T) {
+	t.Parallel()
+
+	ethClient := mocks.NewEthereumClientMock(t)
+	ethman, err := New(ethClient, bind.TransactOpts{}, &config.Config{
+		SequencerCache: config.SequencerCacheConfig{TTL: configTypes.NewDuration(time.Minute)},
+	})
+	require.NoError(t, err)
+
+	ethClient.On( // Call "RollupIDToRollupData" on the rollup manager
+		"CallContract",
+		mock.Anything,
+		ethereum.CallMsg{
+			To:   &common.Address{},
+			Data: common.Hex2Bytes("f9c4c2ae0000000000000000000000000000000000000000000000000000000000000001"),
+		},
+		(*big.Int)(nil),
+	).Return(
+		common.Hex2Bytes("000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001"),
+		nil,
+	).Twice()
+
+	ethClient.On( // Call "TrustedSequencer" property on rollup contract
+		"CallContract",
+		mock.Anything,
+		ethereum.CallMsg{
+			To:   &common.Address{},
+			Data: []uint8{0xcf, 0xa8, 0xed, 0x47},
+		},
+		(*big.Int)(nil),
+	).Return(
+		common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000002"),
+		nil,
+	).Twice()
+
+	for i := 0; i < 2; i++ {
+		sequencer, err := ethman.GetSequencerAddr(1)
+		require.NoError(t, err)
+		require.Equal(t, common.HexToAddress("0x2"), sequencer)
+	}
+
+	// fresh reads always go to L1
+	sequencer, err := ethman.GetFreshSequencerAddr(1)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToAddress("0x2"), sequencer)
+}
@@ -0,0 +1,126 @@
+package interop
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/0xPolygon/agglayer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/jackc/pgx/v4"
+)
+
+// GetTxDetails returns the lifecycle of the intake tx, including the L1 txs sent to settle it
+func (e *Executor) GetTxDetails(ctx context.Context, itx types.IntakeTx, dbTx pgx.Tx) (types.TxDetails, error) {
+	signer, scheme := itx.Signer, itx.SignatureScheme
+	if scheme == "" {
+		signer, scheme = e.signer(itx)
+	}
+
+	details := types.TxDetails{
+		Hash:            itx.Hash,
+		Tx:              itx.SignedTx.Tx,
+		Signer:          signer,
+		SignatureScheme: scheme,
+		Status:          itx.Status.String(),
+		Outcome:         types.TxOutcomePending,
+		Error:           itx.Error,
+		ErrorCode:       itx.ErrorCode,
+		Stages: types.TxStages{
+			ReceivedAt: itx.ReceivedAt,
+			VerifiedAt: itx.VerifiedAt,
+			SettlingAt: itx.SettlingAt,
+			RejectedAt: itx.RejectedAt,
+			UpdatedAt:  itx.UpdatedAt,
+		},
+	}
+
+	if itx.Status == types.IntakeTxStatusRejected {
+		details.Outcome = types.TxOutcomeRejected
+	}
+	if itx.Status != types.IntakeTxStatusSettling {
+		return details, nil
+	}
+
+	res, err := e.ethTxMan.Result(ctx, ethTxManOwner, itx.Hash.Hex(), dbTx)
+	if err != nil {
+		return types.TxDetails{}, fmt.Errorf("failed to get the settlement of tx %s: %w", itx.Hash.Hex(), err)
+	}
+
+	details.Status = res.Status.String()
+	details.Settlement = newTxSettlement(res)
+
+	switch res.Status {
+	case txmTypes.MonitoredTxStatusConfirmed, txmTypes.MonitoredTxStatusDone:
+		details.Outcome = types.TxOutcomeSettled
+	case txmTypes.MonitoredTxStatusFailed:
+		details.Outcome = types.TxOutcomeFailed
+	}
+
+	return details, nil
+}
+
+// signer returns the authorized signer of a tx that wasn't verified yet and its signature scheme, checked
+// against the cached sequencer only so reads don't query L1. When the signer isn't authorized, or it
+// can't be checked, the typed data signer is returned
+func (e *Executor) signer(itx types.IntakeTx) (common.Address, string) {
+	scheme, err := e.checkSigner(itx.SignedTx, e.cachedSequencer)
+	if err != nil {
+		return e.authorizedSigner(itx.SignedTx, signatureSchemeTypedData), ""
+	}
+
+	return e.authorizedSigner(itx.SignedTx, scheme), scheme
+}
+
+// cachedSequencer returns the trusted sequencer of the rollup only if it's cached
+func (e *Executor) cachedSequencer(rollupID uint32) (common.Address, error) {
+	sequencer, ok := e.etherman.GetCachedSequencerAddr(rollupID)
+	if !ok {
+		return common.Address{}, fmt.Errorf("the sequencer of rollup %d isn't cached", rollupID)
+	}
+
+	return sequencer, nil
+}
+
+func newTxSettlement(res txmTypes.MonitoredTxResult) *types.TxSettlement {
+	settlement := &types.TxSettlement{
+		Status:      res.Status.String(),
+		GasPrice:    (*hexutil.Big)(res.GasPrice),
+		BlockNumber: (*hexutil.Big)(res.BlockNumber),
+		NumRetries:  hexutil.Uint64(res.NumRetries),
+		Attempts:    make([]types.L1Attempt, 0, len(res.Txs)),
+		CreatedAt:   res.CreatedAt,
+		UpdatedAt:   res.UpdatedAt,
+	}
+
+	for hash, result := range res.Txs {
+		attempt := types.L1Attempt{
+			Hash:          hash,
+			Receipt:       result.Receipt,
+			RevertMessage: result.RevertMessage,
+		}
+		if result.Tx != nil {
+			attempt.Nonce = hexutil.Uint64(result.Tx.Nonce())
+			attempt.GasPrice = (*hexutil.Big)(result.Tx.GasPrice())
+			attempt.Gas = hexutil.Uint64(result.Tx.Gas())
+		}
+
+		settlement.Attempts = append(settlement.Attempts, attempt)
+	}
+
+	// retries bump the gas price, so the attempts are sorted in the order they were sent
+	sort.Slice(settlement.Attempts, func(i, j int) bool {
+		a, b := settlement.Attempts[i], settlement.Attempts[j]
+		if a.GasPrice != nil && b.GasPrice != nil {
+			if c := a.GasPrice.ToInt().Cmp(b.GasPrice.ToInt()); c != 0 {
+				return c < 0
+			}
+		}
+		return bytes.Compare(a.Hash[:], b.Hash[:]) < 0
+	})
+
+	return settlement
+}
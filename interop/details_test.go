@@ -0,0 +1,172 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	"github.com/0xPolygon/agglayer/tx"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/0xPolygon/agglayer/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutor_GetTxDetails(t *testing.T) {
+	t.Parallel()
+
+	signerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(signerKey.PublicKey)
+
+	newIntakeTx := func(t *testing.T, status types.IntakeTxStatus) types.IntakeTx {
+		t.Helper()
+
+		txn := tx.Tx{RollupID: 1, LastVerifiedBatch: 1, NewVerifiedBatch: 2}
+		signedTx, err := txn.SignTypedData(signerKey, tx.SigningDomain{})
+		require.NoError(t, err)
+
+		itx := types.NewIntakeTx(*signedTx)
+		itx.Status = status
+
+		return itx
+	}
+
+	newExecutor := func(t *testing.T, ethTxManager types.IEthTxManager) *Executor {
+		t.Helper()
+
+		cfg := &config.Config{ProofSigners: config.ProofSigners{1: signer}}
+
+		return New(log.WithFields("test", "test"), cfg, common.HexToAddress("0x1"), mocks.NewEthermanMock(t), ethTxManager)
+	}
+
+	t.Run("rejected", func(t *testing.T) {
+		t.Parallel()
+
+		itx := newIntakeTx(t, types.IntakeTxStatusRejected)
+		itx.Error = "invalid ZKP"
+		rejectedAt := time.Now()
+		itx.RejectedAt = &rejectedAt
+
+		details, err := newExecutor(t, mocks.NewEthTxManagerMock(t)).GetTxDetails(context.Background(), itx, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, itx.Hash, details.Hash)
+		require.Equal(t, itx.SignedTx.Tx, details.Tx)
+		require.Equal(t, signer, details.Signer)
+		require.Equal(t, signatureSchemeTypedData, details.SignatureScheme)
+		require.Equal(t, "rejected", details.Status)
+		require.Equal(t, types.TxOutcomeRejected, details.Outcome)
+		require.Equal(t, "invalid ZKP", details.Error)
+		require.Equal(t, &rejectedAt, details.Stages.RejectedAt)
+		require.Nil(t, details.Settlement)
+	})
+
+	t.Run("signer recorded at verification", func(t *testing.T) {
+		t.Parallel()
+
+		itx := newIntakeTx(t, types.IntakeTxStatusVerified)
+		itx.Signer = common.HexToAddress("0x3")
+		itx.SignatureScheme = signatureSchemeLegacy
+
+		details, err := newExecutor(t, mocks.NewEthTxManagerMock(t)).GetTxDetails(context.Background(), itx, nil)
+		require.NoError(t, err)
+		require.Equal(t, itx.Signer, details.Signer)
+		require.Equal(t, signatureSchemeLegacy, details.SignatureScheme)
+	})
+
+	t.Run("unverified tx is checked against the cached sequencer only", func(t *testing.T) {
+		t.Parallel()
+
+		itx := newIntakeTx(t, types.IntakeTxStatusReceived)
+
+		etherman := mocks.NewEthermanMock(t)
+		e := New(log.WithFields("test", "test"), &config.Config{}, common.HexToAddress("0x1"), etherman, mocks.NewEthTxManagerMock(t))
+
+		etherman.On("GetCachedSequencerAddr", uint32(1)).Return(common.Address{}, false).Once()
+		details, err := e.GetTxDetails(context.Background(), itx, nil)
+		require.NoError(t, err)
+		require.Equal(t, signer, details.Signer)
+		require.Empty(t, details.SignatureScheme)
+
+		etherman.On("GetCachedSequencerAddr", uint32(1)).Return(signer, true).Once()
+		details, err = e.GetTxDetails(context.Background(), itx, nil)
+		require.NoError(t, err)
+		require.Equal(t, signer, details.Signer)
+		require.Equal(t, signatureSchemeTypedData, details.SignatureScheme)
+	})
+
+	t.Run("settled", func(t *testing.T) {
+		t.Parallel()
+
+		itx := newIntakeTx(t, types.IntakeTxStatusSettling)
+
+		to := common.HexToAddress("0x2")
+		first := ethTypes.NewTransaction(1, to, big.NewInt(0), 21000, big.NewInt(10), nil)
+		second := ethTypes.NewTransaction(1, to, big.NewInt(0), 21000, big.NewInt(20), nil)
+		receipt := &ethTypes.Receipt{Status: ethTypes.ReceiptStatusSuccessful, BlockNumber: big.NewInt(100)}
+
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+		ethTxManager.On("Result", mock.Anything, ethTxManOwner, itx.Hash.Hex(), nil).
+			Return(txmTypes.MonitoredTxResult{
+				ID:     itx.Hash.Hex(),
+				Status: txmTypes.MonitoredTxStatusDone,
+				Txs: map[common.Hash]txmTypes.TxResult{
+					second.Hash(): {Tx: second, Receipt: receipt},
+					first.Hash():  {Tx: first},
+				},
+				GasPrice:    big.NewInt(20),
+				BlockNumber: big.NewInt(100),
+				NumRetries:  2,
+			}, nil).Once()
+
+		details, err := newExecutor(t, ethTxManager).GetTxDetails(context.Background(), itx, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, "done", details.Status)
+		require.Equal(t, types.TxOutcomeSettled, details.Outcome)
+		require.NotNil(t, details.Settlement)
+		require.Equal(t, uint64(2), uint64(details.Settlement.NumRetries))
+		require.Equal(t, big.NewInt(100), details.Settlement.BlockNumber.ToInt())
+		require.Len(t, details.Settlement.Attempts, 2)
+		require.Equal(t, first.Hash(), details.Settlement.Attempts[0].Hash)
+		require.Nil(t, details.Settlement.Attempts[0].Receipt)
+		require.Equal(t, second.Hash(), details.Settlement.Attempts[1].Hash)
+		require.Equal(t, receipt, details.Settlement.Attempts[1].Receipt)
+	})
+
+	t.Run("reverted on L1", func(t *testing.T) {
+		t.Parallel()
+
+		itx := newIntakeTx(t, types.IntakeTxStatusSettling)
+
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+		ethTxManager.On("Result", mock.Anything, ethTxManOwner, itx.Hash.Hex(), nil).
+			Return(txmTypes.MonitoredTxResult{Status: txmTypes.MonitoredTxStatusFailed}, nil).Once()
+
+		details, err := newExecutor(t, ethTxManager).GetTxDetails(context.Background(), itx, nil)
+		require.NoError(t, err)
+		require.Equal(t, types.TxOutcomeFailed, details.Outcome)
+	})
+
+	t.Run("settlement unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		itx := newIntakeTx(t, types.IntakeTxStatusSettling)
+
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+		ethTxManager.On("Result", mock.Anything, ethTxManOwner, itx.Hash.Hex(), nil).
+			Return(txmTypes.MonitoredTxResult{}, errors.New("error")).Once()
+
+		_, err := newExecutor(t, ethTxManager).GetTxDetails(context.Background(), itx, nil)
+		require.ErrorContains(t, err, "failed to get the settlement")
+	})
+}
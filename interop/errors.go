@@ -0,0 +1,95 @@
+package interop
+
+import (
+	"errors"
+
+	rpcTypes "github.com/0xPolygon/agglayer/rpc/types"
+	"github.com/0xPolygon/agglayer/types"
+)
+
+var (
+	// ErrOverlappingBatchRange when the batch range of a tx overlaps with a range
+	// already verified on L1 or being settled by another tx
+	ErrOverlappingBatchRange = errors.New("batch range overlaps with a settled or in-flight range")
+	// ErrNonContiguousBatchRange when the batch range of a tx doesn't start right where
+	// the last range verified on L1 or being settled ends
+	ErrNonContiguousBatchRange = errors.New("batch range is not contiguous with the last settled or in-flight range")
+	// ErrFullNodeDivergence when the full nodes of a rollup return different batches
+	// and not enough of them agree, as opposed to the tx not matching the rollup
+	ErrFullNodeDivergence = errors.New("full nodes diverge")
+	// ErrCircuitOpen when a full node is not queried because its recent requests failed
+	ErrCircuitOpen = errors.New("circuit breaker open")
+	// ErrInvalidProof when the proof of a tx doesn't have the length or the format expected by the verifier
+	ErrInvalidProof = errors.New("invalid proof")
+	// ErrInvalidBatchRange when the new verified batch of a tx isn't above its last verified batch
+	ErrInvalidBatchRange = errors.New("invalid batch range")
+	// ErrInvalidSignature when the signer of a tx can't be recovered
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrUnauthorizedSigner when a tx isn't signed by the proof signer or the trusted sequencer of its rollup
+	ErrUnauthorizedSigner = errors.New("unauthorized signer")
+	// ErrRollupNotConfigured when the agglayer has no full node or soundness config to check the txs of the rollup
+	ErrRollupNotConfigured = errors.New("rollup not configured")
+	// ErrInvalidPendingState when the pending state a tx settles from doesn't exist on L1
+	// or doesn't end at the last verified batch of the tx
+	ErrInvalidPendingState = errors.New("invalid pending state")
+	// ErrProofRejected when the rollup manager rejects the ZKP of a tx
+	ErrProofRejected = errors.New("proof rejected by L1")
+	// ErrStateRootMismatch when the roots of a tx don't match the batch returned by the full nodes
+	ErrStateRootMismatch = errors.New("state root mismatch")
+	// ErrFullNodeUnavailable when the full nodes can't return the batch of a tx
+	ErrFullNodeUnavailable = errors.New("full node unavailable")
+	// ErrSettlementQueue when a verified tx can't be handed over to the eth tx manager
+	ErrSettlementQueue = errors.New("failed to queue the settlement")
+)
+
+// isFinal returns whether the failure of a tx is inherent to it, so processing it again would fail the same
+// way, as opposed to a failure of L1, the full nodes or the agglayer that may not happen on the next attempt
+func isFinal(err error) bool {
+	for _, final := range []error{
+		ErrInvalidProof,
+		ErrInvalidBatchRange,
+		ErrInvalidSignature,
+		ErrUnauthorizedSigner,
+		ErrInvalidPendingState,
+		ErrProofRejected,
+		ErrStateRootMismatch,
+		ErrOverlappingBatchRange,
+		ErrNonContiguousBatchRange,
+	} {
+		if errors.Is(err, final) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ErrorCode returns the code of the RPC error reporting the failure of a tx
+func ErrorCode(err error) int {
+	switch {
+	case errors.Is(err, types.ErrUnknownRollup), errors.Is(err, ErrRollupNotConfigured):
+		return rpcTypes.ErrorCodeUnknownRollup
+	case errors.Is(err, ErrInvalidProof), errors.Is(err, ErrInvalidBatchRange):
+		return rpcTypes.ErrorCodeInvalidParams
+	case errors.Is(err, ErrInvalidSignature):
+		return rpcTypes.ErrorCodeInvalidSignature
+	case errors.Is(err, ErrUnauthorizedSigner):
+		return rpcTypes.ErrorCodeUnauthorizedSigner
+	case errors.Is(err, ErrInvalidPendingState):
+		return rpcTypes.ErrorCodeInvalidPendingState
+	case errors.Is(err, ErrProofRejected):
+		return rpcTypes.ErrorCodeProofRejected
+	// a quorum may both miss full nodes and find a mismatch, the mismatch prevails
+	case errors.Is(err, ErrStateRootMismatch):
+		return rpcTypes.ErrorCodeStateRootMismatch
+	// the full nodes diverging is wrapped as them being unavailable, the divergence prevails
+	case errors.Is(err, ErrFullNodeDivergence):
+		return rpcTypes.ErrorCodeFullNodeDivergence
+	case errors.Is(err, ErrFullNodeUnavailable):
+		return rpcTypes.ErrorCodeFullNodeUnavailable
+	case errors.Is(err, ErrOverlappingBatchRange), errors.Is(err, ErrNonContiguousBatchRange), errors.Is(err, ErrSettlementQueue):
+		return rpcTypes.ErrorCodeSettlementQueue
+	default:
+		return rpcTypes.ErrorCodeInternal
+	}
+}
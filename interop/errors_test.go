@@ -0,0 +1,66 @@
+package interop
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/0xPolygon/agglayer/etherman"
+	rpcTypes "github.com/0xPolygon/agglayer/rpc/types"
+	"github.com/0xPolygon/agglayer/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err      error
+		expected int
+	}{
+		{fmt.Errorf("%w: rollup 1", types.ErrUnknownRollup), rpcTypes.ErrorCodeUnknownRollup},
+		{fmt.Errorf("%w: there is no RPC registered for 1", ErrRollupNotConfigured), rpcTypes.ErrorCodeUnknownRollup},
+		{fmt.Errorf("%w: expected length 768, got 1", ErrInvalidProof), rpcTypes.ErrorCodeInvalidParams},
+		{ErrInvalidBatchRange, rpcTypes.ErrorCodeInvalidParams},
+		{ErrInvalidSignature, rpcTypes.ErrorCodeInvalidSignature},
+		{ErrUnauthorizedSigner, rpcTypes.ErrorCodeUnauthorizedSigner},
+		{fmt.Errorf("failed to verify ZKP: %w", ErrProofRejected), rpcTypes.ErrorCodeProofRejected},
+		{fmt.Errorf("%w: %w", ErrInvalidPendingState, etherman.ErrPendingStateNotFound), rpcTypes.ErrorCodeInvalidPendingState},
+		{ErrStateRootMismatch, rpcTypes.ErrorCodeStateRootMismatch},
+		{errors.Join(ErrFullNodeUnavailable, ErrStateRootMismatch), rpcTypes.ErrorCodeStateRootMismatch},
+		{ErrFullNodeUnavailable, rpcTypes.ErrorCodeFullNodeUnavailable},
+		{ErrFullNodeDivergence, rpcTypes.ErrorCodeFullNodeDivergence},
+		{fmt.Errorf("%w: error: %w", ErrFullNodeUnavailable, ErrFullNodeDivergence), rpcTypes.ErrorCodeFullNodeDivergence},
+		{ErrOverlappingBatchRange, rpcTypes.ErrorCodeSettlementQueue},
+		{ErrNonContiguousBatchRange, rpcTypes.ErrorCodeSettlementQueue},
+		{ErrSettlementQueue, rpcTypes.ErrorCodeSettlementQueue},
+		{errors.New("unexpected"), rpcTypes.ErrorCodeInternal},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, ErrorCode(tc.err), tc.err.Error())
+	}
+}
+
+func TestIsFinal(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{fmt.Errorf("%w: unexpected signer", ErrUnauthorizedSigner), true},
+		{fmt.Errorf("failed to verify ZKP: %w", ErrProofRejected), true},
+		{errors.Join(ErrFullNodeUnavailable, ErrStateRootMismatch), true},
+		{ErrOverlappingBatchRange, true},
+		{fmt.Errorf("failed to verify ZKP: %w: %w", ErrInvalidPendingState, etherman.ErrPendingStateBatchMismatch), true},
+		{ErrFullNodeUnavailable, false},
+		{ErrFullNodeDivergence, false},
+		{fmt.Errorf("%w: failed to add tx to ethTxMan", ErrSettlementQueue), false},
+		{fmt.Errorf("failed to call verify ZKP: %w", errors.New("connection refused")), false},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, isFinal(tc.err), tc.err.Error())
+	}
+}
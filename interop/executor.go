@@ -5,9 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
+	"sync"
 
 	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/etherman"
+	rpcTypes "github.com/0xPolygon/agglayer/rpc/types"
 	"github.com/0xPolygon/agglayer/tx"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
 	"github.com/0xPolygon/agglayer/types"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -16,22 +21,14 @@ import (
 
 	"github.com/0xPolygon/agglayer/log"
 	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
-	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/jackc/pgx/v4"
 )
 
 const meterName = "github.com/0xPolygon/agglayer/interop"
 
-var _ types.IZkEVMClientClientCreator = (*zkEVMClientCreator)(nil)
-
-type zkEVMClientCreator struct{}
-
-func (zc *zkEVMClientCreator) NewClient(rpc string) types.IZkEVMClient {
-	return client.NewClient(rpc)
-}
-
 type Executor struct {
 	logger             *zap.SugaredLogger
 	meter              metric.Meter
@@ -39,7 +36,13 @@ type Executor struct {
 	config             *config.Config
 	ethTxMan           types.IEthTxManager
 	etherman           types.IEtherman
+	settlements        *settlementTracker
+	fullNodePoolsMu    sync.Mutex
+	fullNodePools      map[uint32]*fullNodePool
+	quorumPoolsMu      sync.Mutex
+	quorumPools        map[uint32]*fullNodePool
 	ZkEVMClientCreator types.IZkEVMClientClientCreator
+	RollupDiscovery    types.IRollupDiscovery
 }
 
 func New(
@@ -58,17 +61,34 @@ func New(
 		config:             cfg,
 		ethTxMan:           ethTxManager,
 		etherman:           etherman,
-		ZkEVMClientCreator: &zkEVMClientCreator{},
+		settlements:        newSettlementTracker(),
+		fullNodePools:      make(map[uint32]*fullNodePool),
+		quorumPools:        make(map[uint32]*fullNodePool),
+		ZkEVMClientCreator: newZkEVMClientRegistry(logger, cfg.ZkEVMClient),
 	}
 }
 
 const ethTxManOwner = "interop"
 
+// revertedErrorCode is the code of the L1 calls that revert
+const revertedErrorCode = 3
+
+const (
+	signatureSchemeTypedData = "typed_data"
+	signatureSchemeLegacy    = "legacy"
+)
+
 func (e *Executor) CheckTx(tx tx.SignedTx) error {
-	// Check if the RPC is actually registered, if not it won't be possible to assert soundness (in the future once we are stateless won't be needed)
-	// TODO: The JSON parsing of the contract is incorrect
-	if _, ok := e.config.FullNodeRPCs[tx.Tx.RollupID]; !ok {
-		return fmt.Errorf("there is no RPC registered for %v", tx.Tx.RollupID)
+	// Check the rollup is registered in the rollup manager, if its rollups are being discovered
+	if e.RollupDiscovery != nil {
+		if _, err := e.RollupDiscovery.Rollup(tx.Tx.RollupID); err != nil {
+			return err
+		}
+	}
+
+	// Check if the soundness of the tx can be asserted, for most rollups it means an RPC is registered
+	if _, err := e.soundnessChecker(tx.Tx.RollupID); err != nil {
+		return err
 	}
 
 	opts := metric.WithAttributes(attribute.Key("rollup_id").Int(int(tx.Tx.RollupID)))
@@ -81,25 +101,44 @@ func (e *Executor) CheckTx(tx tx.SignedTx) error {
 	return nil
 }
 
-func (e *Executor) Verify(ctx context.Context, tx tx.SignedTx) error {
-	err := e.verifyZKP(ctx, tx)
+// Verify checks the signature and the ZKP of the tx, it returns the authorized signer of the tx
+// and its signature scheme
+func (e *Executor) Verify(ctx context.Context, tx tx.SignedTx) (common.Address, string, error) {
+	// The signature is checked first as the ZKP is verified with an L1 call
+	signer, scheme, err := e.verifySignature(tx)
 	if err != nil {
-		return fmt.Errorf("failed to verify ZKP: %s", err)
+		return common.Address{}, "", err
 	}
 
-	return e.verifySignature(tx)
+	if err := e.verifyZKP(ctx, tx); err != nil {
+		return common.Address{}, "", fmt.Errorf("failed to verify ZKP: %w", err)
+	}
+
+	return signer, scheme, nil
 }
 
-func (e *Executor) verifyZKP(ctx context.Context, stx tx.SignedTx) error {
-	// Verify ZKP using eth_call
-	l1TxData, err := e.etherman.BuildTrustedVerifyBatchesTxData(
+// buildVerifyBatchesTxData builds the data of the L1 tx settling the tx, a pending state
+// the tx can't be settled from makes it invalid
+func (e *Executor) buildVerifyBatchesTxData(stx tx.SignedTx) ([]byte, error) {
+	data, err := e.etherman.BuildTrustedVerifyBatchesTxData(
 		uint64(stx.Tx.LastVerifiedBatch),
 		uint64(stx.Tx.NewVerifiedBatch),
 		stx.Tx.ZKP,
 		stx.Tx.RollupID,
+		stx.Tx.PendingState(),
 	)
+	if errors.Is(err, etherman.ErrPendingStateNotFound) || errors.Is(err, etherman.ErrPendingStateBatchMismatch) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPendingState, err)
+	}
+
+	return data, err
+}
+
+func (e *Executor) verifyZKP(ctx context.Context, stx tx.SignedTx) error {
+	// Verify ZKP using eth_call
+	l1TxData, err := e.buildVerifyBatchesTxData(stx)
 	if err != nil {
-		return fmt.Errorf("failed to build verify ZKP tx: %s", err)
+		return fmt.Errorf("failed to build verify ZKP tx: %w", err)
 	}
 
 	msg := ethereum.CallMsg{
@@ -109,9 +148,14 @@ func (e *Executor) verifyZKP(ctx context.Context, stx tx.SignedTx) error {
 	}
 	log.Debugf("verify batches trusted L1 call: %v", msg)
 
-	res, err := e.etherman.CallContract(ctx, msg, nil)
-	if err != nil {
-		return fmt.Errorf("failed to call verify ZKP response: %s, error: %s", res, err)
+	if _, err = e.etherman.CallContract(ctx, msg, nil); err != nil {
+		err = etherman.DecodeRevert(err)
+		if isReverted(err) {
+			e.countRejectedZKP(stx, err)
+			err = fmt.Errorf("%w: %w", ErrProofRejected, err)
+		}
+
+		return fmt.Errorf("failed to call verify ZKP: %w", err)
 	}
 
 	opts := metric.WithAttributes(attribute.Key("rollup_id").Int(int(stx.Tx.RollupID)))
@@ -124,73 +168,139 @@ func (e *Executor) verifyZKP(ctx context.Context, stx tx.SignedTx) error {
 	return nil
 }
 
-func (e *Executor) verifySignature(stx tx.SignedTx) error {
-	// Auth: check signature vs admin
-	signer, err := stx.Signer()
+func (e *Executor) verifySignature(stx tx.SignedTx) (common.Address, string, error) {
+	scheme, err := e.checkSigner(stx, e.etherman.GetSequencerAddr)
+	if err != nil {
+		return common.Address{}, "", err
+	}
+
+	opts := metric.WithAttributes(
+		attribute.Key("rollup_id").Int(int(stx.Tx.RollupID)),
+		attribute.Key("scheme").String(scheme),
+	)
+	c, err := e.meter.Int64Counter("verify_signature")
+	if err != nil {
+		e.logger.Warnf("failed to create check_tx counter: %s", err)
+	}
+	c.Add(context.Background(), 1, opts)
+
+	return e.authorizedSigner(stx, scheme), scheme, nil
+}
+
+// checkSigner checks the tx is signed by the authorized proof signer of the rollup or, if it
+// has none, by the sequencer returned by the lookup. It returns the scheme of the signature
+func (e *Executor) checkSigner(stx tx.SignedTx, sequencerLookup func(rollupID uint32) (common.Address, error)) (string, error) {
+	// Auth: check signature vs admin, legacy signatures are recovered as well while the migration window is open
+	signer, legacySigner, err := e.recoverSigners(stx)
 	if err != nil {
-		return errors.New("failed to get signer")
+		return "", fmt.Errorf("%w: failed to get signer", ErrInvalidSignature)
 	}
 
 	// Attempt to retrieve the authorized proof signer for the given rollup, if one exists
-	authorizedProofSigner, hasKey := e.config.ProofSigners[stx.Tx.RollupID]
+	authorizedProofSigner, hasKey := e.config.Rollups().ProofSigner(stx.Tx.RollupID)
 
 	// If an authorized proof signer is defined and matches the signer, no further checks are needed
+	var scheme string
 	if hasKey {
 		// If an authorized proof signer exists but does not match the signer, return an error.
-		if authorizedProofSigner != signer {
-			return fmt.Errorf("unexpected signer: expected authorized signer %s, but got %s", authorizedProofSigner, signer)
+		if scheme = signatureScheme(authorizedProofSigner, signer, legacySigner); scheme == "" {
+			return "", fmt.Errorf("%w: unexpected signer: expected authorized signer %s, but got %s", ErrUnauthorizedSigner, authorizedProofSigner, signer)
 		}
 	} else {
-		sequencer, err := e.etherman.GetSequencerAddr(stx.Tx.RollupID)
+		sequencer, err := sequencerLookup(stx.Tx.RollupID)
 		if err != nil {
-			return errors.New("failed to get admin from L1")
+			return "", errors.New("failed to get admin from L1")
 		}
 
 		// If no specific authorized proof signer is defined, fall back to comparing with the sequencer
-		if sequencer != signer {
-			return fmt.Errorf("unexpected signer: expected sequencer %s but got %s", sequencer, signer)
+		if scheme = signatureScheme(sequencer, signer, legacySigner); scheme == "" {
+			return "", fmt.Errorf("%w: unexpected signer: expected sequencer %s but got %s", ErrUnauthorizedSigner, sequencer, signer)
 		}
 	}
 
-	opts := metric.WithAttributes(attribute.Key("rollup_id").Int(int(stx.Tx.RollupID)))
-	c, err := e.meter.Int64Counter("verify_signature")
-	if err != nil {
-		e.logger.Warnf("failed to create check_tx counter: %s", err)
+	return scheme, nil
+}
+
+// countRejectedZKP counts the ZKPs rejected by the rollup manager by the custom error it reverted with
+func (e *Executor) countRejectedZKP(stx tx.SignedTx, err error) {
+	reason := "unknown"
+	var revertErr *etherman.RevertError
+	if errors.As(err, &revertErr) {
+		reason = revertErr.Name
+	}
+
+	opts := metric.WithAttributes(
+		attribute.Key("rollup_id").Int(int(stx.Tx.RollupID)),
+		attribute.Key("reason").String(reason),
+	)
+	c, merr := e.meter.Int64Counter("zkp_rejected")
+	if merr != nil {
+		e.logger.Warnf("failed to create zkp_rejected counter: %s", merr)
 	}
 	c.Add(context.Background(), 1, opts)
+}
 
-	return nil
+// isReverted returns whether the L1 call failed because it reverted, as opposed to not reaching L1
+func isReverted(err error) bool {
+	var revertErr *etherman.RevertError
+	if errors.As(err, &revertErr) {
+		return true
+	}
+
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+
+	return rpcErr.ErrorCode() == revertedErrorCode || strings.Contains(rpcErr.Error(), "execution reverted")
 }
 
-func (e *Executor) Execute(ctx context.Context, signedTx tx.SignedTx) error {
-	// Check expected root vs root from the managed full node
-	// TODO: go stateless, depends on https://github.com/0xPolygonHermez/zkevm-prover/issues/581
-	// when this happens we should go async from here, since processing all the batches could take a lot of time
-	zkEVMClient := e.ZkEVMClientCreator.NewClient(e.config.FullNodeRPCs[signedTx.Tx.RollupID])
-	batch, err := zkEVMClient.BatchByNumber(
-		ctx,
-		big.NewInt(int64(signedTx.Tx.NewVerifiedBatch)),
-	)
+// signingDomain returns the typed data domain txs must be signed for
+func (e *Executor) signingDomain() tx.SigningDomain {
+	return tx.SigningDomain{
+		L1ChainID:             uint64(e.config.L1.ChainID),
+		RollupManagerContract: e.config.L1.RollupManagerContract,
+	}
+}
+
+// authorizedSigner returns the address that signed the tx under the scheme the signer was authorized with
+func (e *Executor) authorizedSigner(stx tx.SignedTx, scheme string) common.Address {
+	if scheme == signatureSchemeLegacy {
+		if signer, err := stx.Signer(); err == nil {
+			return signer
+		}
+	}
+
+	signer, err := stx.TypedDataSigner(e.signingDomain())
 	if err != nil {
-		return fmt.Errorf("failed to get batch from our node, error: %s", err)
+		return common.Address{}
 	}
-	log.Debugf("get batch by number: %v", batch)
 
-	if batch == nil {
-		return fmt.Errorf(
-			"unable to perform soundness check because batch with number %d is undefined",
-			signedTx.Tx.NewVerifiedBatch,
-		)
+	return signer
+}
+
+// signatureScheme returns the scheme under which the expected address signed the tx,
+// or an empty string if neither the typed data nor the legacy signer match
+func signatureScheme(expected, signer common.Address, legacySigner *common.Address) string {
+	if signer == expected {
+		return signatureSchemeTypedData
+	}
+	if legacySigner != nil && *legacySigner == expected {
+		return signatureSchemeLegacy
+	}
+	return ""
+}
+
+func (e *Executor) Execute(ctx context.Context, signedTx tx.SignedTx) error {
+	// Check expected root vs root from the rollup
+	// TODO: go stateless, depends on https://github.com/0xPolygonHermez/zkevm-prover/issues/581
+	checker, err := e.soundnessChecker(signedTx.Tx.RollupID)
+	if err != nil {
+		return err
 	}
 
-	if batch.StateRoot != signedTx.Tx.ZKP.NewStateRoot || batch.LocalExitRoot != signedTx.Tx.ZKP.NewLocalExitRoot {
-		return fmt.Errorf(
-			"mismatch detected, expected local exit root: %s actual: %s. expected state root: %s actual: %s",
-			signedTx.Tx.ZKP.NewLocalExitRoot.Hex(),
-			batch.LocalExitRoot.Hex(),
-			signedTx.Tx.ZKP.NewStateRoot.Hex(),
-			batch.StateRoot.Hex(),
-		)
+	if err = checker.CheckSoundness(ctx, signedTx); err != nil {
+		return err
 	}
 
 	opts := metric.WithAttributes(attribute.Key("rollup_id").Int(int(signedTx.Tx.RollupID)))
@@ -204,15 +314,27 @@ func (e *Executor) Execute(ctx context.Context, signedTx tx.SignedTx) error {
 }
 
 func (e *Executor) Settle(ctx context.Context, signedTx tx.SignedTx, dbTx pgx.Tx) (common.Hash, error) {
+	// Make sure the batch range follows what is settled or being settled for the rollup
+	inFlight, err := e.reserveSettlement(ctx, signedTx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to reserve settlement: %w", err)
+	}
+	if inFlight {
+		log.Debugf("tx %s is already being settled", signedTx.Tx.Hash().Hex())
+		return signedTx.Tx.Hash(), nil
+	}
+
+	// The tx may have been verified against a cached sequencer, make sure it wasn't rotated since
+	if _, err := e.checkSigner(signedTx, e.etherman.GetFreshSequencerAddr); err != nil {
+		e.ReleaseSettlement(signedTx)
+		return common.Hash{}, fmt.Errorf("failed to check signer before settling: %w", err)
+	}
+
 	// Send L1 tx
-	l1TxData, err := e.etherman.BuildTrustedVerifyBatchesTxData(
-		uint64(signedTx.Tx.LastVerifiedBatch),
-		uint64(signedTx.Tx.NewVerifiedBatch),
-		signedTx.Tx.ZKP,
-		signedTx.Tx.RollupID,
-	)
+	l1TxData, err := e.buildVerifyBatchesTxData(signedTx)
 	if err != nil {
-		return common.Hash{}, fmt.Errorf("failed to build verify ZKP tx: %s", err)
+		e.ReleaseSettlement(signedTx)
+		return common.Hash{}, fmt.Errorf("failed to build verify ZKP tx: %w", err)
 	}
 
 	if err := e.ethTxMan.Add(
@@ -224,9 +346,15 @@ func (e *Executor) Settle(ctx context.Context, signedTx tx.SignedTx, dbTx pgx.Tx
 		big.NewInt(0),
 		l1TxData,
 		e.config.EthTxManager.GasOffset,
+		&txmTypes.RollupBatches{
+			RollupID:          signedTx.Tx.RollupID,
+			LastVerifiedBatch: uint64(signedTx.Tx.LastVerifiedBatch),
+			NewVerifiedBatch:  uint64(signedTx.Tx.NewVerifiedBatch),
+		},
 		dbTx,
 	); err != nil {
-		return common.Hash{}, fmt.Errorf("failed to add tx to ethTxMan, error: %s", err)
+		e.ReleaseSettlement(signedTx)
+		return common.Hash{}, fmt.Errorf("%w: failed to add tx to ethTxMan, error: %w", ErrSettlementQueue, err)
 	}
 
 	log.Debugf("successfuly added tx %s to ethTxMan", signedTx.Tx.Hash().Hex())
@@ -242,9 +370,14 @@ func (e *Executor) Settle(ctx context.Context, signedTx tx.SignedTx, dbTx pgx.Tx
 
 func (e *Executor) GetTxStatus(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (result string, err jRPC.Error) {
 	res, innerErr := e.ethTxMan.Result(ctx, ethTxManOwner, hash.Hex(), dbTx)
-	if innerErr != nil {
+	if errors.Is(innerErr, txmTypes.ErrNotFound) {
+		result = "0x0"
+		err = jRPC.NewRPCError(rpcTypes.ErrorCodeTxNotFound, fmt.Sprintf("tx %s not found", hash.Hex()))
+
+		return
+	} else if innerErr != nil {
 		result = "0x0"
-		err = jRPC.NewRPCError(jRPC.DefaultErrorCode, fmt.Sprintf("failed to get tx, error: %s", innerErr))
+		err = jRPC.NewRPCError(rpcTypes.ErrorCodeDB, fmt.Sprintf("failed to get tx, error: %s", innerErr))
 
 		return
 	}
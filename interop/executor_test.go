@@ -6,9 +6,12 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/0xPolygon/agglayer/log"
+	agglayerRpcTypes "github.com/0xPolygon/agglayer/rpc/types"
 	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/0xPolygon/agglayer/types"
 	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
 	rpctypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -40,8 +43,8 @@ func TestNewExecutor(t *testing.T) {
 
 func TestExecutor_CheckTx(t *testing.T) {
 	cfg := &config.Config{
-		FullNodeRPCs: map[uint32]string{
-			1: "http://localhost:8545",
+		FullNodeRPCs: config.FullNodeRPCs{
+			1: {"http://localhost:8545"},
 		},
 	}
 	interopAdminAddr := common.HexToAddress("0x1234567890abcdef")
@@ -78,6 +81,20 @@ func TestExecutor_CheckTx(t *testing.T) {
 
 	err = executor.CheckTx(signedTx)
 	assert.Error(t, err)
+
+	// Rollups unknown to the rollup manager are rejected when they are discovered
+	rollupDiscovery := mocks.NewRollupDiscoveryMock(t)
+	executor.RollupDiscovery = rollupDiscovery
+
+	rollupDiscovery.On("Rollup", uint32(1)).Return(types.Rollup{ID: 1}, nil).Once()
+	signedTx.Tx.RollupID = 1
+	err = executor.CheckTx(signedTx)
+	assert.NoError(t, err)
+
+	rollupDiscovery.On("Rollup", uint32(2)).Return(types.Rollup{}, types.ErrUnknownRollup).Once()
+	signedTx.Tx.RollupID = 2
+	err = executor.CheckTx(signedTx)
+	assert.ErrorIs(t, err, types.ErrUnknownRollup)
 }
 
 func TestExecutor_VerifyZKP(t *testing.T) {
@@ -100,6 +117,7 @@ func TestExecutor_VerifyZKP(t *testing.T) {
 		uint64(tnx.NewVerifiedBatch),
 		mock.Anything,
 		uint32(1),
+		uint64(0),
 	).Return(
 		[]byte{},
 		nil,
@@ -159,7 +177,7 @@ func TestExecutor_VerifySignature(t *testing.T) {
 		signedTx, err := txn.Sign(sequencerKey)
 		require.NoError(t, err)
 
-		err = executor.verifySignature(*signedTx)
+		_, _, err = executor.verifySignature(*signedTx)
 		require.NoError(t, err)
 		etherman.AssertExpectations(t)
 	})
@@ -176,7 +194,7 @@ func TestExecutor_VerifySignature(t *testing.T) {
 		signedTx, err := txn.Sign(sequencerKey)
 		require.NoError(t, err)
 
-		err = executor.verifySignature(*signedTx)
+		_, _, err = executor.verifySignature(*signedTx)
 		require.Error(t, err)
 		etherman.AssertExpectations(t)
 	})
@@ -185,14 +203,14 @@ func TestExecutor_VerifySignature(t *testing.T) {
 		anotherKey, err := crypto.GenerateKey()
 		require.NoError(t, err)
 
-		cfg.ProofSigners = config.ProofSigners{1: crypto.PubkeyToAddress(anotherKey.PublicKey)}
+		require.NoError(t, cfg.Rollups().Update("test", cfg.FullNodeRPCs, config.ProofSigners{1: crypto.PubkeyToAddress(anotherKey.PublicKey)}))
 
 		signedTx, err := txn.Sign(anotherKey)
 		require.NoError(t, err)
 
 		executor = New(nil, cfg, interopAdminAddr, etherman, ethTxManager)
 
-		err = executor.verifySignature(*signedTx)
+		_, _, err = executor.verifySignature(*signedTx)
 		require.NoError(t, err)
 	})
 
@@ -200,16 +218,79 @@ func TestExecutor_VerifySignature(t *testing.T) {
 		anotherKey, err := crypto.GenerateKey()
 		require.NoError(t, err)
 
-		cfg.ProofSigners = config.ProofSigners{1: common.Address{0x1}}
+		require.NoError(t, cfg.Rollups().Update("test", cfg.FullNodeRPCs, config.ProofSigners{1: common.Address{0x1}}))
 
 		signedTx, err := txn.Sign(anotherKey)
 		require.NoError(t, err)
 
 		executor = New(nil, cfg, interopAdminAddr, etherman, ethTxManager)
 
-		err = executor.verifySignature(*signedTx)
+		_, _, err = executor.verifySignature(*signedTx)
 		require.Error(t, err)
 	})
+
+	t.Run("typed data signature, correct domain", func(t *testing.T) {
+		cfg := &config.Config{
+			L1:           config.L1Config{ChainID: 1337, RollupManagerContract: common.HexToAddress("0xdeadbeef")},
+			ProofSigners: config.ProofSigners{1: crypto.PubkeyToAddress(sequencerKey.PublicKey)},
+		}
+		executor := New(nil, cfg, interopAdminAddr, etherman, ethTxManager)
+
+		signedTx, err := txn.SignTypedData(sequencerKey, executor.signingDomain())
+		require.NoError(t, err)
+
+		_, _, err = executor.verifySignature(*signedTx)
+		require.NoError(t, err)
+	})
+
+	t.Run("typed data signature, wrong domain", func(t *testing.T) {
+		cfg := &config.Config{
+			L1:           config.L1Config{ChainID: 1337, RollupManagerContract: common.HexToAddress("0xdeadbeef")},
+			ProofSigners: config.ProofSigners{1: crypto.PubkeyToAddress(sequencerKey.PublicKey)},
+		}
+		executor := New(nil, cfg, interopAdminAddr, etherman, ethTxManager)
+
+		signedTx, err := txn.SignTypedData(sequencerKey, tx.SigningDomain{L1ChainID: 1})
+		require.NoError(t, err)
+
+		_, _, err = executor.verifySignature(*signedTx)
+		require.ErrorContains(t, err, "unexpected signer")
+	})
+
+	t.Run("typed data signature, replayed against another rollup", func(t *testing.T) {
+		cfg := &config.Config{
+			ProofSigners: config.ProofSigners{
+				1: crypto.PubkeyToAddress(sequencerKey.PublicKey),
+				2: crypto.PubkeyToAddress(sequencerKey.PublicKey),
+			},
+		}
+		executor := New(nil, cfg, interopAdminAddr, etherman, ethTxManager)
+
+		signedTx, err := txn.SignTypedData(sequencerKey, executor.signingDomain())
+		require.NoError(t, err)
+
+		signedTx.Tx.RollupID = 2
+		_, _, err = executor.verifySignature(*signedTx)
+		require.ErrorContains(t, err, "unexpected signer")
+	})
+
+	t.Run("legacy signature after the migration window", func(t *testing.T) {
+		cfg := &config.Config{
+			ProofSigners: config.ProofSigners{1: crypto.PubkeyToAddress(sequencerKey.PublicKey)},
+			Signatures:   config.SignaturesConfig{AcceptLegacyUntil: time.Now().Add(-time.Hour)},
+		}
+		executor := New(nil, cfg, interopAdminAddr, etherman, ethTxManager)
+
+		signedTx, err := txn.Sign(sequencerKey)
+		require.NoError(t, err)
+
+		_, _, err = executor.verifySignature(*signedTx)
+		require.ErrorContains(t, err, "unexpected signer")
+
+		cfg.Signatures.AcceptLegacyUntil = time.Now().Add(time.Hour)
+		_, _, err = executor.verifySignature(*signedTx)
+		require.NoError(t, err)
+	})
 }
 
 func TestExecutor_Execute(t *testing.T) {
@@ -231,7 +312,7 @@ func TestExecutor_Execute(t *testing.T) {
 	t.Run("Batch is not nil and roots match", func(t *testing.T) {
 		t.Parallel()
 
-		cfg := &config.Config{}
+		cfg := &config.Config{FullNodeRPCs: config.FullNodeRPCs{0: {"http://localhost:8545"}}}
 		interopAdminAddr := common.HexToAddress("0x1234567890abcdef")
 		etherman := mocks.NewEthermanMock(t)
 		ethTxManager := mocks.NewEthTxManagerMock(t)
@@ -262,7 +343,7 @@ func TestExecutor_Execute(t *testing.T) {
 	t.Run("Returns expected error when Batch is nil", func(t *testing.T) {
 		t.Parallel()
 
-		cfg := &config.Config{}
+		cfg := &config.Config{FullNodeRPCs: config.FullNodeRPCs{0: {"http://localhost:8545"}}}
 		interopAdminAddr := common.HexToAddress("0x1234567890abcdef")
 		etherman := mocks.NewEthermanMock(t)
 		ethTxManager := mocks.NewEthTxManagerMock(t)
@@ -301,16 +382,23 @@ func TestExecutor_Settle(t *testing.T) {
 
 	executor := New(nil, cfg, interopAdminAddr, etherman, ethTxManager)
 
-	signedTx := tx.SignedTx{
-		Tx: tx.Tx{
-			LastVerifiedBatch: 0,
-			NewVerifiedBatch:  1,
-			ZKP: tx.ZKP{
-				Proof: []byte("sampleProof"),
-			},
-			RollupID: 1,
+	sequencerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	txn := tx.Tx{
+		LastVerifiedBatch: 0,
+		NewVerifiedBatch:  1,
+		ZKP: tx.ZKP{
+			Proof: []byte("sampleProof"),
 		},
+		RollupID: 1,
 	}
+	signed, err := txn.Sign(sequencerKey)
+	require.NoError(t, err)
+	signedTx := *signed
+
+	etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(0), nil).Once()
+	etherman.On("GetFreshSequencerAddr", uint32(1)).Return(crypto.PubkeyToAddress(sequencerKey.PublicKey), nil).Once()
 
 	l1TxData := []byte("sampleL1TxData")
 	etherman.On(
@@ -319,6 +407,7 @@ func TestExecutor_Settle(t *testing.T) {
 		uint64(signedTx.Tx.NewVerifiedBatch),
 		signedTx.Tx.ZKP,
 		uint32(1),
+		uint64(0),
 	).Return(
 		l1TxData,
 		nil,
@@ -335,6 +424,11 @@ func TestExecutor_Settle(t *testing.T) {
 		big.NewInt(0),
 		l1TxData,
 		uint64(0),
+		&txmTypes.RollupBatches{
+			RollupID:          1,
+			LastVerifiedBatch: uint64(signedTx.Tx.LastVerifiedBatch),
+			NewVerifiedBatch:  uint64(signedTx.Tx.NewVerifiedBatch),
+		},
 		dbTx,
 	).Return(
 		nil,
@@ -348,6 +442,36 @@ func TestExecutor_Settle(t *testing.T) {
 	ethTxManager.AssertExpectations(t)
 }
 
+func TestExecutor_SettleRotatedSequencer(t *testing.T) {
+	cfg := &config.Config{}
+	etherman := mocks.NewEthermanMock(t)
+	ethTxManager := mocks.NewEthTxManagerMock(t)
+
+	executor := New(nil, cfg, common.HexToAddress("0x1234567890abcdef"), etherman, ethTxManager)
+
+	sequencerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	txn := tx.Tx{NewVerifiedBatch: 1, RollupID: 1}
+	signedTx, err := txn.Sign(sequencerKey)
+	require.NoError(t, err)
+
+	// verified against the cached sequencer, which was rotated before settling
+	etherman.On("GetSequencerAddr", uint32(1)).Return(crypto.PubkeyToAddress(sequencerKey.PublicKey), nil).Once()
+	etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(0), nil).Twice()
+	etherman.On("GetFreshSequencerAddr", uint32(1)).Return(common.Address{0x1}, nil).Twice()
+
+	_, _, err = executor.verifySignature(*signedTx)
+	require.NoError(t, err)
+
+	_, err = executor.Settle(context.Background(), *signedTx, &mocks.TxMock{})
+	require.ErrorContains(t, err, "unexpected signer")
+
+	// the settlement was released, so it's checked again on retry
+	_, err = executor.Settle(context.Background(), *signedTx, &mocks.TxMock{})
+	require.ErrorContains(t, err, "unexpected signer")
+}
+
 func TestExecutor_GetTxStatus(t *testing.T) {
 	cfg := &config.Config{}
 	interopAdminAddr := common.HexToAddress("0x1234567890abcdef")
@@ -359,7 +483,7 @@ func TestExecutor_GetTxStatus(t *testing.T) {
 
 	hash := common.HexToHash("0x1234567890abcdef")
 	expectedResult := "0x1"
-	expectedError := jRPC.NewRPCError(rpctypes.DefaultErrorCode, "failed to get tx, error: sampleError")
+	expectedError := jRPC.NewRPCError(agglayerRpcTypes.ErrorCodeDB, "failed to get tx, error: sampleError")
 
 	ethTxManager.On("Result", mock.Anything, ethTxManOwner, hash.Hex(), dbTx).
 		Return(txmTypes.MonitoredTxResult{
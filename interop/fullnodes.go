@@ -0,0 +1,228 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/types"
+	rpctypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+var _ types.IZkEVMClient = (*fullNodePool)(nil)
+
+// fullNodeEndpoint is a full node of a rollup
+type fullNodeEndpoint struct {
+	url    string
+	client types.IZkEVMClient
+}
+
+// healthReporter is implemented by the clients tracking the health of their full node,
+// the clients that don't are always considered healthy
+type healthReporter interface {
+	healthy() bool
+}
+
+// fullNodePool queries the full nodes of a rollup, failing over unhealthy ones
+// or, with a quorum above 1, requiring that many of them to return matching batches
+type fullNodePool struct {
+	logger   *zap.SugaredLogger
+	meter    metric.Meter
+	rollupID uint32
+	cfg      config.FullNodesConfig
+	quorum   int
+
+	mu        sync.Mutex
+	endpoints []*fullNodeEndpoint
+	next      int
+}
+
+// fullNodes returns the pool of full nodes of the rollup, the pool is kept across
+// calls to keep its round robin position and rebuilt if the URLs change
+func (e *Executor) fullNodes(rollupID uint32) (*fullNodePool, error) {
+	urls, ok := e.config.Rollups().FullNodeRPCs(rollupID)
+	if !ok || len(urls) == 0 {
+		return nil, fmt.Errorf("%w: there is no RPC registered for %v", ErrRollupNotConfigured, rollupID)
+	}
+
+	e.fullNodePoolsMu.Lock()
+	defer e.fullNodePoolsMu.Unlock()
+
+	if pool, ok := e.fullNodePools[rollupID]; ok && slices.Equal(pool.urls(), urls) {
+		return pool, nil
+	}
+
+	pool := e.newFullNodePool(rollupID, urls, 0)
+	e.fullNodePools[rollupID] = pool
+
+	return pool, nil
+}
+
+// newFullNodePool returns a pool of the full nodes of the URLs, requiring the quorum if above 1
+func (e *Executor) newFullNodePool(rollupID uint32, urls []string, quorum int) *fullNodePool {
+	pool := &fullNodePool{
+		logger:    e.logger,
+		meter:     e.meter,
+		rollupID:  rollupID,
+		cfg:       e.config.FullNodes,
+		quorum:    quorum,
+		endpoints: make([]*fullNodeEndpoint, 0, len(urls)),
+	}
+	for _, url := range urls {
+		pool.endpoints = append(pool.endpoints, &fullNodeEndpoint{
+			url:    url,
+			client: e.ZkEVMClientCreator.NewClient(url),
+		})
+	}
+
+	return pool
+}
+
+func (p *fullNodePool) urls() []string {
+	urls := make([]string, 0, len(p.endpoints))
+	for _, endpoint := range p.endpoints {
+		urls = append(urls, endpoint.url)
+	}
+
+	return urls
+}
+
+// BatchByNumber returns the batch from the first full node that answers, or the
+// batch a quorum of full nodes agree on if one is configured
+func (p *fullNodePool) BatchByNumber(ctx context.Context, number *big.Int) (*rpctypes.Batch, error) {
+	endpoints := p.order()
+
+	if p.quorum > 1 {
+		return p.quorumBatchByNumber(ctx, endpoints, number)
+	}
+
+	errs := make([]error, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		batch, err := endpoint.client.BatchByNumber(ctx, number)
+		if err != nil {
+			p.logger.Warnf("full node %s of rollup %d failed: %s", endpoint.url, p.rollupID, err)
+			errs = append(errs, fmt.Errorf("%s: %w", endpoint.url, err))
+			continue
+		}
+
+		return batch, nil
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// batchRoots identifies the content of a batch the soundness check depends on
+type batchRoots struct {
+	stateRoot     common.Hash
+	localExitRoot common.Hash
+}
+
+// quorumBatchByNumber queries all the full nodes and returns the batch at least quorum of them agree on
+func (p *fullNodePool) quorumBatchByNumber(ctx context.Context, endpoints []*fullNodeEndpoint, number *big.Int) (*rpctypes.Batch, error) {
+	batches := make([]*rpctypes.Batch, len(endpoints))
+	errs := make([]error, len(endpoints))
+
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *fullNodeEndpoint) {
+			defer wg.Done()
+			batches[i], errs[i] = endpoint.client.BatchByNumber(ctx, number)
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	votes := make(map[batchRoots]int)
+	var (
+		winner *rpctypes.Batch
+		agreed int
+	)
+	for i, endpoint := range endpoints {
+		// a full node lagging behind doesn't have the batch yet, it doesn't vote for another version of it
+		if errs[i] == nil && batches[i] == nil {
+			errs[i] = fmt.Errorf("batch %d not found", number)
+		}
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("%s: %w", endpoint.url, errs[i])
+			continue
+		}
+
+		roots := batchRoots{stateRoot: batches[i].StateRoot, localExitRoot: batches[i].LocalExitRoot}
+		votes[roots]++
+		if votes[roots] > agreed {
+			agreed = votes[roots]
+			winner = batches[i]
+		}
+	}
+
+	if len(votes) > 1 {
+		p.reportDivergence(ctx, number, votes)
+	}
+
+	if agreed >= p.quorum {
+		return winner, nil
+	}
+
+	if len(votes) > 1 {
+		return nil, fmt.Errorf(
+			"%w: full nodes returned %d distinct versions of batch %d and %d matching ones are required",
+			ErrFullNodeDivergence,
+			len(votes),
+			number,
+			p.quorum,
+		)
+	}
+
+	return nil, fmt.Errorf(
+		"quorum not reached, %d of %d full nodes agree and %d are required: %w",
+		agreed,
+		len(endpoints),
+		p.quorum,
+		errors.Join(errs...),
+	)
+}
+
+// order returns the endpoints in the order they must be queried, healthy ones first
+// following the strategy and the ones with an open circuit breaker as last resort
+func (p *fullNodePool) order() []*fullNodeEndpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	start := 0
+	if p.cfg.Strategy == config.FullNodeStrategyRoundRobin {
+		start = p.next
+		p.next = (p.next + 1) % len(p.endpoints)
+	}
+
+	healthy := make([]*fullNodeEndpoint, 0, len(p.endpoints))
+	unhealthy := make([]*fullNodeEndpoint, 0)
+	for i := range p.endpoints {
+		endpoint := p.endpoints[(start+i)%len(p.endpoints)]
+		if reporter, ok := endpoint.client.(healthReporter); ok && !reporter.healthy() {
+			unhealthy = append(unhealthy, endpoint)
+		} else {
+			healthy = append(healthy, endpoint)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+func (p *fullNodePool) reportDivergence(ctx context.Context, number *big.Int, votes map[batchRoots]int) {
+	p.logger.Warnf("full nodes of rollup %d diverge on batch %d: %v", p.rollupID, number, votes)
+
+	opts := metric.WithAttributes(attribute.Key("rollup_id").Int(int(p.rollupID)))
+	c, err := p.meter.Int64Counter("full_node_divergence")
+	if err != nil {
+		p.logger.Warnf("failed to create full_node_divergence counter: %s", err)
+	}
+	c.Add(ctx, 1, opts)
+}
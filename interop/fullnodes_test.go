@@ -0,0 +1,206 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	rpctypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// unhealthyClient is a client whose circuit breaker is open
+type unhealthyClient struct {
+	*mocks.ZkEVMClientMock
+}
+
+func (c *unhealthyClient) healthy() bool {
+	return false
+}
+
+func TestExecutor_FullNodes(t *testing.T) {
+	t.Parallel()
+
+	newExecutor := func(t *testing.T, urls []string, fullNodes config.FullNodesConfig) (*Executor, []*mocks.ZkEVMClientMock) {
+		t.Helper()
+
+		cfg := &config.Config{
+			FullNodeRPCs: config.FullNodeRPCs{1: urls},
+			FullNodes:    fullNodes,
+		}
+		e := New(log.WithFields("test", "test"), cfg, common.HexToAddress("0xadmin"),
+			mocks.NewEthermanMock(t), mocks.NewEthTxManagerMock(t))
+
+		creator := mocks.NewZkEVMClientClientCreatorMock(t)
+		clients := make([]*mocks.ZkEVMClientMock, 0, len(urls))
+		for _, url := range urls {
+			client := mocks.NewZkEVMClientMock(t)
+			creator.On("NewClient", url).Return(client).Once()
+			clients = append(clients, client)
+		}
+		e.ZkEVMClientCreator = creator
+
+		return e, clients
+	}
+
+	quorumPool := func(e *Executor, quorum int) (*fullNodePool, error) {
+		urls, _ := e.config.Rollups().FullNodeRPCs(1)
+
+		return e.quorumPool(1, config.SoundnessConfig{Mode: config.SoundnessModeQuorum, RPCs: urls, Quorum: quorum})
+	}
+
+	batch := func(stateRoot string) *rpctypes.Batch {
+		return &rpctypes.Batch{
+			StateRoot:     common.HexToHash(stateRoot),
+			LocalExitRoot: common.HexToHash("0xlocalexitroot"),
+		}
+	}
+
+	t.Run("pool is reused and rebuilt when the URLs change", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := newExecutor(t, []string{"http://a", "http://b"}, config.FullNodesConfig{})
+
+		pool, err := e.fullNodes(1)
+		require.NoError(t, err)
+
+		cached, err := e.fullNodes(1)
+		require.NoError(t, err)
+		require.Same(t, pool, cached)
+
+		creator := mocks.NewZkEVMClientClientCreatorMock(t)
+		creator.On("NewClient", "http://c").Return(mocks.NewZkEVMClientMock(t)).Once()
+		e.ZkEVMClientCreator = creator
+		require.NoError(t, e.config.Rollups().Update("test", config.FullNodeRPCs{1: {"http://c"}}, nil))
+
+		rebuilt, err := e.fullNodes(1)
+		require.NoError(t, err)
+		require.NotSame(t, pool, rebuilt)
+		require.Equal(t, []string{"http://c"}, rebuilt.urls())
+	})
+
+	t.Run("fails over to the next full node", func(t *testing.T) {
+		t.Parallel()
+
+		e, clients := newExecutor(t, []string{"http://a", "http://b"}, config.FullNodesConfig{Strategy: config.FullNodeStrategyPriority})
+
+		clients[0].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(nil, errors.New("unavailable")).Once()
+		clients[1].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(batch("0x1"), nil).Twice()
+
+		pool, err := e.fullNodes(1)
+		require.NoError(t, err)
+
+		result, err := pool.BatchByNumber(context.Background(), big.NewInt(1))
+		require.NoError(t, err)
+		require.Equal(t, batch("0x1"), result)
+
+		// the circuit breaker of the failing full node opened so it isn't queried first anymore
+		pool.endpoints[0].client = &unhealthyClient{clients[0]}
+		require.Equal(t, "http://b", pool.order()[0].url)
+
+		result, err = pool.BatchByNumber(context.Background(), big.NewInt(1))
+		require.NoError(t, err)
+		require.Equal(t, batch("0x1"), result)
+	})
+
+	t.Run("all full nodes fail", func(t *testing.T) {
+		t.Parallel()
+
+		e, clients := newExecutor(t, []string{"http://a", "http://b"}, config.FullNodesConfig{})
+
+		clients[0].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(nil, errors.New("error a")).Once()
+		clients[1].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(nil, errors.New("error b")).Once()
+
+		pool, err := e.fullNodes(1)
+		require.NoError(t, err)
+
+		_, err = pool.BatchByNumber(context.Background(), big.NewInt(1))
+		require.ErrorContains(t, err, "http://a: error a")
+		require.ErrorContains(t, err, "http://b: error b")
+	})
+
+	t.Run("round robin", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := newExecutor(t, []string{"http://a", "http://b", "http://c"},
+			config.FullNodesConfig{Strategy: config.FullNodeStrategyRoundRobin})
+
+		pool, err := e.fullNodes(1)
+		require.NoError(t, err)
+
+		for _, first := range []string{"http://a", "http://b", "http://c", "http://a"} {
+			require.Equal(t, first, pool.order()[0].url)
+		}
+	})
+
+	t.Run("quorum reached", func(t *testing.T) {
+		t.Parallel()
+
+		e, clients := newExecutor(t, []string{"http://a", "http://b", "http://c"}, config.FullNodesConfig{})
+
+		clients[0].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(batch("0x1"), nil).Once()
+		clients[1].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(batch("0x2"), nil).Once()
+		clients[2].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(batch("0x1"), nil).Once()
+
+		pool, err := quorumPool(e, 2)
+		require.NoError(t, err)
+
+		result, err := pool.BatchByNumber(context.Background(), big.NewInt(1))
+		require.NoError(t, err)
+		require.Equal(t, batch("0x1"), result)
+	})
+
+	t.Run("full nodes diverge", func(t *testing.T) {
+		t.Parallel()
+
+		e, clients := newExecutor(t, []string{"http://a", "http://b", "http://c"}, config.FullNodesConfig{})
+
+		clients[0].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(batch("0x1"), nil).Once()
+		clients[1].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(batch("0x2"), nil).Once()
+		clients[2].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(nil, errors.New("unavailable")).Once()
+
+		pool, err := quorumPool(e, 2)
+		require.NoError(t, err)
+
+		_, err = pool.BatchByNumber(context.Background(), big.NewInt(1))
+		require.ErrorIs(t, err, ErrFullNodeDivergence)
+	})
+
+	t.Run("quorum not reached", func(t *testing.T) {
+		t.Parallel()
+
+		e, clients := newExecutor(t, []string{"http://a", "http://b"}, config.FullNodesConfig{})
+
+		clients[0].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(batch("0x1"), nil).Once()
+		clients[1].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(nil, errors.New("unavailable")).Once()
+
+		pool, err := quorumPool(e, 2)
+		require.NoError(t, err)
+
+		_, err = pool.BatchByNumber(context.Background(), big.NewInt(1))
+		require.NotErrorIs(t, err, ErrFullNodeDivergence)
+		require.ErrorContains(t, err, "quorum not reached, 1 of 2 full nodes agree and 2 are required")
+	})
+
+	t.Run("a lagging full node doesn't diverge", func(t *testing.T) {
+		t.Parallel()
+
+		e, clients := newExecutor(t, []string{"http://a", "http://b"}, config.FullNodesConfig{})
+
+		clients[0].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(batch("0x1"), nil).Once()
+		clients[1].On("BatchByNumber", mock.Anything, big.NewInt(1)).Return(nil, nil).Once()
+
+		pool, err := quorumPool(e, 2)
+		require.NoError(t, err)
+
+		_, err = pool.BatchByNumber(context.Background(), big.NewInt(1))
+		require.NotErrorIs(t, err, ErrFullNodeDivergence)
+		require.ErrorContains(t, err, "http://b: batch 1 not found")
+	})
+}
@@ -0,0 +1,69 @@
+package interop
+
+import (
+	"context"
+	"fmt"
+
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/0xPolygon/agglayer/types"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	// defaultListLimit is the page size when the filter doesn't set a limit
+	defaultListLimit = 100
+	// maxListLimit bounds the page size requested by the callers
+	maxListLimit = 1000
+)
+
+// ListTxs returns a page of the txs settled on L1 selected by the filter, newest first
+func (e *Executor) ListTxs(ctx context.Context, filter types.TxListFilter, dbTx pgx.Tx) (types.TxList, error) {
+	if err := filter.Validate(); err != nil {
+		return types.TxList{}, err
+	}
+
+	statuses, err := filter.MonitoredTxStatuses()
+	if err != nil {
+		return types.TxList{}, err
+	}
+
+	mFilter := txmTypes.MonitoredTxFilter{
+		Owner:         ethTxManOwner,
+		RollupID:      filter.RollupID,
+		Statuses:      statuses,
+		FromBatch:     (*uint64)(filter.FromBatch),
+		ToBatch:       (*uint64)(filter.ToBatch),
+		CreatedAfter:  filter.CreatedAfter,
+		CreatedBefore: filter.CreatedBefore,
+		Limit:         filter.Limit,
+	}
+
+	if mFilter.Limit == 0 {
+		mFilter.Limit = defaultListLimit
+	} else if mFilter.Limit > maxListLimit {
+		mFilter.Limit = maxListLimit
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := txmTypes.DecodeMonitoredTxCursor(filter.Cursor)
+		if err != nil {
+			return types.TxList{}, err
+		}
+		mFilter.Cursor = &cursor
+	}
+
+	mTxs, next, err := e.ethTxMan.List(ctx, mFilter, dbTx)
+	if err != nil {
+		return types.TxList{}, fmt.Errorf("failed to list txs: %w", err)
+	}
+
+	list := types.TxList{Txs: make([]types.TxSummary, 0, len(mTxs))}
+	for _, mTx := range mTxs {
+		list.Txs = append(list.Txs, types.NewTxSummary(mTx))
+	}
+	if next != nil {
+		list.NextCursor = next.Encode()
+	}
+
+	return list, nil
+}
@@ -0,0 +1,120 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/0xPolygon/agglayer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutor_ListTxs(t *testing.T) {
+	t.Parallel()
+
+	newExecutor := func(t *testing.T, ethTxManager types.IEthTxManager) *Executor {
+		t.Helper()
+
+		return New(log.WithFields("test", "test"), &config.Config{}, common.HexToAddress("0x1"), mocks.NewEthermanMock(t), ethTxManager)
+	}
+
+	t.Run("maps the filter and the page", func(t *testing.T) {
+		t.Parallel()
+
+		rollupID := uint32(1)
+		fromBatch, toBatch := hexutil.Uint64(10), hexutil.Uint64(20)
+		createdAfter := time.Now().Add(-time.Hour)
+		cursor := txmTypes.MonitoredTxCursor{CreatedAt: time.Now().UTC().Round(0), ID: common.HexToHash("0x3").Hex()}
+
+		settled := txmTypes.MonitoredTx{
+			ID:          common.HexToHash("0x1").Hex(),
+			Status:      txmTypes.MonitoredTxStatusConfirmed,
+			BlockNumber: big.NewInt(5),
+			Batches:     &txmTypes.RollupBatches{RollupID: 1, LastVerifiedBatch: 10, NewVerifiedBatch: 12},
+		}
+		legacy := txmTypes.MonitoredTx{ID: common.HexToHash("0x2").Hex(), Status: txmTypes.MonitoredTxStatusSent}
+		next := txmTypes.NewMonitoredTxCursor(legacy)
+
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+		ethTxManager.On("List", mock.Anything, txmTypes.MonitoredTxFilter{
+			Owner:        ethTxManOwner,
+			RollupID:     &rollupID,
+			Statuses:     []txmTypes.MonitoredTxStatus{txmTypes.MonitoredTxStatusConfirmed, txmTypes.MonitoredTxStatusSent},
+			FromBatch:    (*uint64)(&fromBatch),
+			ToBatch:      (*uint64)(&toBatch),
+			CreatedAfter: &createdAfter,
+			Cursor:       &cursor,
+			Limit:        defaultListLimit,
+		}, nil).Return([]txmTypes.MonitoredTx{settled, legacy}, &next, nil).Once()
+
+		list, err := newExecutor(t, ethTxManager).ListTxs(context.Background(), types.TxListFilter{
+			RollupID:     &rollupID,
+			Statuses:     []string{"confirmed", "sent"},
+			FromBatch:    &fromBatch,
+			ToBatch:      &toBatch,
+			CreatedAfter: &createdAfter,
+			Cursor:       cursor.Encode(),
+		}, nil)
+		require.NoError(t, err)
+
+		require.Len(t, list.Txs, 2)
+		require.Equal(t, common.HexToHash("0x1"), list.Txs[0].Hash)
+		require.Equal(t, "confirmed", list.Txs[0].Status)
+		require.Equal(t, int64(5), list.Txs[0].BlockNumber.ToInt().Int64())
+		require.Equal(t, uint32(1), *list.Txs[0].RollupID)
+		require.Equal(t, hexutil.Uint64(10), *list.Txs[0].LastVerifiedBatch)
+		require.Equal(t, hexutil.Uint64(12), *list.Txs[0].NewVerifiedBatch)
+		require.Nil(t, list.Txs[1].RollupID)
+		require.Nil(t, list.Txs[1].BlockNumber)
+		require.Equal(t, next.Encode(), list.NextCursor)
+	})
+
+	t.Run("clamps the limit", func(t *testing.T) {
+		t.Parallel()
+
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+		ethTxManager.On("List", mock.Anything, mock.MatchedBy(func(f txmTypes.MonitoredTxFilter) bool {
+			return f.Limit == maxListLimit
+		}), nil).Return(nil, nil, nil).Once()
+
+		list, err := newExecutor(t, ethTxManager).ListTxs(context.Background(), types.TxListFilter{Limit: maxListLimit + 1}, nil)
+		require.NoError(t, err)
+		require.Empty(t, list.Txs)
+		require.Empty(t, list.NextCursor)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		t.Parallel()
+
+		e := newExecutor(t, mocks.NewEthTxManagerMock(t))
+		fromBatch, toBatch := hexutil.Uint64(2), hexutil.Uint64(1)
+
+		_, err := e.ListTxs(context.Background(), types.TxListFilter{FromBatch: &fromBatch, ToBatch: &toBatch}, nil)
+		require.ErrorIs(t, err, types.ErrInvalidTxListFilter)
+
+		_, err = e.ListTxs(context.Background(), types.TxListFilter{Statuses: []string{"settled"}}, nil)
+		require.ErrorIs(t, err, types.ErrInvalidTxListFilter)
+
+		_, err = e.ListTxs(context.Background(), types.TxListFilter{Cursor: "not a cursor"}, nil)
+		require.ErrorIs(t, err, txmTypes.ErrInvalidCursor)
+	})
+
+	t.Run("failed to list", func(t *testing.T) {
+		t.Parallel()
+
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+		ethTxManager.On("List", mock.Anything, mock.Anything, nil).Return(nil, nil, errors.New("error")).Once()
+
+		_, err := newExecutor(t, ethTxManager).ListTxs(context.Background(), types.TxListFilter{}, nil)
+		require.ErrorContains(t, err, "failed to list txs")
+	})
+}
@@ -0,0 +1,382 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/tx"
+	"github.com/0xPolygon/agglayer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// maxBackoffShift bounds the exponential backoff of the txs postponed over and over
+const maxBackoffShift = 10
+
+// Pipeline drives the txs persisted in the intake queue through verification,
+// soundness checks and settlement, recording the outcome of each stage
+type Pipeline struct {
+	logger   *zap.SugaredLogger
+	meter    metric.Meter
+	cfg      config.IntakeConfig
+	executor *Executor
+	db       types.IDB
+
+	// StatusListener, if set, is notified every time a tx is received, verified or rejected.
+	// Once settling, the status of a tx is the one of its monitored tx
+	StatusListener types.IntakeStatusListener
+
+	notify chan struct{}
+	queue  chan types.IntakeTx
+
+	inFlightMu sync.Mutex
+	inFlight   map[common.Hash]struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewPipeline returns a pipeline processing the intake queue with the given executor
+func NewPipeline(
+	logger *zap.SugaredLogger,
+	cfg *config.Config,
+	executor *Executor,
+	db types.IDB,
+) *Pipeline {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	intakeCfg := cfg.Intake
+	if intakeCfg.Workers <= 0 {
+		intakeCfg.Workers = 1
+	}
+
+	return &Pipeline{
+		logger:   logger,
+		meter:    otel.Meter(meterName),
+		cfg:      intakeCfg,
+		executor: executor,
+		db:       db,
+		notify:   make(chan struct{}, 1),
+		queue:    make(chan types.IntakeTx),
+		inFlight: make(map[common.Hash]struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start runs the workers and polls the intake queue until the pipeline is stopped
+func (p *Pipeline) Start() {
+	for i := 0; i < p.cfg.Workers; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+
+	// txs can't be dispatched until the ones being settled are tracked again,
+	// otherwise overlapping ranges could reach L1
+	restored := false
+
+	for {
+		if !restored {
+			if err := p.restoreSettlements(); err != nil {
+				p.logger.Errorf("failed to restore settling intake txs: %s", err)
+			} else {
+				restored = true
+			}
+		}
+
+		if restored {
+			if err := p.dispatch(); err != nil {
+				p.logger.Errorf("failed to dispatch intake txs: %s", err)
+			}
+		}
+
+		select {
+		case <-p.ctx.Done():
+			p.wg.Wait()
+			return
+		case <-p.notify:
+		case <-time.After(p.cfg.FrequencyToProcessTxs.Duration):
+		}
+	}
+}
+
+// Stop stops polling the intake queue, txs being processed are interrupted and
+// picked up again on the next start
+func (p *Pipeline) Stop() {
+	p.cancel()
+}
+
+// Notify publishes the status of a just received tx and wakes up the pipeline so it doesn't wait for the next poll
+func (p *Pipeline) Notify(itx types.IntakeTx) {
+	p.notifyStatusChange(itx)
+
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+// restoreSettlements hands over to the executor the txs that were settling before the pipeline started
+func (p *Pipeline) restoreSettlements() error {
+	itxs, err := p.db.GetIntakeTxsByStatus(p.ctx, []types.IntakeTxStatus{types.IntakeTxStatusSettling}, nil)
+	if err != nil {
+		return err
+	}
+
+	stxs := make([]tx.SignedTx, 0, len(itxs))
+	for _, itx := range itxs {
+		stxs = append(stxs, itx.SignedTx)
+	}
+	p.executor.RestoreSettlements(stxs)
+
+	return nil
+}
+
+// dispatch hands over the pending txs of the intake queue to the workers
+func (p *Pipeline) dispatch() error {
+	itxs, err := p.db.GetIntakeTxsByStatus(
+		p.ctx,
+		[]types.IntakeTxStatus{types.IntakeTxStatusReceived, types.IntakeTxStatusVerified},
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, itx := range itxs {
+		if itx.NextAttemptAt != nil && itx.NextAttemptAt.After(now) {
+			continue
+		}
+
+		if !p.reserve(itx.Hash) {
+			continue
+		}
+
+		select {
+		case <-p.ctx.Done():
+			p.release(itx.Hash)
+			return nil
+		case p.queue <- itx:
+		}
+	}
+
+	return nil
+}
+
+// work processes the txs dispatched to the queue until the pipeline is stopped
+func (p *Pipeline) work() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case itx := <-p.queue:
+			if err := p.process(p.ctx, itx); err != nil {
+				p.logger.Errorf("failed to process intake tx %s: %s", itx.Hash.Hex(), err)
+			}
+			p.release(itx.Hash)
+		}
+	}
+}
+
+// process moves a tx to its next stages. Txs failing the checks or the settlement because of
+// the tx itself are rejected, any other error postpones the tx to be retried with a backoff
+func (p *Pipeline) process(parentCtx context.Context, itx types.IntakeTx) error {
+	ctx, cancel := context.WithTimeout(parentCtx, p.cfg.ProcessTimeout.Duration)
+	defer cancel()
+
+	if itx.Status == types.IntakeTxStatusReceived {
+		signer, scheme, err := p.verify(ctx, itx)
+		if err != nil {
+			if !isFinal(err) {
+				return p.postpone(ctx, itx, "verification", err)
+			}
+
+			return p.reject(ctx, itx, err)
+		}
+
+		now := time.Now().UTC().Round(time.Microsecond)
+		itx.Status = types.IntakeTxStatusVerified
+		itx.VerifiedAt = &now
+		itx.Signer = signer
+		itx.SignatureScheme = scheme
+		itx.Attempts = 0
+		itx.NextAttemptAt = nil
+		if err := p.db.UpdateIntakeTx(ctx, itx, nil); err != nil {
+			return fmt.Errorf("failed to update intake tx, error: %w", err)
+		}
+		p.count(ctx, itx)
+		p.notifyStatusChange(itx)
+	}
+
+	dbTx, err := p.db.BeginStateTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin dbTx, error: %w", err)
+	}
+
+	if _, err = p.executor.Settle(ctx, itx.SignedTx, dbTx); err != nil {
+		if errRollback := dbTx.Rollback(ctx); errRollback != nil {
+			p.logger.Errorf("rollback err: %s", errRollback)
+		}
+
+		// txs processed concurrently may reach settlement out of order, a gap is
+		// only final once the tx had enough time for the preceding ones to be settled, it isn't backed off meanwhile
+		if errors.Is(err, ErrNonContiguousBatchRange) && time.Since(itx.ReceivedAt) < p.cfg.ProcessTimeout.Duration {
+			return fmt.Errorf("settlement postponed, error: %w", err)
+		}
+		if !isFinal(err) {
+			return p.postpone(ctx, itx, "settlement", err)
+		}
+
+		return p.reject(ctx, itx, err)
+	}
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	itx.Status = types.IntakeTxStatusSettling
+	itx.SettlingAt = &now
+	if err = p.db.UpdateIntakeTx(ctx, itx, dbTx); err != nil {
+		if errRollback := dbTx.Rollback(ctx); errRollback != nil {
+			p.logger.Errorf("rollback err: %s", errRollback)
+		}
+		p.executor.ReleaseSettlement(itx.SignedTx)
+		return fmt.Errorf("failed to update intake tx, error: %w", err)
+	}
+
+	if err = dbTx.Commit(ctx); err != nil {
+		p.executor.ReleaseSettlement(itx.SignedTx)
+		return fmt.Errorf("failed to commit dbTx, error: %w", err)
+	}
+	p.count(ctx, itx)
+
+	p.logger.Debugf("intake tx %s handed over to ethTxMan", itx.Hash.Hex())
+
+	return nil
+}
+
+// verify runs the checks that used to be performed synchronously by interop_sendTx, it returns
+// the authorized signer of the tx and its signature scheme
+func (p *Pipeline) verify(ctx context.Context, itx types.IntakeTx) (common.Address, string, error) {
+	if err := p.executor.CheckTx(itx.SignedTx); err != nil {
+		return common.Address{}, "", err
+	}
+
+	signer, scheme, err := p.executor.Verify(ctx, itx.SignedTx)
+	if err != nil {
+		return common.Address{}, "", fmt.Errorf("failed to verify tx: %w", err)
+	}
+
+	if err := p.executor.Execute(ctx, itx.SignedTx); err != nil {
+		return common.Address{}, "", fmt.Errorf("failed to execute tx: %w", err)
+	}
+
+	return signer, scheme, nil
+}
+
+// postpone records a failure of the given stage unrelated to the tx and schedules its next attempt,
+// backing off exponentially. The tx is rejected with the error once it used up its attempts
+func (p *Pipeline) postpone(ctx context.Context, itx types.IntakeTx, stage string, reason error) error {
+	// a failure caused by stopping the pipeline says nothing about the tx
+	if p.ctx.Err() != nil {
+		return fmt.Errorf("pipeline stopped, error: %w", reason)
+	}
+
+	itx.Attempts++
+	if p.cfg.MaxAttempts > 0 && itx.Attempts >= p.cfg.MaxAttempts {
+		return p.reject(ctx, itx, fmt.Errorf("%s failed %d times, last error: %w", stage, itx.Attempts, reason))
+	}
+
+	ctx, cancel := p.updateCtx(ctx)
+	defer cancel()
+
+	shift := itx.Attempts - 1
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+	next := time.Now().UTC().Round(time.Microsecond).Add(p.cfg.FrequencyToProcessTxs.Duration << shift)
+	itx.NextAttemptAt = &next
+	if err := p.db.UpdateIntakeTx(ctx, itx, nil); err != nil {
+		return fmt.Errorf("%s postponed, failed to record attempt %d: %s, error: %w", stage, itx.Attempts, err, reason)
+	}
+
+	return fmt.Errorf("%s postponed until %s, attempt %d, error: %w", stage, next.Format(time.RFC3339), itx.Attempts, reason)
+}
+
+// reject records the reason why the tx won't be settled
+func (p *Pipeline) reject(ctx context.Context, itx types.IntakeTx, reason error) error {
+	// a failure caused by stopping the pipeline says nothing about the tx
+	if p.ctx.Err() != nil {
+		return fmt.Errorf("pipeline stopped, error: %w", reason)
+	}
+
+	ctx, cancel := p.updateCtx(ctx)
+	defer cancel()
+
+	p.logger.Debugf("intake tx %s rejected: %s", itx.Hash.Hex(), reason)
+
+	now := time.Now().UTC().Round(time.Microsecond)
+	itx.Status = types.IntakeTxStatusRejected
+	itx.Error = reason.Error()
+	itx.ErrorCode = ErrorCode(reason)
+	itx.RejectedAt = &now
+	if err := p.db.UpdateIntakeTx(ctx, itx, nil); err != nil {
+		return fmt.Errorf("failed to update intake tx, error: %w", err)
+	}
+	p.count(ctx, itx)
+	p.notifyStatusChange(itx)
+
+	return nil
+}
+
+// updateCtx returns the context recording the outcome of a tx. The processing deadline
+// may be the reason of the outcome, the update must still go through
+func (p *Pipeline) updateCtx(ctx context.Context) (context.Context, context.CancelFunc) {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return context.WithTimeout(p.ctx, p.cfg.ProcessTimeout.Duration)
+	}
+
+	return ctx, func() {}
+}
+
+func (p *Pipeline) reserve(hash common.Hash) bool {
+	p.inFlightMu.Lock()
+	defer p.inFlightMu.Unlock()
+
+	if _, ok := p.inFlight[hash]; ok {
+		return false
+	}
+	p.inFlight[hash] = struct{}{}
+
+	return true
+}
+
+func (p *Pipeline) release(hash common.Hash) {
+	p.inFlightMu.Lock()
+	defer p.inFlightMu.Unlock()
+
+	delete(p.inFlight, hash)
+}
+
+func (p *Pipeline) notifyStatusChange(itx types.IntakeTx) {
+	if p.StatusListener != nil {
+		p.StatusListener.OnIntakeStatusChange(itx)
+	}
+}
+
+func (p *Pipeline) count(ctx context.Context, itx types.IntakeTx) {
+	opts := metric.WithAttributes(attribute.Key("rollup_id").Int(int(itx.SignedTx.Tx.RollupID)))
+	c, err := p.meter.Int64Counter("intake_" + itx.Status.String())
+	if err != nil {
+		p.logger.Warnf("failed to create intake_%s counter: %s", itx.Status, err)
+	}
+	c.Add(ctx, 1, opts)
+}
@@ -0,0 +1,552 @@
+package interop
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/agglayer/config"
+	ethermanPkg "github.com/0xPolygon/agglayer/etherman"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	agglayerRpcTypes "github.com/0xPolygon/agglayer/rpc/types"
+	"github.com/0xPolygon/agglayer/tx"
+	"github.com/0xPolygon/agglayer/types"
+	configTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	rpctypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// intakeStatusRecorder records the statuses the pipeline notifies
+type intakeStatusRecorder struct {
+	mu       sync.Mutex
+	statuses []types.IntakeTxStatus
+}
+
+func (r *intakeStatusRecorder) OnIntakeStatusChange(itx types.IntakeTx) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statuses = append(r.statuses, itx.Status)
+}
+
+func (r *intakeStatusRecorder) Statuses() []types.IntakeTxStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.statuses
+}
+
+func TestPipeline_Process(t *testing.T) {
+	t.Parallel()
+
+	newSignedTx := func(t *testing.T) (*tx.SignedTx, common.Address) {
+		t.Helper()
+
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+
+		tnx := tx.Tx{
+			LastVerifiedBatch: 1,
+			NewVerifiedBatch:  2,
+			ZKP: tx.ZKP{
+				NewStateRoot:     common.BigToHash(big.NewInt(11)),
+				NewLocalExitRoot: common.BigToHash(big.NewInt(11)),
+				Proof:            []byte("sampleProof"),
+			},
+			RollupID: 1,
+		}
+		signedTx, err := tnx.Sign(privateKey)
+		require.NoError(t, err)
+
+		return signedTx, crypto.PubkeyToAddress(privateKey.PublicKey)
+	}
+
+	newPipeline := func(
+		t *testing.T,
+		etherman *mocks.EthermanMock,
+		ethTxManager *mocks.EthTxManagerMock,
+		db *mocks.DBMock,
+		zkEVMClient *mocks.ZkEVMClientMock,
+	) *Pipeline {
+		t.Helper()
+
+		cfg := &config.Config{
+			FullNodeRPCs: config.FullNodeRPCs{1: {"someRPC"}},
+			Intake: config.IntakeConfig{
+				Workers:               1,
+				FrequencyToProcessTxs: configTypes.NewDuration(time.Second),
+				ProcessTimeout:        configTypes.NewDuration(time.Minute),
+				MaxAttempts:           3,
+			},
+		}
+		executor := New(log.WithFields("test", "test"), cfg, common.HexToAddress("0xadmin"), etherman, ethTxManager)
+
+		zkEVMClientCreator := mocks.NewZkEVMClientClientCreatorMock(t)
+		zkEVMClientCreator.On("NewClient", mock.Anything).Return(zkEVMClient).Maybe()
+		executor.ZkEVMClientCreator = zkEVMClientCreator
+
+		return NewPipeline(log.WithFields("test", "test"), cfg, executor, db)
+	}
+
+	withStatus := func(status types.IntakeTxStatus) interface{} {
+		return mock.MatchedBy(func(itx types.IntakeTx) bool { return itx.Status == status })
+	}
+
+	// postponed matches a tx whose next attempt is backed off by the given delay
+	postponed := func(status types.IntakeTxStatus, attempts int, delay time.Duration) interface{} {
+		return mock.MatchedBy(func(itx types.IntakeTx) bool {
+			return itx.Status == status && itx.Attempts == attempts && itx.NextAttemptAt != nil &&
+				time.Until(*itx.NextAttemptAt) > delay-time.Second && time.Until(*itx.NextAttemptAt) <= delay
+		})
+	}
+
+	t.Run("rejected when the signer isn't the sequencer, before the ZKP is verified", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, _ := newSignedTx(t)
+		etherman := mocks.NewEthermanMock(t)
+		db := mocks.NewDBMock(t)
+
+		etherman.On("GetSequencerAddr", uint32(1)).Return(common.HexToAddress("0x1"), nil).Once()
+		db.On("UpdateIntakeTx", mock.Anything, withStatus(types.IntakeTxStatusRejected), nil).Return(nil).Once()
+
+		p := newPipeline(t, etherman, mocks.NewEthTxManagerMock(t), db, mocks.NewZkEVMClientMock(t))
+
+		err := p.process(p.ctx, types.NewIntakeTx(*signedTx))
+		require.NoError(t, err)
+	})
+
+	t.Run("rejected when the ZKP is rejected by L1", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, signer := newSignedTx(t)
+		etherman := mocks.NewEthermanMock(t)
+		db := mocks.NewDBMock(t)
+
+		etherman.On("GetSequencerAddr", uint32(1)).Return(signer, nil).Once()
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(2), mock.Anything, uint32(1), uint64(0)).
+			Return([]byte{1, 2}, nil).Once()
+		etherman.On("CallContract", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, revertError{}).Once()
+		db.On("UpdateIntakeTx", mock.Anything, mock.MatchedBy(func(itx types.IntakeTx) bool {
+			return itx.Status == types.IntakeTxStatusRejected &&
+				itx.RejectedAt != nil &&
+				itx.ErrorCode == agglayerRpcTypes.ErrorCodeProofRejected
+		}), nil).Return(nil).Once()
+
+		p := newPipeline(t, etherman, mocks.NewEthTxManagerMock(t), db, mocks.NewZkEVMClientMock(t))
+		statuses := &intakeStatusRecorder{}
+		p.StatusListener = statuses
+
+		err := p.process(p.ctx, types.NewIntakeTx(*signedTx))
+		require.NoError(t, err)
+		require.Equal(t, []types.IntakeTxStatus{types.IntakeTxStatusRejected}, statuses.Statuses())
+	})
+
+	t.Run("rejected when the pending state doesn't exist on L1", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, signer := newSignedTx(t)
+		etherman := mocks.NewEthermanMock(t)
+		db := mocks.NewDBMock(t)
+
+		etherman.On("GetSequencerAddr", uint32(1)).Return(signer, nil).Once()
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(2), mock.Anything, uint32(1), uint64(0)).
+			Return(nil, fmt.Errorf("%w: pending state 4, last pending state 3", ethermanPkg.ErrPendingStateNotFound)).Once()
+		db.On("UpdateIntakeTx", mock.Anything, mock.MatchedBy(func(itx types.IntakeTx) bool {
+			return itx.Status == types.IntakeTxStatusRejected &&
+				itx.ErrorCode == agglayerRpcTypes.ErrorCodeInvalidPendingState &&
+				strings.Contains(itx.Error, "pending state 4")
+		}), nil).Return(nil).Once()
+
+		p := newPipeline(t, etherman, mocks.NewEthTxManagerMock(t), db, mocks.NewZkEVMClientMock(t))
+
+		err := p.process(p.ctx, types.NewIntakeTx(*signedTx))
+		require.NoError(t, err)
+	})
+
+	t.Run("not rejected when L1 can't verify the ZKP", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, signer := newSignedTx(t)
+		etherman := mocks.NewEthermanMock(t)
+
+		etherman.On("GetSequencerAddr", uint32(1)).Return(signer, nil).Once()
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(2), mock.Anything, uint32(1), uint64(0)).
+			Return([]byte{1, 2}, nil).Once()
+		etherman.On("CallContract", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("connection refused")).Once()
+		db := mocks.NewDBMock(t)
+		db.On("UpdateIntakeTx", mock.Anything, postponed(types.IntakeTxStatusReceived, 1, time.Second), nil).
+			Return(nil).Once()
+
+		p := newPipeline(t, etherman, mocks.NewEthTxManagerMock(t), db, mocks.NewZkEVMClientMock(t))
+
+		err := p.process(p.ctx, types.NewIntakeTx(*signedTx))
+		require.ErrorContains(t, err, "verification postponed")
+	})
+
+	t.Run("backed off exponentially", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, signer := newSignedTx(t)
+		etherman := mocks.NewEthermanMock(t)
+		db := mocks.NewDBMock(t)
+
+		etherman.On("GetSequencerAddr", uint32(1)).Return(signer, nil).Once()
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(2), mock.Anything, uint32(1), uint64(0)).
+			Return([]byte{1, 2}, nil).Once()
+		etherman.On("CallContract", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("connection refused")).Once()
+		db.On("UpdateIntakeTx", mock.Anything, postponed(types.IntakeTxStatusReceived, 2, 2*time.Second), nil).
+			Return(nil).Once()
+
+		p := newPipeline(t, etherman, mocks.NewEthTxManagerMock(t), db, mocks.NewZkEVMClientMock(t))
+
+		itx := types.NewIntakeTx(*signedTx)
+		itx.Attempts = 1
+
+		err := p.process(p.ctx, itx)
+		require.ErrorContains(t, err, "verification postponed")
+		require.ErrorContains(t, err, "attempt 2")
+	})
+
+	t.Run("rejected with the last error once the attempts are used up", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, signer := newSignedTx(t)
+		etherman := mocks.NewEthermanMock(t)
+		db := mocks.NewDBMock(t)
+
+		etherman.On("GetSequencerAddr", uint32(1)).Return(signer, nil).Once()
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(2), mock.Anything, uint32(1), uint64(0)).
+			Return([]byte{1, 2}, nil).Once()
+		etherman.On("CallContract", mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, errors.New("connection refused")).Once()
+		db.On("UpdateIntakeTx", mock.Anything, mock.MatchedBy(func(itx types.IntakeTx) bool {
+			return itx.Status == types.IntakeTxStatusRejected && itx.Attempts == 3 &&
+				strings.Contains(itx.Error, "verification failed 3 times") &&
+				strings.Contains(itx.Error, "connection refused")
+		}), nil).Return(nil).Once()
+
+		p := newPipeline(t, etherman, mocks.NewEthTxManagerMock(t), db, mocks.NewZkEVMClientMock(t))
+
+		itx := types.NewIntakeTx(*signedTx)
+		itx.Attempts = 2
+
+		err := p.process(p.ctx, itx)
+		require.NoError(t, err)
+	})
+
+	t.Run("not rejected when the full nodes are unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, signer := newSignedTx(t)
+		etherman := mocks.NewEthermanMock(t)
+		zkEVMClient := mocks.NewZkEVMClientMock(t)
+
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(2), mock.Anything, uint32(1), uint64(0)).
+			Return([]byte{1, 2}, nil).Once()
+		etherman.On("CallContract", mock.Anything, mock.Anything, mock.Anything).
+			Return([]byte{1, 2}, nil).Once()
+		etherman.On("GetSequencerAddr", uint32(1)).Return(signer, nil).Once()
+		zkEVMClient.On("BatchByNumber", mock.Anything, big.NewInt(2)).
+			Return(nil, errors.New("timeout")).Maybe()
+		db := mocks.NewDBMock(t)
+		db.On("UpdateIntakeTx", mock.Anything, postponed(types.IntakeTxStatusReceived, 1, time.Second), nil).
+			Return(nil).Once()
+
+		p := newPipeline(t, etherman, mocks.NewEthTxManagerMock(t), db, zkEVMClient)
+
+		err := p.process(p.ctx, types.NewIntakeTx(*signedTx))
+		require.ErrorIs(t, err, ErrFullNodeUnavailable)
+	})
+
+	t.Run("rejected when the batch doesn't match", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, signer := newSignedTx(t)
+		etherman := mocks.NewEthermanMock(t)
+		db := mocks.NewDBMock(t)
+		zkEVMClient := mocks.NewZkEVMClientMock(t)
+
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(2), mock.Anything, uint32(1), uint64(0)).
+			Return([]byte{1, 2}, nil).Once()
+		etherman.On("CallContract", mock.Anything, mock.Anything, mock.Anything).
+			Return([]byte{1, 2}, nil).Once()
+		etherman.On("GetSequencerAddr", uint32(1)).Return(signer, nil).Once()
+		zkEVMClient.On("BatchByNumber", mock.Anything, big.NewInt(2)).
+			Return(&rpctypes.Batch{StateRoot: common.BigToHash(big.NewInt(12))}, nil).Once()
+		db.On("UpdateIntakeTx", mock.Anything, mock.MatchedBy(func(itx types.IntakeTx) bool {
+			return itx.Status == types.IntakeTxStatusRejected
+		}), nil).Return(nil).Once()
+
+		p := newPipeline(t, etherman, mocks.NewEthTxManagerMock(t), db, zkEVMClient)
+
+		err := p.process(p.ctx, types.NewIntakeTx(*signedTx))
+		require.NoError(t, err)
+	})
+
+	t.Run("verified and settled", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, signer := newSignedTx(t)
+		etherman := mocks.NewEthermanMock(t)
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+		db := mocks.NewDBMock(t)
+		dbTx := new(mocks.TxMock)
+		zkEVMClient := mocks.NewZkEVMClientMock(t)
+
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(2), mock.Anything, uint32(1), uint64(0)).
+			Return([]byte{1, 2}, nil).Twice()
+		etherman.On("CallContract", mock.Anything, mock.Anything, mock.Anything).
+			Return([]byte{1, 2}, nil).Once()
+		etherman.On("GetSequencerAddr", uint32(1)).Return(signer, nil).Once()
+		zkEVMClient.On("BatchByNumber", mock.Anything, big.NewInt(2)).
+			Return(&rpctypes.Batch{
+				StateRoot:     common.BigToHash(big.NewInt(11)),
+				LocalExitRoot: common.BigToHash(big.NewInt(11)),
+			}, nil).Once()
+		// the signer is recorded once verified, so reading the tx doesn't check it against L1 again
+		db.On("UpdateIntakeTx", mock.Anything, mock.MatchedBy(func(itx types.IntakeTx) bool {
+			return itx.Status == types.IntakeTxStatusVerified && itx.Signer == signer && itx.SignatureScheme == signatureSchemeLegacy
+		}), nil).Return(nil).Once()
+		db.On("BeginStateTransaction", mock.Anything).Return(dbTx, nil).Once()
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(1), nil).Once()
+		etherman.On("GetFreshSequencerAddr", uint32(1)).Return(signer, nil).Once()
+		ethTxManager.On("Add", mock.Anything, ethTxManOwner, signedTx.Tx.Hash().Hex(),
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, dbTx).
+			Return(nil).Once()
+		db.On("UpdateIntakeTx", mock.Anything, withStatus(types.IntakeTxStatusSettling), dbTx).
+			Return(nil).Once()
+		dbTx.On("Commit", mock.Anything).Return(nil).Once()
+
+		p := newPipeline(t, etherman, ethTxManager, db, zkEVMClient)
+		statuses := &intakeStatusRecorder{}
+		p.StatusListener = statuses
+
+		err := p.process(p.ctx, types.NewIntakeTx(*signedTx))
+		require.NoError(t, err)
+
+		dbTx.AssertExpectations(t)
+
+		// once settling, the status is the one of the monitored tx
+		require.Equal(t, []types.IntakeTxStatus{types.IntakeTxStatusVerified}, statuses.Statuses())
+	})
+
+	t.Run("verified tx is only settled, and not rejected when the settlement can't be queued", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, signer := newSignedTx(t)
+		etherman := mocks.NewEthermanMock(t)
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+		db := mocks.NewDBMock(t)
+		dbTx := new(mocks.TxMock)
+
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(2), mock.Anything, uint32(1), uint64(0)).
+			Return([]byte{1, 2}, nil).Once()
+		db.On("BeginStateTransaction", mock.Anything).Return(dbTx, nil).Once()
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(1), nil).Once()
+		etherman.On("GetFreshSequencerAddr", uint32(1)).Return(signer, nil).Once()
+		ethTxManager.On("Add", mock.Anything, ethTxManOwner, signedTx.Tx.Hash().Hex(),
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, dbTx).
+			Return(errors.New("error")).Once()
+		dbTx.On("Rollback", mock.Anything).Return(nil).Once()
+		db.On("UpdateIntakeTx", mock.Anything, postponed(types.IntakeTxStatusVerified, 1, time.Second), nil).
+			Return(nil).Once()
+
+		p := newPipeline(t, etherman, ethTxManager, db, mocks.NewZkEVMClientMock(t))
+
+		itx := types.NewIntakeTx(*signedTx)
+		itx.Status = types.IntakeTxStatusVerified
+
+		err := p.process(p.ctx, itx)
+		require.ErrorIs(t, err, ErrSettlementQueue)
+		require.ErrorContains(t, err, "settlement postponed")
+
+		dbTx.AssertExpectations(t)
+	})
+
+	t.Run("not rejected when the db is unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, _ := newSignedTx(t)
+		db := mocks.NewDBMock(t)
+
+		db.On("BeginStateTransaction", mock.Anything).Return(nil, errors.New("error")).Once()
+
+		p := newPipeline(t, mocks.NewEthermanMock(t), mocks.NewEthTxManagerMock(t), db, mocks.NewZkEVMClientMock(t))
+
+		itx := types.NewIntakeTx(*signedTx)
+		itx.Status = types.IntakeTxStatusVerified
+
+		err := p.process(p.ctx, itx)
+		require.ErrorContains(t, err, "failed to begin dbTx")
+	})
+
+	t.Run("settlement postponed on a gap", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, _ := newSignedTx(t)
+		etherman := mocks.NewEthermanMock(t)
+		db := mocks.NewDBMock(t)
+		dbTx := new(mocks.TxMock)
+
+		db.On("BeginStateTransaction", mock.Anything).Return(dbTx, nil).Once()
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(0), nil).Once()
+		dbTx.On("Rollback", mock.Anything).Return(nil).Once()
+
+		p := newPipeline(t, etherman, mocks.NewEthTxManagerMock(t), db, mocks.NewZkEVMClientMock(t))
+
+		itx := types.NewIntakeTx(*signedTx)
+		itx.Status = types.IntakeTxStatusVerified
+
+		err := p.process(p.ctx, itx)
+		require.ErrorIs(t, err, ErrNonContiguousBatchRange)
+
+		dbTx.AssertExpectations(t)
+	})
+
+	t.Run("settled from a pending state before the last settled batch", func(t *testing.T) {
+		t.Parallel()
+
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		signer := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+		tnx := tx.Tx{
+			LastVerifiedBatch: 1,
+			NewVerifiedBatch:  4,
+			PendingStateNum:   agglayerRpcTypes.ArgUint64Ptr(3),
+			RollupID:          1,
+		}
+		signedTx, err := tnx.Sign(privateKey)
+		require.NoError(t, err)
+
+		etherman := mocks.NewEthermanMock(t)
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+		db := mocks.NewDBMock(t)
+		dbTx := new(mocks.TxMock)
+
+		db.On("BeginStateTransaction", mock.Anything).Return(dbTx, nil).Once()
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(2), nil).Once()
+		etherman.On("GetFreshSequencerAddr", uint32(1)).Return(signer, nil).Once()
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(4), mock.Anything, uint32(1), uint64(3)).
+			Return([]byte{1, 2}, nil).Once()
+		ethTxManager.On("Add", mock.Anything, ethTxManOwner, signedTx.Tx.Hash().Hex(),
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, dbTx).
+			Return(nil).Once()
+		db.On("UpdateIntakeTx", mock.Anything, withStatus(types.IntakeTxStatusSettling), dbTx).
+			Return(nil).Once()
+		dbTx.On("Commit", mock.Anything).Return(nil).Once()
+
+		p := newPipeline(t, etherman, ethTxManager, db, mocks.NewZkEVMClientMock(t))
+
+		itx := types.NewIntakeTx(*signedTx)
+		itx.Status = types.IntakeTxStatusVerified
+
+		err = p.process(p.ctx, itx)
+		require.NoError(t, err)
+
+		dbTx.AssertExpectations(t)
+	})
+
+	t.Run("rejected on an overlap", func(t *testing.T) {
+		t.Parallel()
+
+		signedTx, _ := newSignedTx(t)
+		etherman := mocks.NewEthermanMock(t)
+		db := mocks.NewDBMock(t)
+		dbTx := new(mocks.TxMock)
+
+		db.On("BeginStateTransaction", mock.Anything).Return(dbTx, nil).Once()
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(2), nil).Once()
+		dbTx.On("Rollback", mock.Anything).Return(nil).Once()
+		db.On("UpdateIntakeTx", mock.Anything, mock.MatchedBy(func(itx types.IntakeTx) bool {
+			return itx.Status == types.IntakeTxStatusRejected &&
+				strings.Contains(itx.Error, ErrOverlappingBatchRange.Error())
+		}), nil).Return(nil).Once()
+
+		p := newPipeline(t, etherman, mocks.NewEthTxManagerMock(t), db, mocks.NewZkEVMClientMock(t))
+
+		itx := types.NewIntakeTx(*signedTx)
+		itx.Status = types.IntakeTxStatusVerified
+
+		err := p.process(p.ctx, itx)
+		require.NoError(t, err)
+
+		dbTx.AssertExpectations(t)
+	})
+}
+
+func TestPipeline_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	itx := types.IntakeTx{Hash: common.HexToHash("0x01"), Status: types.IntakeTxStatusReceived}
+
+	db := mocks.NewDBMock(t)
+	db.On("GetIntakeTxsByStatus", mock.Anything,
+		[]types.IntakeTxStatus{types.IntakeTxStatusReceived, types.IntakeTxStatusVerified}, nil).
+		Return([]types.IntakeTx{itx}, nil).Twice()
+
+	p := NewPipeline(log.WithFields("test", "test"), &config.Config{}, nil, db)
+
+	done := make(chan error)
+	go func() { done <- p.dispatch() }()
+
+	require.Equal(t, itx, <-p.queue)
+	require.NoError(t, <-done)
+
+	// a tx being processed isn't dispatched twice
+	require.NoError(t, p.dispatch())
+
+	p.release(itx.Hash)
+	require.True(t, p.reserve(itx.Hash))
+}
+
+func TestPipeline_Notify(t *testing.T) {
+	t.Parallel()
+
+	p := NewPipeline(log.WithFields("test", "test"), &config.Config{}, nil, mocks.NewDBMock(t))
+	statuses := &intakeStatusRecorder{}
+	p.StatusListener = statuses
+
+	p.Notify(types.IntakeTx{Hash: common.HexToHash("0x01"), Status: types.IntakeTxStatusReceived})
+	// every received tx is published, the pipeline is only woken up once
+	p.Notify(types.IntakeTx{Hash: common.HexToHash("0x02"), Status: types.IntakeTxStatusReceived})
+
+	require.Equal(t, []types.IntakeTxStatus{types.IntakeTxStatusReceived, types.IntakeTxStatusReceived}, statuses.Statuses())
+	require.Len(t, p.notify, 1)
+}
+
+func TestPipeline_DispatchBackedOff(t *testing.T) {
+	t.Parallel()
+
+	next := time.Now().Add(time.Minute)
+	backedOff := types.IntakeTx{Hash: common.HexToHash("0x01"), Status: types.IntakeTxStatusReceived, Attempts: 1, NextAttemptAt: &next}
+	due := types.IntakeTx{Hash: common.HexToHash("0x02"), Status: types.IntakeTxStatusReceived}
+
+	db := mocks.NewDBMock(t)
+	db.On("GetIntakeTxsByStatus", mock.Anything,
+		[]types.IntakeTxStatus{types.IntakeTxStatusReceived, types.IntakeTxStatusVerified}, nil).
+		Return([]types.IntakeTx{backedOff, due}, nil).Once()
+
+	p := NewPipeline(log.WithFields("test", "test"), &config.Config{}, nil, db)
+
+	done := make(chan error)
+	go func() { done <- p.dispatch() }()
+
+	require.Equal(t, due, <-p.queue)
+	require.NoError(t, <-done)
+
+	// the backed off tx isn't dispatched until its next attempt
+	require.True(t, p.reserve(backedOff.Hash))
+}
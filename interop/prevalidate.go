@@ -0,0 +1,121 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/0xPolygon/agglayer/etherman"
+	"github.com/0xPolygon/agglayer/tx"
+	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	prevalidateOK                 = "ok"
+	prevalidateInvalidProof       = "invalid_proof"
+	prevalidateInvalidBatchRange  = "invalid_batch_range"
+	prevalidateInvalidSignature   = "invalid_signature"
+	prevalidateUnauthorizedSigner = "unauthorized_signer"
+)
+
+// proofFieldModulus is the modulus of the BN254 base field, every element of a proof is lower than it
+var proofFieldModulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583", 10)
+
+// Prevalidate runs the checks of the tx that don't need any network I/O, so the txs that would
+// obviously fail are rejected before the ZKP is verified on L1 or the full nodes are queried.
+// The signer is only checked against the proof signer of the rollup or its cached sequencer
+func (e *Executor) Prevalidate(stx tx.SignedTx) error {
+	err := e.prevalidate(stx)
+
+	outcome := prevalidateOK
+	switch {
+	case errors.Is(err, ErrInvalidProof):
+		outcome = prevalidateInvalidProof
+	case errors.Is(err, ErrInvalidBatchRange):
+		outcome = prevalidateInvalidBatchRange
+	case errors.Is(err, ErrInvalidSignature):
+		outcome = prevalidateInvalidSignature
+	case errors.Is(err, ErrUnauthorizedSigner):
+		outcome = prevalidateUnauthorizedSigner
+	}
+
+	opts := metric.WithAttributes(
+		attribute.Key("rollup_id").Int(int(stx.Tx.RollupID)),
+		attribute.Key("outcome").String(outcome),
+	)
+	c, merr := e.meter.Int64Counter("prevalidate_tx")
+	if merr != nil {
+		e.logger.Warnf("failed to create prevalidate_tx counter: %s", merr)
+	}
+	c.Add(context.Background(), 1, opts)
+
+	return err
+}
+
+func (e *Executor) prevalidate(stx tx.SignedTx) error {
+	if err := checkProof(stx.Tx.ZKP.Proof); err != nil {
+		return err
+	}
+
+	if stx.Tx.NewVerifiedBatch <= stx.Tx.LastVerifiedBatch {
+		return fmt.Errorf("%w: new verified batch %d is not above last verified batch %d",
+			ErrInvalidBatchRange, stx.Tx.NewVerifiedBatch, stx.Tx.LastVerifiedBatch)
+	}
+
+	signer, legacySigner, err := e.recoverSigners(stx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, err)
+	}
+
+	expected, known := e.config.Rollups().ProofSigner(stx.Tx.RollupID)
+	if !known {
+		expected, known = e.etherman.GetCachedSequencerAddr(stx.Tx.RollupID)
+	}
+
+	// an unknown sequencer is read from L1 when the tx is verified
+	if known && signatureScheme(expected, signer, legacySigner) == "" {
+		return fmt.Errorf("%w: expected %s but got %s", ErrUnauthorizedSigner, expected, signer)
+	}
+
+	return nil
+}
+
+// checkProof checks the proof has the length and the format expected by the verifier
+func checkProof(proof []byte) error {
+	const expectedLength = etherman.ProofLength * etherman.HashLength
+
+	if len(proof) != expectedLength {
+		return fmt.Errorf("%w: expected length %d, got %d", ErrInvalidProof, expectedLength, len(proof))
+	}
+
+	for i := 0; i < etherman.ProofLength; i++ {
+		element := new(big.Int).SetBytes(proof[i*etherman.HashLength : (i+1)*etherman.HashLength])
+		if element.Cmp(proofFieldModulus) >= 0 {
+			return fmt.Errorf("%w: element %d is not a field element", ErrInvalidProof, i)
+		}
+	}
+
+	return nil
+}
+
+// recoverSigners returns the typed data signer of the tx and, while legacy signatures
+// are accepted, its legacy signer if it can be recovered
+func (e *Executor) recoverSigners(stx tx.SignedTx) (common.Address, *common.Address, error) {
+	signer, err := stx.TypedDataSigner(e.signingDomain())
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	var legacySigner *common.Address
+	if e.config.Signatures.AcceptsLegacy(time.Now()) {
+		if s, err := stx.Signer(); err == nil {
+			legacySigner = &s
+		}
+	}
+
+	return signer, legacySigner, nil
+}
@@ -0,0 +1,128 @@
+package interop
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	"github.com/0xPolygon/agglayer/tx"
+)
+
+func TestExecutor_Prevalidate(t *testing.T) {
+	t.Parallel()
+
+	signerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(signerKey.PublicKey)
+
+	validTx := func() tx.Tx {
+		return tx.Tx{
+			LastVerifiedBatch: 1,
+			NewVerifiedBatch:  2,
+			ZKP: tx.ZKP{
+				NewStateRoot:     common.BigToHash(big.NewInt(11)),
+				NewLocalExitRoot: common.BigToHash(big.NewInt(11)),
+				Proof:            make([]byte, 24*32),
+			},
+			RollupID: 1,
+		}
+	}
+
+	newExecutor := func(t *testing.T, cfg *config.Config, cachedSequencer *common.Address) *Executor {
+		t.Helper()
+
+		etherman := mocks.NewEthermanMock(t)
+		if cachedSequencer != nil {
+			etherman.On("GetCachedSequencerAddr", uint32(1)).Return(*cachedSequencer, true).Maybe()
+		} else {
+			etherman.On("GetCachedSequencerAddr", uint32(1)).Return(common.Address{}, false).Maybe()
+		}
+
+		return New(log.WithFields("module", "test"), cfg, common.HexToAddress("0xadmin"), etherman, mocks.NewEthTxManagerMock(t))
+	}
+
+	sign := func(t *testing.T, e *Executor, tnx tx.Tx) tx.SignedTx {
+		t.Helper()
+
+		signedTx, err := tnx.SignTypedData(signerKey, e.signingDomain())
+		require.NoError(t, err)
+
+		return *signedTx
+	}
+
+	t.Run("valid tx signed by the proof signer", func(t *testing.T) {
+		t.Parallel()
+
+		e := newExecutor(t, &config.Config{ProofSigners: config.ProofSigners{1: signer}}, nil)
+		require.NoError(t, e.Prevalidate(sign(t, e, validTx())))
+	})
+
+	t.Run("valid tx whose sequencer isn't cached", func(t *testing.T) {
+		t.Parallel()
+
+		e := newExecutor(t, &config.Config{}, nil)
+		require.NoError(t, e.Prevalidate(sign(t, e, validTx())))
+	})
+
+	t.Run("proof of the wrong length", func(t *testing.T) {
+		t.Parallel()
+
+		e := newExecutor(t, &config.Config{}, nil)
+		tnx := validTx()
+		tnx.ZKP.Proof = []byte("sampleProof")
+
+		require.ErrorIs(t, e.Prevalidate(sign(t, e, tnx)), ErrInvalidProof)
+	})
+
+	t.Run("proof element out of the field", func(t *testing.T) {
+		t.Parallel()
+
+		e := newExecutor(t, &config.Config{}, nil)
+		tnx := validTx()
+		tnx.ZKP.Proof = append(bytes.Repeat([]byte{0xff}, 32), make([]byte, 23*32)...)
+
+		require.ErrorIs(t, e.Prevalidate(sign(t, e, tnx)), ErrInvalidProof)
+	})
+
+	t.Run("empty batch range", func(t *testing.T) {
+		t.Parallel()
+
+		e := newExecutor(t, &config.Config{}, nil)
+		tnx := validTx()
+		tnx.NewVerifiedBatch = tnx.LastVerifiedBatch
+
+		require.ErrorIs(t, e.Prevalidate(sign(t, e, tnx)), ErrInvalidBatchRange)
+	})
+
+	t.Run("unrecoverable signature", func(t *testing.T) {
+		t.Parallel()
+
+		e := newExecutor(t, &config.Config{}, nil)
+
+		require.ErrorIs(t, e.Prevalidate(tx.SignedTx{Tx: validTx()}), ErrInvalidSignature)
+	})
+
+	t.Run("not signed by the proof signer", func(t *testing.T) {
+		t.Parallel()
+
+		e := newExecutor(t, &config.Config{ProofSigners: config.ProofSigners{1: common.HexToAddress("0x1")}}, nil)
+
+		require.ErrorIs(t, e.Prevalidate(sign(t, e, validTx())), ErrUnauthorizedSigner)
+	})
+
+	t.Run("not signed by the cached sequencer", func(t *testing.T) {
+		t.Parallel()
+
+		sequencer := common.HexToAddress("0x1")
+		e := newExecutor(t, &config.Config{}, &sequencer)
+
+		require.ErrorIs(t, e.Prevalidate(sign(t, e, validTx())), ErrUnauthorizedSigner)
+	})
+}
@@ -0,0 +1,224 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygon/agglayer/tx"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// batchRange is the range of batches a tx verifies, from its last verified batch to its new verified batch
+type batchRange struct {
+	from uint64
+	to   uint64
+
+	// pendingState is the pending state the range is verified from, 0 meaning the consolidated state
+	pendingState uint64
+}
+
+func newBatchRange(stx tx.SignedTx) batchRange {
+	return batchRange{
+		from:         uint64(stx.Tx.LastVerifiedBatch),
+		to:           uint64(stx.Tx.NewVerifiedBatch),
+		pendingState: stx.Tx.PendingState(),
+	}
+}
+
+func (r batchRange) overlaps(other batchRange) bool {
+	return r.from < other.to && other.from < r.to
+}
+
+func (r batchRange) String() string {
+	return fmt.Sprintf("%d-%d", r.from, r.to)
+}
+
+// rollupSettlements is the settlement state of a single rollup
+type rollupSettlements struct {
+	mu sync.Mutex
+
+	// lastSettled is the last batch verified on L1
+	lastSettled uint64
+
+	// inFlight are the ranges handed over to ethTxMan that aren't final yet
+	inFlight map[common.Hash]batchRange
+}
+
+// tip returns the last batch either verified on L1 or being settled
+func (r *rollupSettlements) tip() uint64 {
+	tip := r.lastSettled
+	for _, rng := range r.inFlight {
+		if rng.to > tip {
+			tip = rng.to
+		}
+	}
+
+	return tip
+}
+
+// validate checks that the range can be settled on top of the current state
+func (r *rollupSettlements) validate(rng batchRange) error {
+	for hash, inFlight := range r.inFlight {
+		if rng.overlaps(inFlight) {
+			return fmt.Errorf("%w: range %s overlaps with range %s of tx %s", ErrOverlappingBatchRange, rng, inFlight, hash.Hex())
+		}
+	}
+
+	// a range verified from a pending state starts at the batch of that pending state, which L1
+	// already checked when building the tx, and only has to go past the batches settled meanwhile
+	if rng.pendingState != 0 {
+		if tip := r.tip(); rng.to <= tip {
+			return fmt.Errorf("%w: range %s from pending state %d doesn't go past batch %d", ErrOverlappingBatchRange, rng, rng.pendingState, tip)
+		}
+
+		return nil
+	}
+
+	if rng.from < r.lastSettled {
+		return fmt.Errorf("%w: range %s starts before the last settled batch %d", ErrOverlappingBatchRange, rng, r.lastSettled)
+	}
+
+	if tip := r.tip(); rng.from != tip {
+		return fmt.Errorf("%w: range %s doesn't start at batch %d", ErrNonContiguousBatchRange, rng, tip)
+	}
+
+	return nil
+}
+
+// settlementTracker keeps per rollup the last batch verified on L1 and the ranges
+// handed over to ethTxMan, so overlapping or non-contiguous txs never reach L1
+type settlementTracker struct {
+	mu      sync.Mutex
+	rollups map[uint32]*rollupSettlements
+}
+
+func newSettlementTracker() *settlementTracker {
+	return &settlementTracker{
+		rollups: make(map[uint32]*rollupSettlements),
+	}
+}
+
+// rollup returns the settlement state of the given rollup, creating it if needed
+func (t *settlementTracker) rollup(rollupID uint32) *rollupSettlements {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	r, ok := t.rollups[rollupID]
+	if !ok {
+		r = &rollupSettlements{inFlight: make(map[common.Hash]batchRange)}
+		t.rollups[rollupID] = r
+	}
+
+	return r
+}
+
+// RestoreSettlements tracks again the txs that were handed over to ethTxMan
+// before a restart, txs that are already final are dropped on the next settlement
+func (e *Executor) RestoreSettlements(stxs []tx.SignedTx) {
+	for _, stx := range stxs {
+		r := e.settlements.rollup(stx.Tx.RollupID)
+
+		r.mu.Lock()
+		r.inFlight[stx.Tx.Hash()] = newBatchRange(stx)
+		r.mu.Unlock()
+	}
+}
+
+// ReleaseSettlement stops tracking a tx whose hand over to ethTxMan wasn't committed
+func (e *Executor) ReleaseSettlement(stx tx.SignedTx) {
+	r := e.settlements.rollup(stx.Tx.RollupID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.inFlight, stx.Tx.Hash())
+}
+
+// reserveSettlement checks the range of the tx against the settlement state of its rollup
+// and tracks it as in-flight. It returns true if the tx is already being settled
+func (e *Executor) reserveSettlement(ctx context.Context, stx tx.SignedTx) (bool, error) {
+	r := e.settlements.rollup(stx.Tx.RollupID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hash := stx.Tx.Hash()
+	if _, ok := r.inFlight[hash]; ok {
+		return true, nil
+	}
+
+	if err := e.refreshSettlements(ctx, stx.Tx.RollupID, r); err != nil {
+		return false, err
+	}
+
+	rng := newBatchRange(stx)
+	if err := r.validate(rng); err != nil {
+		return false, err
+	}
+	r.inFlight[hash] = rng
+
+	return false, nil
+}
+
+// ReserveSettlement tracks again the range of a failed tx an operator retries. The retry is
+// refused if the range was settled or is being settled by a newer tx meanwhile
+func (e *Executor) ReserveSettlement(ctx context.Context, mTx txmTypes.MonitoredTx) error {
+	if mTx.Owner != ethTxManOwner {
+		return nil
+	}
+	if mTx.Batches == nil {
+		return fmt.Errorf("the batch range of tx %s isn't recorded, it can't be checked against the newer settlements", mTx.ID)
+	}
+
+	r := e.settlements.rollup(mTx.Batches.RollupID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the failed tx may still be tracked until the next refresh
+	hash := common.HexToHash(mTx.ID)
+	delete(r.inFlight, hash)
+
+	if err := e.refreshSettlements(ctx, mTx.Batches.RollupID, r); err != nil {
+		return err
+	}
+
+	rng := batchRange{from: mTx.Batches.LastVerifiedBatch, to: mTx.Batches.NewVerifiedBatch}
+	if err := r.validate(rng); err != nil {
+		return err
+	}
+	r.inFlight[hash] = rng
+
+	return nil
+}
+
+// refreshSettlements drops the in-flight txs that are final and reads the last verified batch from L1.
+// Txs not found in ethTxMan are kept, their hand over may not be committed yet
+func (e *Executor) refreshSettlements(ctx context.Context, rollupID uint32, r *rollupSettlements) error {
+	for hash := range r.inFlight {
+		res, err := e.ethTxMan.Result(ctx, ethTxManOwner, hash.Hex(), nil)
+		if errors.Is(err, txmTypes.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to get in-flight tx %s, error: %w", hash.Hex(), err)
+		}
+
+		switch res.Status {
+		case txmTypes.MonitoredTxStatusConfirmed,
+			txmTypes.MonitoredTxStatusDone,
+			txmTypes.MonitoredTxStatusFailed:
+			delete(r.inFlight, hash)
+		}
+	}
+
+	lastSettled, err := e.etherman.GetLastVerifiedBatch(rollupID)
+	if err != nil {
+		return fmt.Errorf("failed to get last verified batch from L1, error: %w", err)
+	}
+	r.lastSettled = lastSettled
+
+	return nil
+}
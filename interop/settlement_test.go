@@ -0,0 +1,273 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	"github.com/0xPolygon/agglayer/rpc/types"
+	"github.com/0xPolygon/agglayer/tx"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func settlementTx(rollupID uint32, lastVerifiedBatch, newVerifiedBatch uint64) tx.SignedTx {
+	return tx.SignedTx{
+		Tx: tx.Tx{
+			RollupID:          rollupID,
+			LastVerifiedBatch: types.ArgUint64(lastVerifiedBatch),
+			NewVerifiedBatch:  types.ArgUint64(newVerifiedBatch),
+		},
+	}
+}
+
+func TestExecutor_ReserveSettlement(t *testing.T) {
+	t.Parallel()
+
+	newExecutor := func(t *testing.T) (*Executor, *mocks.EthermanMock, *mocks.EthTxManagerMock) {
+		t.Helper()
+
+		etherman := mocks.NewEthermanMock(t)
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+
+		return New(log.WithFields("test", "test"), &config.Config{}, common.HexToAddress("0xadmin"), etherman, ethTxManager),
+			etherman, ethTxManager
+	}
+
+	t.Run("contiguous ranges are reserved", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, ethTxManager := newExecutor(t)
+		first, second := settlementTx(1, 10, 12), settlementTx(1, 12, 15)
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(10), nil).Twice()
+		ethTxManager.On("Result", mock.Anything, ethTxManOwner, first.Tx.Hash().Hex(), nil).
+			Return(txmTypes.MonitoredTxResult{Status: txmTypes.MonitoredTxStatusSent}, nil).Once()
+
+		inFlight, err := e.reserveSettlement(context.Background(), first)
+		require.NoError(t, err)
+		require.False(t, inFlight)
+
+		inFlight, err = e.reserveSettlement(context.Background(), second)
+		require.NoError(t, err)
+		require.False(t, inFlight)
+	})
+
+	t.Run("identical resubmission is idempotent", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, _ := newExecutor(t)
+		stx := settlementTx(1, 10, 12)
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(10), nil).Once()
+
+		inFlight, err := e.reserveSettlement(context.Background(), stx)
+		require.NoError(t, err)
+		require.False(t, inFlight)
+
+		inFlight, err = e.reserveSettlement(context.Background(), stx)
+		require.NoError(t, err)
+		require.True(t, inFlight)
+	})
+
+	t.Run("overlapping in-flight range", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, ethTxManager := newExecutor(t)
+		first, second := settlementTx(1, 10, 12), settlementTx(1, 10, 13)
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(10), nil).Twice()
+		ethTxManager.On("Result", mock.Anything, ethTxManOwner, first.Tx.Hash().Hex(), nil).
+			Return(txmTypes.MonitoredTxResult{}, txmTypes.ErrNotFound).Once()
+
+		_, err := e.reserveSettlement(context.Background(), first)
+		require.NoError(t, err)
+
+		_, err = e.reserveSettlement(context.Background(), second)
+		require.ErrorIs(t, err, ErrOverlappingBatchRange)
+	})
+
+	t.Run("range already verified on L1", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, _ := newExecutor(t)
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(12), nil).Once()
+
+		_, err := e.reserveSettlement(context.Background(), settlementTx(1, 10, 12))
+		require.ErrorIs(t, err, ErrOverlappingBatchRange)
+	})
+
+	t.Run("gap after the last settled batch", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, _ := newExecutor(t)
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(8), nil).Once()
+
+		_, err := e.reserveSettlement(context.Background(), settlementTx(1, 10, 12))
+		require.ErrorIs(t, err, ErrNonContiguousBatchRange)
+	})
+
+	t.Run("range from a pending state before the last settled batch", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, _ := newExecutor(t)
+		stx := settlementTx(1, 10, 14)
+		stx.Tx.PendingStateNum = types.ArgUint64Ptr(2)
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(12), nil).Once()
+
+		inFlight, err := e.reserveSettlement(context.Background(), stx)
+		require.NoError(t, err)
+		require.False(t, inFlight)
+	})
+
+	t.Run("range from a pending state not going past the last settled batch", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, _ := newExecutor(t)
+		stx := settlementTx(1, 10, 12)
+		stx.Tx.PendingStateNum = types.ArgUint64Ptr(2)
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(12), nil).Once()
+
+		_, err := e.reserveSettlement(context.Background(), stx)
+		require.ErrorIs(t, err, ErrOverlappingBatchRange)
+	})
+
+	t.Run("range from a pending state overlapping an in-flight range", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, ethTxManager := newExecutor(t)
+		first, second := settlementTx(1, 12, 14), settlementTx(1, 10, 16)
+		second.Tx.PendingStateNum = types.ArgUint64Ptr(2)
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(12), nil).Twice()
+		ethTxManager.On("Result", mock.Anything, ethTxManOwner, first.Tx.Hash().Hex(), nil).
+			Return(txmTypes.MonitoredTxResult{Status: txmTypes.MonitoredTxStatusSent}, nil).Once()
+
+		_, err := e.reserveSettlement(context.Background(), first)
+		require.NoError(t, err)
+
+		_, err = e.reserveSettlement(context.Background(), second)
+		require.ErrorIs(t, err, ErrOverlappingBatchRange)
+	})
+
+	t.Run("rollups are tracked independently", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, _ := newExecutor(t)
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(10), nil).Once()
+		etherman.On("GetLastVerifiedBatch", uint32(2)).Return(uint64(10), nil).Once()
+
+		_, err := e.reserveSettlement(context.Background(), settlementTx(1, 10, 12))
+		require.NoError(t, err)
+
+		_, err = e.reserveSettlement(context.Background(), settlementTx(2, 10, 12))
+		require.NoError(t, err)
+	})
+
+	t.Run("final txs are dropped", func(t *testing.T) {
+		t.Parallel()
+
+		for _, status := range []txmTypes.MonitoredTxStatus{
+			txmTypes.MonitoredTxStatusConfirmed,
+			txmTypes.MonitoredTxStatusDone,
+			txmTypes.MonitoredTxStatusFailed,
+		} {
+			e, etherman, ethTxManager := newExecutor(t)
+			restored := settlementTx(1, 10, 12)
+			e.RestoreSettlements([]tx.SignedTx{restored})
+
+			ethTxManager.On("Result", mock.Anything, ethTxManOwner, restored.Tx.Hash().Hex(), nil).
+				Return(txmTypes.MonitoredTxResult{Status: status}, nil).Once()
+			etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(10), nil).Once()
+
+			// the range of the restored tx is free again
+			_, err := e.reserveSettlement(context.Background(), settlementTx(1, 10, 11))
+			require.NoError(t, err, status)
+		}
+	})
+
+	t.Run("released range can be reserved again", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, _ := newExecutor(t)
+		first, second := settlementTx(1, 10, 12), settlementTx(1, 10, 11)
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(10), nil).Twice()
+
+		_, err := e.reserveSettlement(context.Background(), first)
+		require.NoError(t, err)
+
+		e.ReleaseSettlement(first)
+
+		_, err = e.reserveSettlement(context.Background(), second)
+		require.NoError(t, err)
+	})
+
+	t.Run("retried tx is reserved again", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, ethTxManager := newExecutor(t)
+		failed := settlementTx(1, 10, 12)
+		e.RestoreSettlements([]tx.SignedTx{failed})
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(10), nil).Twice()
+		ethTxManager.On("Result", mock.Anything, ethTxManOwner, failed.Tx.Hash().Hex(), nil).
+			Return(txmTypes.MonitoredTxResult{Status: txmTypes.MonitoredTxStatusSent}, nil).Once()
+
+		require.NoError(t, e.ReserveSettlement(context.Background(), txmTypes.MonitoredTx{
+			Owner:   ethTxManOwner,
+			ID:      failed.Tx.Hash().Hex(),
+			Batches: &txmTypes.RollupBatches{RollupID: 1, LastVerifiedBatch: 10, NewVerifiedBatch: 12},
+		}))
+
+		// the range of the retried tx is in flight again
+		_, err := e.reserveSettlement(context.Background(), settlementTx(1, 10, 11))
+		require.ErrorIs(t, err, ErrOverlappingBatchRange)
+	})
+
+	t.Run("retry refused after a newer settlement", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, ethTxManager := newExecutor(t)
+		failed, newer := settlementTx(1, 10, 12), settlementTx(1, 10, 11)
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(10), nil).Twice()
+		ethTxManager.On("Result", mock.Anything, ethTxManOwner, newer.Tx.Hash().Hex(), nil).
+			Return(txmTypes.MonitoredTxResult{Status: txmTypes.MonitoredTxStatusSent}, nil).Once()
+
+		_, err := e.reserveSettlement(context.Background(), newer)
+		require.NoError(t, err)
+
+		err = e.ReserveSettlement(context.Background(), txmTypes.MonitoredTx{
+			Owner:   ethTxManOwner,
+			ID:      failed.Tx.Hash().Hex(),
+			Batches: &txmTypes.RollupBatches{RollupID: 1, LastVerifiedBatch: 10, NewVerifiedBatch: 12},
+		})
+		require.ErrorIs(t, err, ErrOverlappingBatchRange)
+
+		// without its range the retry can't be checked
+		err = e.ReserveSettlement(context.Background(), txmTypes.MonitoredTx{Owner: ethTxManOwner, ID: "0x1"})
+		require.ErrorContains(t, err, "isn't recorded")
+	})
+
+	t.Run("L1 unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		e, etherman, _ := newExecutor(t)
+
+		etherman.On("GetLastVerifiedBatch", uint32(1)).Return(uint64(0), errors.New("error")).Once()
+
+		_, err := e.reserveSettlement(context.Background(), settlementTx(1, 10, 12))
+		require.ErrorContains(t, err, "failed to get last verified batch from L1")
+	})
+}
@@ -0,0 +1,99 @@
+package interop
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/agglayer/etherman"
+	"github.com/0xPolygon/agglayer/tx"
+	"github.com/0xPolygon/agglayer/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Simulate runs the checks of the tx and builds the L1 tx that would settle it, without settling it.
+// Nothing is persisted and the eth tx manager isn't involved
+func (e *Executor) Simulate(ctx context.Context, stx tx.SignedTx) types.TxSimulation {
+	sim := types.TxSimulation{
+		Hash: stx.Tx.Hash(),
+		To:   e.config.L1.RollupManagerContract,
+		From: e.interopAdminAddr,
+	}
+
+	e.simulate(ctx, stx, &sim)
+
+	sim.Success = true
+	for _, step := range sim.Steps {
+		sim.Success = sim.Success && step.Passed
+	}
+
+	opts := metric.WithAttributes(
+		attribute.Key("rollup_id").Int(int(stx.Tx.RollupID)),
+		attribute.Key("success").Bool(sim.Success),
+	)
+	c, err := e.meter.Int64Counter("simulate_tx")
+	if err != nil {
+		e.logger.Warnf("failed to create simulate_tx counter: %s", err)
+	}
+	c.Add(context.Background(), 1, opts)
+
+	return sim
+}
+
+func (e *Executor) simulate(ctx context.Context, stx tx.SignedTx, sim *types.TxSimulation) {
+	// Nothing else can be checked for a malformed tx or an unknown rollup
+	if !recordStep(sim, types.SimulationStepPrevalidate, e.Prevalidate(stx)) {
+		return
+	}
+	if !recordStep(sim, types.SimulationStepCheckTx, e.CheckTx(stx)) {
+		return
+	}
+
+	// The ZKP and the soundness are independent, both are reported
+	_, _, err := e.Verify(ctx, stx)
+	recordStep(sim, types.SimulationStepVerify, err)
+	sim.RevertReason = etherman.DecodeRevertReason(err)
+
+	recordStep(sim, types.SimulationStepExecute, e.Execute(ctx, stx))
+
+	l1TxData, err := e.buildVerifyBatchesTxData(stx)
+	if err != nil {
+		recordStep(sim, types.SimulationStepBuildCalldata, fmt.Errorf("failed to build verify ZKP tx: %w", err))
+		return
+	}
+	recordStep(sim, types.SimulationStepBuildCalldata, nil)
+	sim.Calldata = l1TxData
+
+	gas, err := e.etherman.EstimateGas(ctx, sim.From, &sim.To, big.NewInt(0), l1TxData)
+	if err != nil {
+		err = etherman.DecodeRevert(err)
+		if isReverted(err) {
+			err = fmt.Errorf("%w: %w", ErrProofRejected, err)
+		}
+		recordStep(sim, types.SimulationStepEstimateGas, fmt.Errorf("failed to estimate gas: %w", err))
+		if reason := etherman.DecodeRevertReason(err); reason != "" {
+			sim.RevertReason = reason
+		}
+
+		return
+	}
+	recordStep(sim, types.SimulationStepEstimateGas, nil)
+
+	gasEstimate := hexutil.Uint64(gas)
+	sim.GasEstimate = &gasEstimate
+}
+
+// recordStep appends the outcome of the step to the simulation, returning whether it passed
+func recordStep(sim *types.TxSimulation, step types.SimulationStep, err error) bool {
+	result := types.SimulationStepResult{Step: step, Passed: err == nil}
+	if err != nil {
+		result.Error = err.Error()
+		result.ErrorCode = ErrorCode(err)
+	}
+
+	sim.Steps = append(sim.Steps, result)
+
+	return result.Passed
+}
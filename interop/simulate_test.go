@@ -0,0 +1,178 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	agglayerRpcTypes "github.com/0xPolygon/agglayer/rpc/types"
+	"github.com/0xPolygon/agglayer/tx"
+	"github.com/0xPolygon/agglayer/types"
+	rpctypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// revertError is the error of an L1 call reverted with the InvalidProof custom error
+type revertError struct{}
+
+func (revertError) Error() string          { return "execution reverted" }
+func (revertError) ErrorCode() int         { return revertedErrorCode }
+func (revertError) ErrorData() interface{} { return "0x09bde339" }
+
+func TestExecutor_Simulate(t *testing.T) {
+	t.Parallel()
+
+	signerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := crypto.PubkeyToAddress(signerKey.PublicKey)
+
+	rollupManager := common.HexToAddress("0xrollupmanager")
+	admin := common.HexToAddress("0xadmin")
+	stateRoot := common.BigToHash(big.NewInt(11))
+
+	newExecutor := func(t *testing.T, etherman *mocks.EthermanMock, zkEVMClient *mocks.ZkEVMClientMock) (*Executor, tx.SignedTx) {
+		t.Helper()
+
+		cfg := &config.Config{
+			FullNodeRPCs: config.FullNodeRPCs{1: {"someRPC"}},
+			ProofSigners: config.ProofSigners{1: signer},
+			L1:           config.L1Config{RollupManagerContract: rollupManager},
+		}
+		executor := New(log.WithFields("test", "test"), cfg, admin, etherman, mocks.NewEthTxManagerMock(t))
+
+		zkEVMClientCreator := mocks.NewZkEVMClientClientCreatorMock(t)
+		zkEVMClientCreator.On("NewClient", mock.Anything).Return(zkEVMClient).Maybe()
+		executor.ZkEVMClientCreator = zkEVMClientCreator
+
+		tnx := tx.Tx{
+			LastVerifiedBatch: 1,
+			NewVerifiedBatch:  2,
+			ZKP: tx.ZKP{
+				NewStateRoot:     stateRoot,
+				NewLocalExitRoot: stateRoot,
+				Proof:            make([]byte, 24*32),
+			},
+			RollupID: 1,
+		}
+		signedTx, err := tnx.SignTypedData(signerKey, executor.signingDomain())
+		require.NoError(t, err)
+
+		return executor, *signedTx
+	}
+
+	steps := func(sim types.TxSimulation) []types.SimulationStep {
+		result := make([]types.SimulationStep, len(sim.Steps))
+		for i, step := range sim.Steps {
+			result[i] = step.Step
+		}
+
+		return result
+	}
+
+	t.Run("sound tx", func(t *testing.T) {
+		t.Parallel()
+
+		etherman := mocks.NewEthermanMock(t)
+		zkEVMClient := mocks.NewZkEVMClientMock(t)
+
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(2), mock.Anything, uint32(1), uint64(0)).
+			Return([]byte{1, 2}, nil).Twice()
+		etherman.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return([]byte{}, nil).Once()
+		etherman.On("EstimateGas", mock.Anything, admin, &rollupManager, big.NewInt(0), []byte{1, 2}).
+			Return(uint64(300000), nil).Once()
+		zkEVMClient.On("BatchByNumber", mock.Anything, big.NewInt(2)).
+			Return(&rpctypes.Batch{StateRoot: stateRoot, LocalExitRoot: stateRoot}, nil).Once()
+
+		e, signedTx := newExecutor(t, etherman, zkEVMClient)
+		sim := e.Simulate(context.Background(), signedTx)
+
+		require.True(t, sim.Success)
+		require.Equal(t, signedTx.Tx.Hash(), sim.Hash)
+		require.Equal(t, []types.SimulationStep{
+			types.SimulationStepPrevalidate,
+			types.SimulationStepCheckTx,
+			types.SimulationStepVerify,
+			types.SimulationStepExecute,
+			types.SimulationStepBuildCalldata,
+			types.SimulationStepEstimateGas,
+		}, steps(sim))
+		require.Equal(t, hexutil.Bytes{1, 2}, sim.Calldata)
+		require.Equal(t, hexutil.Uint64(300000), *sim.GasEstimate)
+		require.Equal(t, rollupManager, sim.To)
+		require.Equal(t, admin, sim.From)
+		require.Empty(t, sim.RevertReason)
+	})
+
+	t.Run("proof rejected by L1", func(t *testing.T) {
+		t.Parallel()
+
+		etherman := mocks.NewEthermanMock(t)
+		zkEVMClient := mocks.NewZkEVMClientMock(t)
+
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(2), mock.Anything, uint32(1), uint64(0)).
+			Return([]byte{1, 2}, nil).Twice()
+		etherman.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(nil, revertError{}).Once()
+		etherman.On("EstimateGas", mock.Anything, admin, &rollupManager, big.NewInt(0), []byte{1, 2}).
+			Return(uint64(0), revertError{}).Once()
+		zkEVMClient.On("BatchByNumber", mock.Anything, big.NewInt(2)).
+			Return(&rpctypes.Batch{StateRoot: common.HexToHash("0x1"), LocalExitRoot: stateRoot}, nil).Once()
+
+		e, signedTx := newExecutor(t, etherman, zkEVMClient)
+		sim := e.Simulate(context.Background(), signedTx)
+
+		require.False(t, sim.Success)
+		require.Len(t, sim.Steps, 6)
+		require.False(t, sim.Steps[2].Passed)
+		require.Equal(t, agglayerRpcTypes.ErrorCodeProofRejected, sim.Steps[2].ErrorCode)
+		require.Contains(t, sim.Steps[2].Error, "proof rejected by L1: execution reverted: InvalidProof()")
+		require.False(t, sim.Steps[3].Passed)
+		require.Equal(t, agglayerRpcTypes.ErrorCodeStateRootMismatch, sim.Steps[3].ErrorCode)
+		require.True(t, sim.Steps[4].Passed)
+		require.False(t, sim.Steps[5].Passed)
+		require.Equal(t, agglayerRpcTypes.ErrorCodeProofRejected, sim.Steps[5].ErrorCode)
+		require.Equal(t, "InvalidProof()", sim.RevertReason)
+		require.Nil(t, sim.GasEstimate)
+	})
+
+	t.Run("unknown rollup stops the simulation", func(t *testing.T) {
+		t.Parallel()
+
+		e, signedTx := newExecutor(t, mocks.NewEthermanMock(t), mocks.NewZkEVMClientMock(t))
+		e.config.FullNodeRPCs = config.FullNodeRPCs{}
+
+		sim := e.Simulate(context.Background(), signedTx)
+
+		require.False(t, sim.Success)
+		require.Equal(t, []types.SimulationStep{types.SimulationStepPrevalidate, types.SimulationStepCheckTx}, steps(sim))
+		require.Equal(t, agglayerRpcTypes.ErrorCodeUnknownRollup, sim.Steps[1].ErrorCode)
+		require.Empty(t, sim.Calldata)
+	})
+
+	t.Run("calldata can't be built", func(t *testing.T) {
+		t.Parallel()
+
+		etherman := mocks.NewEthermanMock(t)
+		zkEVMClient := mocks.NewZkEVMClientMock(t)
+
+		etherman.On("BuildTrustedVerifyBatchesTxData", uint64(1), uint64(2), mock.Anything, uint32(1), uint64(0)).
+			Return(nil, errors.New("pending state not found")).Twice()
+		zkEVMClient.On("BatchByNumber", mock.Anything, big.NewInt(2)).
+			Return(&rpctypes.Batch{StateRoot: stateRoot, LocalExitRoot: stateRoot}, nil).Once()
+
+		e, signedTx := newExecutor(t, etherman, zkEVMClient)
+		sim := e.Simulate(context.Background(), signedTx)
+
+		require.False(t, sim.Success)
+		require.Len(t, sim.Steps, 5)
+		require.Equal(t, types.SimulationStepBuildCalldata, sim.Steps[4].Step)
+		require.Contains(t, sim.Steps[4].Error, "pending state not found")
+	})
+}
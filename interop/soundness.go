@@ -0,0 +1,129 @@
+package interop
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/tx"
+	"github.com/0xPolygon/agglayer/types"
+)
+
+// SoundnessChecker asserts that the state root and local exit root proven by a tx
+// are the ones of the rollup
+type SoundnessChecker interface {
+	CheckSoundness(ctx context.Context, stx tx.SignedTx) error
+}
+
+var (
+	_ SoundnessChecker = (*fullNodeSoundnessChecker)(nil)
+	_ SoundnessChecker = (*noopSoundnessChecker)(nil)
+)
+
+// soundnessChecker returns the checker configured for the rollup, rollups without
+// a soundness config are checked against their entry of FullNodeRPCs
+func (e *Executor) soundnessChecker(rollupID uint32) (SoundnessChecker, error) {
+	cfg, ok := e.config.Soundness[rollupID]
+	if !ok {
+		cfg = config.SoundnessConfig{Mode: config.SoundnessModeFullNode}
+	}
+
+	switch cfg.Mode {
+	case config.SoundnessModeFullNode, "":
+		pool, err := e.fullNodes(rollupID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &fullNodeSoundnessChecker{client: pool}, nil
+
+	case config.SoundnessModeQuorum:
+		pool, err := e.quorumPool(rollupID, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return &fullNodeSoundnessChecker{client: pool}, nil
+
+	case config.SoundnessModeNone:
+		return &noopSoundnessChecker{}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: unknown soundness mode %q for %v", ErrRollupNotConfigured, cfg.Mode, rollupID)
+	}
+}
+
+// quorumPool returns the pool of the RPCs of the quorum of the rollup, it's created once
+// as the soundness config doesn't change at runtime
+func (e *Executor) quorumPool(rollupID uint32, cfg config.SoundnessConfig) (*fullNodePool, error) {
+	e.quorumPoolsMu.Lock()
+	defer e.quorumPoolsMu.Unlock()
+
+	if pool, ok := e.quorumPools[rollupID]; ok {
+		return pool, nil
+	}
+
+	if len(cfg.RPCs) == 0 {
+		return nil, fmt.Errorf("%w: there is no RPC registered for the quorum of %v", ErrRollupNotConfigured, rollupID)
+	}
+	if cfg.Quorum < 1 || cfg.Quorum > len(cfg.RPCs) {
+		return nil, fmt.Errorf("%w: invalid quorum %d of %d RPCs for %v", ErrRollupNotConfigured, cfg.Quorum, len(cfg.RPCs), rollupID)
+	}
+
+	pool := e.newFullNodePool(rollupID, cfg.RPCs, cfg.Quorum)
+	e.quorumPools[rollupID] = pool
+
+	return pool, nil
+}
+
+// fullNodeSoundnessChecker compares the tx against the batch of the trusted full nodes of the rollup,
+// or the batch a quorum of them agree on
+type fullNodeSoundnessChecker struct {
+	client types.IZkEVMClient
+}
+
+func (c *fullNodeSoundnessChecker) CheckSoundness(ctx context.Context, stx tx.SignedTx) error {
+	return checkBatch(ctx, c.client, stx)
+}
+
+// noopSoundnessChecker trusts the rollup purely based on its ZKP
+type noopSoundnessChecker struct{}
+
+func (c *noopSoundnessChecker) CheckSoundness(ctx context.Context, stx tx.SignedTx) error {
+	return nil
+}
+
+// checkBatch compares the roots of the tx with the ones of the batch returned by the full node
+func checkBatch(ctx context.Context, client types.IZkEVMClient, signedTx tx.SignedTx) error {
+	batch, err := client.BatchByNumber(
+		ctx,
+		big.NewInt(int64(signedTx.Tx.NewVerifiedBatch)),
+	)
+	if err != nil {
+		return fmt.Errorf("%w: failed to get batch from our node, error: %w", ErrFullNodeUnavailable, err)
+	}
+	log.Debugf("get batch by number: %v", batch)
+
+	if batch == nil {
+		return fmt.Errorf(
+			"%w: unable to perform soundness check because batch with number %d is undefined",
+			ErrFullNodeUnavailable,
+			signedTx.Tx.NewVerifiedBatch,
+		)
+	}
+
+	if batch.StateRoot != signedTx.Tx.ZKP.NewStateRoot || batch.LocalExitRoot != signedTx.Tx.ZKP.NewLocalExitRoot {
+		return fmt.Errorf(
+			"%w: mismatch detected, expected local exit root: %s actual: %s. expected state root: %s actual: %s",
+			ErrStateRootMismatch,
+			signedTx.Tx.ZKP.NewLocalExitRoot.Hex(),
+			batch.LocalExitRoot.Hex(),
+			signedTx.Tx.ZKP.NewStateRoot.Hex(),
+			batch.StateRoot.Hex(),
+		)
+	}
+
+	return nil
+}
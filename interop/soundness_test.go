@@ -0,0 +1,106 @@
+package interop
+
+import (
+	"context"
+	"testing"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	"github.com/0xPolygon/agglayer/tx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutor_SoundnessChecker(t *testing.T) {
+	t.Parallel()
+
+	newExecutor := func(t *testing.T, cfg *config.Config) (*Executor, *mocks.ZkEVMClientClientCreatorMock) {
+		t.Helper()
+
+		e := New(log.WithFields("test", "test"), cfg, common.HexToAddress("0xadmin"),
+			mocks.NewEthermanMock(t), mocks.NewEthTxManagerMock(t))
+		creator := mocks.NewZkEVMClientClientCreatorMock(t)
+		e.ZkEVMClientCreator = creator
+
+		return e, creator
+	}
+
+	t.Run("defaults to the full node", func(t *testing.T) {
+		t.Parallel()
+
+		e, creator := newExecutor(t, &config.Config{FullNodeRPCs: config.FullNodeRPCs{1: {"http://node"}}})
+		creator.On("NewClient", "http://node").Return(mocks.NewZkEVMClientMock(t)).Once()
+
+		checker, err := e.soundnessChecker(1)
+		require.NoError(t, err)
+		require.IsType(t, &fullNodeSoundnessChecker{}, checker)
+	})
+
+	t.Run("full node not registered", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := newExecutor(t, &config.Config{})
+
+		_, err := e.soundnessChecker(1)
+		require.ErrorContains(t, err, "there is no RPC registered for 1")
+	})
+
+	t.Run("quorum", func(t *testing.T) {
+		t.Parallel()
+
+		e, creator := newExecutor(t, &config.Config{
+			Soundness: config.Soundness{
+				1: {Mode: config.SoundnessModeQuorum, RPCs: []string{"http://a", "http://b"}, Quorum: 2},
+			},
+		})
+		creator.On("NewClient", mock.Anything).Return(mocks.NewZkEVMClientMock(t)).Twice()
+
+		checker, err := e.soundnessChecker(1)
+		require.NoError(t, err)
+		require.IsType(t, &fullNodeSoundnessChecker{}, checker)
+
+		// the pool of the quorum is created once for the rollup
+		cached, err := e.soundnessChecker(1)
+		require.NoError(t, err)
+		require.Same(t, checker.(*fullNodeSoundnessChecker).client, cached.(*fullNodeSoundnessChecker).client)
+	})
+
+	t.Run("quorum larger than the RPCs", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := newExecutor(t, &config.Config{
+			Soundness: config.Soundness{
+				1: {Mode: config.SoundnessModeQuorum, RPCs: []string{"http://a"}, Quorum: 2},
+			},
+		})
+
+		_, err := e.soundnessChecker(1)
+		require.ErrorContains(t, err, "invalid quorum 2 of 1 RPCs")
+	})
+
+	t.Run("none doesn't need any RPC", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := newExecutor(t, &config.Config{
+			Soundness: config.Soundness{1: {Mode: config.SoundnessModeNone}},
+		})
+
+		checker, err := e.soundnessChecker(1)
+		require.NoError(t, err)
+		require.NoError(t, checker.CheckSoundness(context.Background(), tx.SignedTx{}))
+		require.NoError(t, e.CheckTx(tx.SignedTx{Tx: tx.Tx{RollupID: 1}}))
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		t.Parallel()
+
+		e, _ := newExecutor(t, &config.Config{
+			Soundness: config.Soundness{1: {Mode: "other"}},
+		})
+
+		_, err := e.soundnessChecker(1)
+		require.ErrorContains(t, err, `unknown soundness mode "other"`)
+	})
+}
@@ -0,0 +1,107 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/0xPolygon/agglayer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+)
+
+// GetRollupState returns the last batch of the rollup settled by the agglayer and the settlements
+// in flight, along with the rollup data of the rollup manager to reconcile them with
+func (e *Executor) GetRollupState(ctx context.Context, rollupID uint32, db types.IDB, dbTx pgx.Tx) (types.RollupState, error) {
+	l1State, err := e.etherman.GetRollupL1State(rollupID)
+	if err != nil {
+		return types.RollupState{}, fmt.Errorf("failed to get the L1 state of rollup %d: %w", rollupID, err)
+	}
+
+	state := types.RollupState{
+		RollupID: rollupID,
+		L1:       l1State,
+	}
+
+	settled, _, err := e.ethTxMan.List(ctx, txmTypes.MonitoredTxFilter{
+		Owner:    ethTxManOwner,
+		RollupID: &rollupID,
+		Statuses: []txmTypes.MonitoredTxStatus{txmTypes.MonitoredTxStatusConfirmed, txmTypes.MonitoredTxStatusDone},
+		Limit:    1,
+	}, dbTx)
+	if err != nil {
+		return types.RollupState{}, fmt.Errorf("failed to list the settled txs of rollup %d: %w", rollupID, err)
+	}
+
+	// settlements are sequenced per rollup, so the last one settled verifies the highest batch
+	if len(settled) > 0 {
+		lastSettled, err := e.settledBatch(ctx, settled[0], db, dbTx)
+		if err != nil {
+			return types.RollupState{}, err
+		}
+		state.LastSettled = lastSettled
+	}
+
+	inFlight, _, err := e.ethTxMan.List(ctx, txmTypes.MonitoredTxFilter{
+		Owner:    ethTxManOwner,
+		RollupID: &rollupID,
+		Statuses: []txmTypes.MonitoredTxStatus{
+			txmTypes.MonitoredTxStatusCreated,
+			txmTypes.MonitoredTxStatusSent,
+			txmTypes.MonitoredTxStatusReorged,
+		},
+		Limit: maxListLimit,
+	}, dbTx)
+	if err != nil {
+		return types.RollupState{}, fmt.Errorf("failed to list the in-flight txs of rollup %d: %w", rollupID, err)
+	}
+
+	state.InFlight = make([]types.TxSummary, 0, len(inFlight))
+	for i := len(inFlight) - 1; i >= 0; i-- {
+		state.InFlight = append(state.InFlight, types.NewTxSummary(inFlight[i]))
+	}
+
+	return state, nil
+}
+
+// settledBatch returns the batch verified by the monitored tx, with the roots proven by its intake tx
+func (e *Executor) settledBatch(ctx context.Context, mTx txmTypes.MonitoredTx, db types.IDB, dbTx pgx.Tx) (*types.SettledBatch, error) {
+	res, err := e.ethTxMan.Result(ctx, ethTxManOwner, mTx.ID, dbTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the settlement of tx %s: %w", mTx.ID, err)
+	}
+
+	settled := &types.SettledBatch{
+		TxHash:        common.HexToHash(mTx.ID),
+		L1BlockNumber: (*hexutil.Big)(res.BlockNumber),
+		SettledAt:     res.UpdatedAt,
+	}
+	if mTx.Batches != nil {
+		settled.BatchNumber = hexutil.Uint64(mTx.Batches.NewVerifiedBatch)
+	}
+
+	for hash, result := range res.Txs {
+		if result.Receipt != nil && result.Receipt.Status == ethTypes.ReceiptStatusSuccessful {
+			l1TxHash := hash
+			settled.L1TxHash = &l1TxHash
+			break
+		}
+	}
+
+	itx, err := db.GetIntakeTx(ctx, settled.TxHash, dbTx)
+	if errors.Is(err, types.ErrIntakeTxNotFound) {
+		return settled, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get tx %s: %w", mTx.ID, err)
+	}
+
+	stateRoot := itx.SignedTx.Tx.ZKP.NewStateRoot
+	localExitRoot := itx.SignedTx.Tx.ZKP.NewLocalExitRoot
+	settled.StateRoot = &stateRoot
+	settled.LocalExitRoot = &localExitRoot
+
+	return settled, nil
+}
@@ -0,0 +1,133 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	"github.com/0xPolygon/agglayer/tx"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/0xPolygon/agglayer/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutor_GetRollupState(t *testing.T) {
+	t.Parallel()
+
+	l1State := types.RollupL1State{
+		LastVerifiedBatch:  10,
+		StateRoot:          common.HexToHash("0xa"),
+		LocalExitRoot:      common.HexToHash("0xb"),
+		LastBatchSequenced: 12,
+	}
+
+	isSettled := mock.MatchedBy(func(f txmTypes.MonitoredTxFilter) bool {
+		return f.Limit == 1 && f.RollupID != nil && *f.RollupID == 1
+	})
+	isInFlight := mock.MatchedBy(func(f txmTypes.MonitoredTxFilter) bool {
+		return f.Limit == maxListLimit && f.RollupID != nil && *f.RollupID == 1
+	})
+
+	newExecutor := func(t *testing.T, etherman types.IEtherman, ethTxManager types.IEthTxManager) *Executor {
+		t.Helper()
+
+		return New(log.WithFields("test", "test"), &config.Config{}, common.HexToAddress("0x1"), etherman, ethTxManager)
+	}
+
+	t.Run("settled and in flight", func(t *testing.T) {
+		t.Parallel()
+
+		txHash := common.HexToHash("0x1")
+		settledTx := txmTypes.MonitoredTx{
+			ID:      txHash.Hex(),
+			Status:  txmTypes.MonitoredTxStatusDone,
+			Batches: &txmTypes.RollupBatches{RollupID: 1, LastVerifiedBatch: 5, NewVerifiedBatch: 10},
+		}
+		older := txmTypes.MonitoredTx{ID: common.HexToHash("0x2").Hex(), Status: txmTypes.MonitoredTxStatusSent}
+		newer := txmTypes.MonitoredTx{ID: common.HexToHash("0x3").Hex(), Status: txmTypes.MonitoredTxStatusCreated}
+
+		l1Tx := ethTypes.NewTransaction(1, common.HexToAddress("0x2"), big.NewInt(0), 21000, big.NewInt(10), nil)
+
+		etherman := mocks.NewEthermanMock(t)
+		etherman.On("GetRollupL1State", uint32(1)).Return(l1State, nil).Once()
+
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+		ethTxManager.On("List", mock.Anything, isSettled, nil).Return([]txmTypes.MonitoredTx{settledTx}, nil, nil).Once()
+		ethTxManager.On("List", mock.Anything, isInFlight, nil).Return([]txmTypes.MonitoredTx{newer, older}, nil, nil).Once()
+		ethTxManager.On("Result", mock.Anything, ethTxManOwner, txHash.Hex(), nil).
+			Return(txmTypes.MonitoredTxResult{
+				Status:      txmTypes.MonitoredTxStatusDone,
+				BlockNumber: big.NewInt(100),
+				Txs: map[common.Hash]txmTypes.TxResult{
+					l1Tx.Hash(): {Tx: l1Tx, Receipt: &ethTypes.Receipt{Status: ethTypes.ReceiptStatusSuccessful}},
+				},
+			}, nil).Once()
+
+		zkp := tx.ZKP{NewStateRoot: common.HexToHash("0xa"), NewLocalExitRoot: common.HexToHash("0xb")}
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("GetIntakeTx", mock.Anything, txHash, nil).
+			Return(types.IntakeTx{SignedTx: tx.SignedTx{Tx: tx.Tx{ZKP: zkp}}}, nil).Once()
+
+		state, err := newExecutor(t, etherman, ethTxManager).GetRollupState(context.Background(), 1, dbMock, nil)
+		require.NoError(t, err)
+
+		require.Equal(t, uint32(1), state.RollupID)
+		require.Equal(t, l1State, state.L1)
+		require.NotNil(t, state.LastSettled)
+		require.Equal(t, txHash, state.LastSettled.TxHash)
+		require.Equal(t, hexutil.Uint64(10), state.LastSettled.BatchNumber)
+		require.Equal(t, zkp.NewStateRoot, *state.LastSettled.StateRoot)
+		require.Equal(t, zkp.NewLocalExitRoot, *state.LastSettled.LocalExitRoot)
+		require.Equal(t, big.NewInt(100), state.LastSettled.L1BlockNumber.ToInt())
+		require.Equal(t, l1Tx.Hash(), *state.LastSettled.L1TxHash)
+		require.Len(t, state.InFlight, 2)
+		require.Equal(t, common.HexToHash("0x2"), state.InFlight[0].Hash)
+		require.Equal(t, common.HexToHash("0x3"), state.InFlight[1].Hash)
+	})
+
+	t.Run("nothing settled", func(t *testing.T) {
+		t.Parallel()
+
+		etherman := mocks.NewEthermanMock(t)
+		etherman.On("GetRollupL1State", uint32(1)).Return(l1State, nil).Once()
+
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+		ethTxManager.On("List", mock.Anything, mock.Anything, nil).Return(nil, nil, nil).Twice()
+
+		state, err := newExecutor(t, etherman, ethTxManager).GetRollupState(context.Background(), 1, mocks.NewDBMock(t), nil)
+		require.NoError(t, err)
+		require.Nil(t, state.LastSettled)
+		require.Empty(t, state.InFlight)
+	})
+
+	t.Run("unknown rollup", func(t *testing.T) {
+		t.Parallel()
+
+		etherman := mocks.NewEthermanMock(t)
+		etherman.On("GetRollupL1State", uint32(1)).Return(types.RollupL1State{}, types.ErrUnknownRollup).Once()
+
+		_, err := newExecutor(t, etherman, mocks.NewEthTxManagerMock(t)).GetRollupState(context.Background(), 1, mocks.NewDBMock(t), nil)
+		require.ErrorIs(t, err, types.ErrUnknownRollup)
+	})
+
+	t.Run("settlement unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		etherman := mocks.NewEthermanMock(t)
+		etherman.On("GetRollupL1State", uint32(1)).Return(l1State, nil).Once()
+
+		ethTxManager := mocks.NewEthTxManagerMock(t)
+		ethTxManager.On("List", mock.Anything, isSettled, nil).Return(nil, nil, errors.New("error")).Once()
+
+		_, err := newExecutor(t, etherman, ethTxManager).GetRollupState(context.Background(), 1, mocks.NewDBMock(t), nil)
+		require.ErrorContains(t, err, "failed to list the settled txs")
+	})
+}
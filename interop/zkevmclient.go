@@ -0,0 +1,271 @@
+package interop
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/types"
+	rpctypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"go.uber.org/zap"
+)
+
+const jsonRPCVersion = "2.0"
+
+var (
+	_ types.IZkEVMClientClientCreator = (*zkEVMClientRegistry)(nil)
+	_ types.IZkEVMClient              = (*zkEVMClient)(nil)
+	_ healthReporter                  = (*zkEVMClient)(nil)
+)
+
+// zkEVMClientRegistry keeps a long-lived client per full node, sharing a single
+// transport so the connections to the full nodes of every rollup are reused
+type zkEVMClientRegistry struct {
+	logger     *zap.SugaredLogger
+	cfg        config.ZkEVMClientConfig
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	clients map[string]*zkEVMClient
+}
+
+// NewZkEVMClientCreator returns a creator of the clients of the full nodes, with the retries
+// and the circuit breaker of the executor, for the processes querying them outside of it
+func NewZkEVMClientCreator(logger *zap.SugaredLogger, cfg config.ZkEVMClientConfig) types.IZkEVMClientClientCreator {
+	return newZkEVMClientRegistry(logger, cfg)
+}
+
+func newZkEVMClientRegistry(logger *zap.SugaredLogger, cfg config.ZkEVMClientConfig) *zkEVMClientRegistry {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	if cfg.IdleConnTimeout.Duration > 0 {
+		transport.IdleConnTimeout = cfg.IdleConnTimeout.Duration
+	}
+
+	return &zkEVMClientRegistry{
+		logger:     logger,
+		cfg:        cfg,
+		httpClient: &http.Client{Transport: transport},
+		clients:    make(map[string]*zkEVMClient),
+	}
+}
+
+// NewClient returns the client of the full node, creating it on first use
+func (r *zkEVMClientRegistry) NewClient(rpc string) types.IZkEVMClient {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if c, ok := r.clients[rpc]; ok {
+		return c
+	}
+
+	c := &zkEVMClient{
+		logger:     r.logger,
+		cfg:        r.cfg,
+		httpClient: r.httpClient,
+		url:        rpc,
+	}
+	r.clients[rpc] = c
+
+	return c
+}
+
+// zkEVMClient queries a full node, retrying the requests that fail to reach it
+// and failing fast while the full node is known to be down
+type zkEVMClient struct {
+	logger     *zap.SugaredLogger
+	cfg        config.ZkEVMClientConfig
+	httpClient *http.Client
+	url        string
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// BatchByNumber returns a batch from the full node, the latest one if number is nil
+func (c *zkEVMClient) BatchByNumber(ctx context.Context, number *big.Int) (*rpctypes.Batch, error) {
+	bn := rpctypes.LatestBatchNumber
+	if number != nil {
+		bn = rpctypes.BatchNumber(number.Int64())
+	}
+
+	var batch *rpctypes.Batch
+	if err := c.call(ctx, &batch, "zkevm_getBatchByNumber", bn.StringOrHex(), true); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// httpStatusError is a response of the full node with a non 200 status
+type httpStatusError struct {
+	code int
+	body string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("%v - %v", e.code, e.body)
+}
+
+// retryable returns whether a failed request may succeed if sent again
+func retryable(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code == http.StatusTooManyRequests || statusErr.code >= http.StatusInternalServerError
+	}
+
+	return true
+}
+
+// call sends a JSON-RPC request to the full node and decodes its result
+func (c *zkEVMClient) call(ctx context.Context, result interface{}, method string, parameters ...interface{}) error {
+	if err := c.allow(); err != nil {
+		return err
+	}
+
+	params, err := json.Marshal(parameters)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(rpctypes.Request{
+		JSONRPC: jsonRPCVersion,
+		ID:      float64(1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	parent := ctx
+	if c.cfg.Timeout.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.cfg.Timeout.Duration)
+		defer cancel()
+	}
+
+	backoff := c.cfg.RetryBackoff.Duration
+	for attempt := 0; ; attempt++ {
+		res, err := c.send(ctx, body)
+		if err == nil {
+			c.succeeded()
+			if res.Error != nil {
+				return res.Error.RPCError()
+			}
+
+			return json.Unmarshal(res.Result, result)
+		}
+
+		if attempt >= c.cfg.MaxRetries || !retryable(err) || ctx.Err() != nil {
+			// a request cancelled by the caller says nothing about the health of the full node
+			if parent.Err() == nil {
+				c.failed()
+			}
+
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if parent.Err() == nil {
+				c.failed()
+			}
+
+			return fmt.Errorf("%w, last error: %s", ctx.Err(), err)
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if c.cfg.MaxRetryBackoff.Duration > 0 && backoff > c.cfg.MaxRetryBackoff.Duration {
+			backoff = c.cfg.MaxRetryBackoff.Duration
+		}
+	}
+}
+
+func (c *zkEVMClient) send(ctx context.Context, body []byte) (rpctypes.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return rpctypes.Response{}, err
+	}
+	req.Header.Add("Content-type", "application/json")
+
+	httpRes, err := c.httpClient.Do(req)
+	if err != nil {
+		return rpctypes.Response{}, err
+	}
+	defer httpRes.Body.Close()
+
+	resBody, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return rpctypes.Response{}, err
+	}
+
+	if httpRes.StatusCode != http.StatusOK {
+		return rpctypes.Response{}, &httpStatusError{code: httpRes.StatusCode, body: string(resBody)}
+	}
+
+	var res rpctypes.Response
+	if err := json.Unmarshal(resBody, &res); err != nil {
+		return rpctypes.Response{}, err
+	}
+
+	return res, nil
+}
+
+// allow fails fast while the circuit breaker of the full node is open
+func (c *zkEVMClient) allow() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if time.Now().Before(c.openUntil) {
+		return fmt.Errorf("%w: %s until %s", ErrCircuitOpen, c.url, c.openUntil.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// healthy returns whether the circuit breaker of the full node is closed
+func (c *zkEVMClient) healthy() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return !time.Now().Before(c.openUntil)
+}
+
+func (c *zkEVMClient) succeeded() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failures = 0
+	c.openUntil = time.Time{}
+}
+
+// failed records a failed request, opening the circuit breaker once the threshold
+// is reached. After the cooldown a single failure opens it again
+func (c *zkEVMClient) failed() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failures++
+	if c.cfg.BreakerThreshold <= 0 || c.failures < c.cfg.BreakerThreshold {
+		return
+	}
+
+	c.openUntil = time.Now().Add(c.cfg.BreakerCooldown.Duration)
+	if c.logger != nil {
+		c.logger.Warnf("full node %s failed %d times in a row, not querying it until %s", c.url, c.failures, c.openUntil)
+	}
+}
@@ -0,0 +1,145 @@
+package interop
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	configTypes "github.com/0xPolygonHermez/zkevm-node/config/types"
+	rpctypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZkEVMClient(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.ZkEVMClientConfig{
+		Timeout:          configTypes.NewDuration(time.Second),
+		MaxRetries:       2,
+		RetryBackoff:     configTypes.NewDuration(time.Millisecond),
+		MaxRetryBackoff:  configTypes.NewDuration(2 * time.Millisecond),
+		BreakerThreshold: 2,
+		BreakerCooldown:  configTypes.NewDuration(time.Minute),
+	}
+
+	batch := rpctypes.Batch{
+		Number:        1,
+		StateRoot:     common.HexToHash("0x1"),
+		LocalExitRoot: common.HexToHash("0x2"),
+	}
+
+	// newServer answers with the given statuses in order and with the batch once they're exhausted
+	newServer := func(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+		t.Helper()
+
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := int(requests.Add(1))
+			if n <= len(statuses) {
+				w.WriteHeader(statuses[n-1])
+				return
+			}
+
+			var req rpctypes.Request
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, "zkevm_getBatchByNumber", req.Method)
+
+			result, err := json.Marshal(batch)
+			require.NoError(t, err)
+			require.NoError(t, json.NewEncoder(w).Encode(rpctypes.Response{JSONRPC: "2.0", ID: req.ID, Result: result}))
+		}))
+		t.Cleanup(server.Close)
+
+		return server, &requests
+	}
+
+	t.Run("registry reuses the client of a full node", func(t *testing.T) {
+		t.Parallel()
+
+		r := newZkEVMClientRegistry(log.WithFields("test", "test"), cfg)
+		require.Same(t, r.NewClient("http://a"), r.NewClient("http://a"))
+		require.NotSame(t, r.NewClient("http://a"), r.NewClient("http://b"))
+	})
+
+	t.Run("batch by number", func(t *testing.T) {
+		t.Parallel()
+
+		server, requests := newServer(t)
+		c := newZkEVMClientRegistry(log.WithFields("test", "test"), cfg).NewClient(server.URL)
+
+		result, err := c.BatchByNumber(context.Background(), big.NewInt(1))
+		require.NoError(t, err)
+		require.Equal(t, batch.StateRoot, result.StateRoot)
+		require.Equal(t, batch.LocalExitRoot, result.LocalExitRoot)
+		require.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("server errors are retried", func(t *testing.T) {
+		t.Parallel()
+
+		server, requests := newServer(t, http.StatusBadGateway, http.StatusTooManyRequests)
+		c := newZkEVMClientRegistry(log.WithFields("test", "test"), cfg).NewClient(server.URL)
+
+		_, err := c.BatchByNumber(context.Background(), big.NewInt(1))
+		require.NoError(t, err)
+		require.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		t.Parallel()
+
+		server, requests := newServer(t, http.StatusBadRequest)
+		c := newZkEVMClientRegistry(log.WithFields("test", "test"), cfg).NewClient(server.URL)
+
+		_, err := c.BatchByNumber(context.Background(), big.NewInt(1))
+		require.ErrorContains(t, err, "400")
+		require.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("circuit breaker opens after consecutive failures", func(t *testing.T) {
+		t.Parallel()
+
+		server, requests := newServer(t,
+			http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError,
+			http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError,
+		)
+		c := newZkEVMClientRegistry(log.WithFields("test", "test"), cfg).NewClient(server.URL)
+
+		for i := 0; i < cfg.BreakerThreshold; i++ {
+			require.True(t, c.(healthReporter).healthy())
+			_, err := c.BatchByNumber(context.Background(), big.NewInt(1))
+			require.ErrorContains(t, err, "500")
+		}
+
+		require.False(t, c.(healthReporter).healthy())
+		_, err := c.BatchByNumber(context.Background(), big.NewInt(1))
+		require.ErrorIs(t, err, ErrCircuitOpen)
+		require.Equal(t, int32(cfg.BreakerThreshold*(cfg.MaxRetries+1)), requests.Load())
+	})
+
+	t.Run("cancelled requests don't open the circuit breaker", func(t *testing.T) {
+		t.Parallel()
+
+		server, _ := newServer(t)
+		c := newZkEVMClientRegistry(log.WithFields("test", "test"), cfg).NewClient(server.URL)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		for i := 0; i < cfg.BreakerThreshold; i++ {
+			_, err := c.BatchByNumber(ctx, big.NewInt(1))
+			require.ErrorIs(t, err, context.Canceled)
+		}
+
+		_, err := c.BatchByNumber(context.Background(), big.NewInt(1))
+		require.NoError(t, err)
+	})
+}
@@ -28,17 +28,17 @@ func (_m *EthTxManagerMock) EXPECT() *EthTxManagerMock_Expecter {
 	return &EthTxManagerMock_Expecter{mock: &_m.Mock}
 }
 
-// Add provides a mock function with given fields: ctx, owner, id, from, to, value, data, gasOffset, dbTx
-func (_m *EthTxManagerMock) Add(ctx context.Context, owner string, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, dbTx pgx.Tx) error {
-	ret := _m.Called(ctx, owner, id, from, to, value, data, gasOffset, dbTx)
+// Add provides a mock function with given fields: ctx, owner, id, from, to, value, data, gasOffset, batches, dbTx
+func (_m *EthTxManagerMock) Add(ctx context.Context, owner string, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, batches *txmanagertypes.RollupBatches, dbTx pgx.Tx) error {
+	ret := _m.Called(ctx, owner, id, from, to, value, data, gasOffset, batches, dbTx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Add")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, common.Address, *common.Address, *big.Int, []byte, uint64, pgx.Tx) error); ok {
-		r0 = rf(ctx, owner, id, from, to, value, data, gasOffset, dbTx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, common.Address, *common.Address, *big.Int, []byte, uint64, *txmanagertypes.RollupBatches, pgx.Tx) error); ok {
+		r0 = rf(ctx, owner, id, from, to, value, data, gasOffset, batches, dbTx)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -60,14 +60,15 @@ type EthTxManagerMock_Add_Call struct {
 //   - value *big.Int
 //   - data []byte
 //   - gasOffset uint64
+//   - batches *txmanagertypes.RollupBatches
 //   - dbTx pgx.Tx
-func (_e *EthTxManagerMock_Expecter) Add(ctx interface{}, owner interface{}, id interface{}, from interface{}, to interface{}, value interface{}, data interface{}, gasOffset interface{}, dbTx interface{}) *EthTxManagerMock_Add_Call {
-	return &EthTxManagerMock_Add_Call{Call: _e.mock.On("Add", ctx, owner, id, from, to, value, data, gasOffset, dbTx)}
+func (_e *EthTxManagerMock_Expecter) Add(ctx interface{}, owner interface{}, id interface{}, from interface{}, to interface{}, value interface{}, data interface{}, gasOffset interface{}, batches interface{}, dbTx interface{}) *EthTxManagerMock_Add_Call {
+	return &EthTxManagerMock_Add_Call{Call: _e.mock.On("Add", ctx, owner, id, from, to, value, data, gasOffset, batches, dbTx)}
 }
 
-func (_c *EthTxManagerMock_Add_Call) Run(run func(ctx context.Context, owner string, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, dbTx pgx.Tx)) *EthTxManagerMock_Add_Call {
+func (_c *EthTxManagerMock_Add_Call) Run(run func(ctx context.Context, owner string, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, batches *txmanagertypes.RollupBatches, dbTx pgx.Tx)) *EthTxManagerMock_Add_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(common.Address), args[4].(*common.Address), args[5].(*big.Int), args[6].([]byte), args[7].(uint64), args[8].(pgx.Tx))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(common.Address), args[4].(*common.Address), args[5].(*big.Int), args[6].([]byte), args[7].(uint64), args[8].(*txmanagertypes.RollupBatches), args[9].(pgx.Tx))
 	})
 	return _c
 }
@@ -77,7 +78,76 @@ func (_c *EthTxManagerMock_Add_Call) Return(_a0 error) *EthTxManagerMock_Add_Cal
 	return _c
 }
 
-func (_c *EthTxManagerMock_Add_Call) RunAndReturn(run func(context.Context, string, string, common.Address, *common.Address, *big.Int, []byte, uint64, pgx.Tx) error) *EthTxManagerMock_Add_Call {
+func (_c *EthTxManagerMock_Add_Call) RunAndReturn(run func(context.Context, string, string, common.Address, *common.Address, *big.Int, []byte, uint64, *txmanagertypes.RollupBatches, pgx.Tx) error) *EthTxManagerMock_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, filter, dbTx
+func (_m *EthTxManagerMock) List(ctx context.Context, filter txmanagertypes.MonitoredTxFilter, dbTx pgx.Tx) ([]txmanagertypes.MonitoredTx, *txmanagertypes.MonitoredTxCursor, error) {
+	ret := _m.Called(ctx, filter, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []txmanagertypes.MonitoredTx
+	var r1 *txmanagertypes.MonitoredTxCursor
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, txmanagertypes.MonitoredTxFilter, pgx.Tx) ([]txmanagertypes.MonitoredTx, *txmanagertypes.MonitoredTxCursor, error)); ok {
+		return rf(ctx, filter, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, txmanagertypes.MonitoredTxFilter, pgx.Tx) []txmanagertypes.MonitoredTx); ok {
+		r0 = rf(ctx, filter, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]txmanagertypes.MonitoredTx)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, txmanagertypes.MonitoredTxFilter, pgx.Tx) *txmanagertypes.MonitoredTxCursor); ok {
+		r1 = rf(ctx, filter, dbTx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*txmanagertypes.MonitoredTxCursor)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, txmanagertypes.MonitoredTxFilter, pgx.Tx) error); ok {
+		r2 = rf(ctx, filter, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// EthTxManagerMock_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type EthTxManagerMock_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter txmanagertypes.MonitoredTxFilter
+//   - dbTx pgx.Tx
+func (_e *EthTxManagerMock_Expecter) List(ctx interface{}, filter interface{}, dbTx interface{}) *EthTxManagerMock_List_Call {
+	return &EthTxManagerMock_List_Call{Call: _e.mock.On("List", ctx, filter, dbTx)}
+}
+
+func (_c *EthTxManagerMock_List_Call) Run(run func(ctx context.Context, filter txmanagertypes.MonitoredTxFilter, dbTx pgx.Tx)) *EthTxManagerMock_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(txmanagertypes.MonitoredTxFilter), args[2].(pgx.Tx))
+	})
+	return _c
+}
+
+func (_c *EthTxManagerMock_List_Call) Return(_a0 []txmanagertypes.MonitoredTx, _a1 *txmanagertypes.MonitoredTxCursor, _a2 error) *EthTxManagerMock_List_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *EthTxManagerMock_List_Call) RunAndReturn(run func(context.Context, txmanagertypes.MonitoredTxFilter, pgx.Tx) ([]txmanagertypes.MonitoredTx, *txmanagertypes.MonitoredTxCursor, error)) *EthTxManagerMock_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -21,6 +21,8 @@ import (
 	time "time"
 
 	tx "github.com/0xPolygon/agglayer/tx"
+
+	types "github.com/0xPolygon/agglayer/types"
 )
 
 // EthermanMock is an autogenerated mock type for the IEtherman type
@@ -36,9 +38,9 @@ func (_m *EthermanMock) EXPECT() *EthermanMock_Expecter {
 	return &EthermanMock_Expecter{mock: &_m.Mock}
 }
 
-// BuildTrustedVerifyBatchesTxData provides a mock function with given fields: lastVerifiedBatch, newVerifiedBatch, proof, rollupId
-func (_m *EthermanMock) BuildTrustedVerifyBatchesTxData(lastVerifiedBatch uint64, newVerifiedBatch uint64, proof tx.ZKP, rollupId uint32) ([]byte, error) {
-	ret := _m.Called(lastVerifiedBatch, newVerifiedBatch, proof, rollupId)
+// BuildTrustedVerifyBatchesTxData provides a mock function with given fields: lastVerifiedBatch, newVerifiedBatch, proof, rollupId, pendingStateNum
+func (_m *EthermanMock) BuildTrustedVerifyBatchesTxData(lastVerifiedBatch uint64, newVerifiedBatch uint64, proof tx.ZKP, rollupId uint32, pendingStateNum uint64) ([]byte, error) {
+	ret := _m.Called(lastVerifiedBatch, newVerifiedBatch, proof, rollupId, pendingStateNum)
 
 	if len(ret) == 0 {
 		panic("no return value specified for BuildTrustedVerifyBatchesTxData")
@@ -46,19 +48,19 @@ func (_m *EthermanMock) BuildTrustedVerifyBatchesTxData(lastVerifiedBatch uint64
 
 	var r0 []byte
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64, uint64, tx.ZKP, uint32) ([]byte, error)); ok {
-		return rf(lastVerifiedBatch, newVerifiedBatch, proof, rollupId)
+	if rf, ok := ret.Get(0).(func(uint64, uint64, tx.ZKP, uint32, uint64) ([]byte, error)); ok {
+		return rf(lastVerifiedBatch, newVerifiedBatch, proof, rollupId, pendingStateNum)
 	}
-	if rf, ok := ret.Get(0).(func(uint64, uint64, tx.ZKP, uint32) []byte); ok {
-		r0 = rf(lastVerifiedBatch, newVerifiedBatch, proof, rollupId)
+	if rf, ok := ret.Get(0).(func(uint64, uint64, tx.ZKP, uint32, uint64) []byte); ok {
+		r0 = rf(lastVerifiedBatch, newVerifiedBatch, proof, rollupId, pendingStateNum)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]byte)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64, uint64, tx.ZKP, uint32) error); ok {
-		r1 = rf(lastVerifiedBatch, newVerifiedBatch, proof, rollupId)
+	if rf, ok := ret.Get(1).(func(uint64, uint64, tx.ZKP, uint32, uint64) error); ok {
+		r1 = rf(lastVerifiedBatch, newVerifiedBatch, proof, rollupId, pendingStateNum)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -76,13 +78,14 @@ type EthermanMock_BuildTrustedVerifyBatchesTxData_Call struct {
 //   - newVerifiedBatch uint64
 //   - proof tx.ZKP
 //   - rollupId uint32
-func (_e *EthermanMock_Expecter) BuildTrustedVerifyBatchesTxData(lastVerifiedBatch interface{}, newVerifiedBatch interface{}, proof interface{}, rollupId interface{}) *EthermanMock_BuildTrustedVerifyBatchesTxData_Call {
-	return &EthermanMock_BuildTrustedVerifyBatchesTxData_Call{Call: _e.mock.On("BuildTrustedVerifyBatchesTxData", lastVerifiedBatch, newVerifiedBatch, proof, rollupId)}
+//   - pendingStateNum uint64
+func (_e *EthermanMock_Expecter) BuildTrustedVerifyBatchesTxData(lastVerifiedBatch interface{}, newVerifiedBatch interface{}, proof interface{}, rollupId interface{}, pendingStateNum interface{}) *EthermanMock_BuildTrustedVerifyBatchesTxData_Call {
+	return &EthermanMock_BuildTrustedVerifyBatchesTxData_Call{Call: _e.mock.On("BuildTrustedVerifyBatchesTxData", lastVerifiedBatch, newVerifiedBatch, proof, rollupId, pendingStateNum)}
 }
 
-func (_c *EthermanMock_BuildTrustedVerifyBatchesTxData_Call) Run(run func(lastVerifiedBatch uint64, newVerifiedBatch uint64, proof tx.ZKP, rollupId uint32)) *EthermanMock_BuildTrustedVerifyBatchesTxData_Call {
+func (_c *EthermanMock_BuildTrustedVerifyBatchesTxData_Call) Run(run func(lastVerifiedBatch uint64, newVerifiedBatch uint64, proof tx.ZKP, rollupId uint32, pendingStateNum uint64)) *EthermanMock_BuildTrustedVerifyBatchesTxData_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint64), args[1].(uint64), args[2].(tx.ZKP), args[3].(uint32))
+		run(args[0].(uint64), args[1].(uint64), args[2].(tx.ZKP), args[3].(uint32), args[4].(uint64))
 	})
 	return _c
 }
@@ -92,7 +95,7 @@ func (_c *EthermanMock_BuildTrustedVerifyBatchesTxData_Call) Return(data []byte,
 	return _c
 }
 
-func (_c *EthermanMock_BuildTrustedVerifyBatchesTxData_Call) RunAndReturn(run func(uint64, uint64, tx.ZKP, uint32) ([]byte, error)) *EthermanMock_BuildTrustedVerifyBatchesTxData_Call {
+func (_c *EthermanMock_BuildTrustedVerifyBatchesTxData_Call) RunAndReturn(run func(uint64, uint64, tx.ZKP, uint32, uint64) ([]byte, error)) *EthermanMock_BuildTrustedVerifyBatchesTxData_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -283,6 +286,180 @@ func (_c *EthermanMock_EstimateGas_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetCachedSequencerAddr provides a mock function with given fields: rollupId
+func (_m *EthermanMock) GetCachedSequencerAddr(rollupId uint32) (common.Address, bool) {
+	ret := _m.Called(rollupId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCachedSequencerAddr")
+	}
+
+	var r0 common.Address
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(uint32) (common.Address, bool)); ok {
+		return rf(rollupId)
+	}
+	if rf, ok := ret.Get(0).(func(uint32) common.Address); ok {
+		r0 = rf(rollupId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Address)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint32) bool); ok {
+		r1 = rf(rollupId)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// EthermanMock_GetCachedSequencerAddr_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCachedSequencerAddr'
+type EthermanMock_GetCachedSequencerAddr_Call struct {
+	*mock.Call
+}
+
+// GetCachedSequencerAddr is a helper method to define mock.On call
+//   - rollupId uint32
+func (_e *EthermanMock_Expecter) GetCachedSequencerAddr(rollupId interface{}) *EthermanMock_GetCachedSequencerAddr_Call {
+	return &EthermanMock_GetCachedSequencerAddr_Call{Call: _e.mock.On("GetCachedSequencerAddr", rollupId)}
+}
+
+func (_c *EthermanMock_GetCachedSequencerAddr_Call) Run(run func(rollupId uint32)) *EthermanMock_GetCachedSequencerAddr_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint32))
+	})
+	return _c
+}
+
+func (_c *EthermanMock_GetCachedSequencerAddr_Call) Return(_a0 common.Address, _a1 bool) *EthermanMock_GetCachedSequencerAddr_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanMock_GetCachedSequencerAddr_Call) RunAndReturn(run func(uint32) (common.Address, bool)) *EthermanMock_GetCachedSequencerAddr_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFinalizedBlock provides a mock function with given fields: ctx
+func (_m *EthermanMock) GetFinalizedBlock(ctx context.Context) (*state.Block, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFinalizedBlock")
+	}
+
+	var r0 *state.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*state.Block, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *state.Block); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthermanMock_GetFinalizedBlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFinalizedBlock'
+type EthermanMock_GetFinalizedBlock_Call struct {
+	*mock.Call
+}
+
+// GetFinalizedBlock is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *EthermanMock_Expecter) GetFinalizedBlock(ctx interface{}) *EthermanMock_GetFinalizedBlock_Call {
+	return &EthermanMock_GetFinalizedBlock_Call{Call: _e.mock.On("GetFinalizedBlock", ctx)}
+}
+
+func (_c *EthermanMock_GetFinalizedBlock_Call) Run(run func(ctx context.Context)) *EthermanMock_GetFinalizedBlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *EthermanMock_GetFinalizedBlock_Call) Return(_a0 *state.Block, _a1 error) *EthermanMock_GetFinalizedBlock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanMock_GetFinalizedBlock_Call) RunAndReturn(run func(context.Context) (*state.Block, error)) *EthermanMock_GetFinalizedBlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFreshSequencerAddr provides a mock function with given fields: rollupId
+func (_m *EthermanMock) GetFreshSequencerAddr(rollupId uint32) (common.Address, error) {
+	ret := _m.Called(rollupId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFreshSequencerAddr")
+	}
+
+	var r0 common.Address
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint32) (common.Address, error)); ok {
+		return rf(rollupId)
+	}
+	if rf, ok := ret.Get(0).(func(uint32) common.Address); ok {
+		r0 = rf(rollupId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Address)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint32) error); ok {
+		r1 = rf(rollupId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthermanMock_GetFreshSequencerAddr_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFreshSequencerAddr'
+type EthermanMock_GetFreshSequencerAddr_Call struct {
+	*mock.Call
+}
+
+// GetFreshSequencerAddr is a helper method to define mock.On call
+//   - rollupId uint32
+func (_e *EthermanMock_Expecter) GetFreshSequencerAddr(rollupId interface{}) *EthermanMock_GetFreshSequencerAddr_Call {
+	return &EthermanMock_GetFreshSequencerAddr_Call{Call: _e.mock.On("GetFreshSequencerAddr", rollupId)}
+}
+
+func (_c *EthermanMock_GetFreshSequencerAddr_Call) Run(run func(rollupId uint32)) *EthermanMock_GetFreshSequencerAddr_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint32))
+	})
+	return _c
+}
+
+func (_c *EthermanMock_GetFreshSequencerAddr_Call) Return(_a0 common.Address, _a1 error) *EthermanMock_GetFreshSequencerAddr_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanMock_GetFreshSequencerAddr_Call) RunAndReturn(run func(uint32) (common.Address, error)) *EthermanMock_GetFreshSequencerAddr_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLastBlock provides a mock function with given fields: ctx, dbTx
 func (_m *EthermanMock) GetLastBlock(ctx context.Context, dbTx pgx.Tx) (*state.Block, error) {
 	ret := _m.Called(ctx, dbTx)
@@ -342,6 +519,62 @@ func (_c *EthermanMock_GetLastBlock_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetLastVerifiedBatch provides a mock function with given fields: rollupId
+func (_m *EthermanMock) GetLastVerifiedBatch(rollupId uint32) (uint64, error) {
+	ret := _m.Called(rollupId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastVerifiedBatch")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint32) (uint64, error)); ok {
+		return rf(rollupId)
+	}
+	if rf, ok := ret.Get(0).(func(uint32) uint64); ok {
+		r0 = rf(rollupId)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint32) error); ok {
+		r1 = rf(rollupId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthermanMock_GetLastVerifiedBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastVerifiedBatch'
+type EthermanMock_GetLastVerifiedBatch_Call struct {
+	*mock.Call
+}
+
+// GetLastVerifiedBatch is a helper method to define mock.On call
+//   - rollupId uint32
+func (_e *EthermanMock_Expecter) GetLastVerifiedBatch(rollupId interface{}) *EthermanMock_GetLastVerifiedBatch_Call {
+	return &EthermanMock_GetLastVerifiedBatch_Call{Call: _e.mock.On("GetLastVerifiedBatch", rollupId)}
+}
+
+func (_c *EthermanMock_GetLastVerifiedBatch_Call) Run(run func(rollupId uint32)) *EthermanMock_GetLastVerifiedBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint32))
+	})
+	return _c
+}
+
+func (_c *EthermanMock_GetLastVerifiedBatch_Call) Return(_a0 uint64, _a1 error) *EthermanMock_GetLastVerifiedBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanMock_GetLastVerifiedBatch_Call) RunAndReturn(run func(uint32) (uint64, error)) *EthermanMock_GetLastVerifiedBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetRevertMessage provides a mock function with given fields: ctx, _a1
 func (_m *EthermanMock) GetRevertMessage(ctx context.Context, _a1 *coretypes.Transaction) (string, error) {
 	ret := _m.Called(ctx, _a1)
@@ -399,6 +632,173 @@ func (_c *EthermanMock_GetRevertMessage_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// GetRollup provides a mock function with given fields: rollupId
+func (_m *EthermanMock) GetRollup(rollupId uint32) (types.Rollup, error) {
+	ret := _m.Called(rollupId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRollup")
+	}
+
+	var r0 types.Rollup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint32) (types.Rollup, error)); ok {
+		return rf(rollupId)
+	}
+	if rf, ok := ret.Get(0).(func(uint32) types.Rollup); ok {
+		r0 = rf(rollupId)
+	} else {
+		r0 = ret.Get(0).(types.Rollup)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint32) error); ok {
+		r1 = rf(rollupId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthermanMock_GetRollup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRollup'
+type EthermanMock_GetRollup_Call struct {
+	*mock.Call
+}
+
+// GetRollup is a helper method to define mock.On call
+//   - rollupId uint32
+func (_e *EthermanMock_Expecter) GetRollup(rollupId interface{}) *EthermanMock_GetRollup_Call {
+	return &EthermanMock_GetRollup_Call{Call: _e.mock.On("GetRollup", rollupId)}
+}
+
+func (_c *EthermanMock_GetRollup_Call) Run(run func(rollupId uint32)) *EthermanMock_GetRollup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint32))
+	})
+	return _c
+}
+
+func (_c *EthermanMock_GetRollup_Call) Return(_a0 types.Rollup, _a1 error) *EthermanMock_GetRollup_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanMock_GetRollup_Call) RunAndReturn(run func(uint32) (types.Rollup, error)) *EthermanMock_GetRollup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRollupCount provides a mock function with given fields:
+func (_m *EthermanMock) GetRollupCount() (uint32, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRollupCount")
+	}
+
+	var r0 uint32
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (uint32, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() uint32); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint32)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthermanMock_GetRollupCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRollupCount'
+type EthermanMock_GetRollupCount_Call struct {
+	*mock.Call
+}
+
+// GetRollupCount is a helper method to define mock.On call
+func (_e *EthermanMock_Expecter) GetRollupCount() *EthermanMock_GetRollupCount_Call {
+	return &EthermanMock_GetRollupCount_Call{Call: _e.mock.On("GetRollupCount")}
+}
+
+func (_c *EthermanMock_GetRollupCount_Call) Run(run func()) *EthermanMock_GetRollupCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *EthermanMock_GetRollupCount_Call) Return(_a0 uint32, _a1 error) *EthermanMock_GetRollupCount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanMock_GetRollupCount_Call) RunAndReturn(run func() (uint32, error)) *EthermanMock_GetRollupCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRollupL1State provides a mock function with given fields: rollupId
+func (_m *EthermanMock) GetRollupL1State(rollupId uint32) (types.RollupL1State, error) {
+	ret := _m.Called(rollupId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRollupL1State")
+	}
+
+	var r0 types.RollupL1State
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint32) (types.RollupL1State, error)); ok {
+		return rf(rollupId)
+	}
+	if rf, ok := ret.Get(0).(func(uint32) types.RollupL1State); ok {
+		r0 = rf(rollupId)
+	} else {
+		r0 = ret.Get(0).(types.RollupL1State)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint32) error); ok {
+		r1 = rf(rollupId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthermanMock_GetRollupL1State_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRollupL1State'
+type EthermanMock_GetRollupL1State_Call struct {
+	*mock.Call
+}
+
+// GetRollupL1State is a helper method to define mock.On call
+//   - rollupId uint32
+func (_e *EthermanMock_Expecter) GetRollupL1State(rollupId interface{}) *EthermanMock_GetRollupL1State_Call {
+	return &EthermanMock_GetRollupL1State_Call{Call: _e.mock.On("GetRollupL1State", rollupId)}
+}
+
+func (_c *EthermanMock_GetRollupL1State_Call) Run(run func(rollupId uint32)) *EthermanMock_GetRollupL1State_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint32))
+	})
+	return _c
+}
+
+func (_c *EthermanMock_GetRollupL1State_Call) Return(_a0 types.RollupL1State, _a1 error) *EthermanMock_GetRollupL1State_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanMock_GetRollupL1State_Call) RunAndReturn(run func(uint32) (types.RollupL1State, error)) *EthermanMock_GetRollupL1State_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetSequencerAddr provides a mock function with given fields: rollupId
 func (_m *EthermanMock) GetSequencerAddr(rollupId uint32) (common.Address, error) {
 	ret := _m.Called(rollupId)
@@ -582,6 +982,66 @@ func (_c *EthermanMock_GetTxReceipt_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetUpdatedRollups provides a mock function with given fields: ctx, fromBlock, toBlock
+func (_m *EthermanMock) GetUpdatedRollups(ctx context.Context, fromBlock uint64, toBlock uint64) ([]uint32, error) {
+	ret := _m.Called(ctx, fromBlock, toBlock)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpdatedRollups")
+	}
+
+	var r0 []uint32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]uint32, error)); ok {
+		return rf(ctx, fromBlock, toBlock)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, uint64) []uint32); ok {
+		r0 = rf(ctx, fromBlock, toBlock)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint32)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = rf(ctx, fromBlock, toBlock)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EthermanMock_GetUpdatedRollups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUpdatedRollups'
+type EthermanMock_GetUpdatedRollups_Call struct {
+	*mock.Call
+}
+
+// GetUpdatedRollups is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromBlock uint64
+//   - toBlock uint64
+func (_e *EthermanMock_Expecter) GetUpdatedRollups(ctx interface{}, fromBlock interface{}, toBlock interface{}) *EthermanMock_GetUpdatedRollups_Call {
+	return &EthermanMock_GetUpdatedRollups_Call{Call: _e.mock.On("GetUpdatedRollups", ctx, fromBlock, toBlock)}
+}
+
+func (_c *EthermanMock_GetUpdatedRollups_Call) Run(run func(ctx context.Context, fromBlock uint64, toBlock uint64)) *EthermanMock_GetUpdatedRollups_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(uint64))
+	})
+	return _c
+}
+
+func (_c *EthermanMock_GetUpdatedRollups_Call) Return(_a0 []uint32, _a1 error) *EthermanMock_GetUpdatedRollups_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *EthermanMock_GetUpdatedRollups_Call) RunAndReturn(run func(context.Context, uint64, uint64) ([]uint32, error)) *EthermanMock_GetUpdatedRollups_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PendingNonce provides a mock function with given fields: ctx, account
 func (_m *EthermanMock) PendingNonce(ctx context.Context, account common.Address) (uint64, error) {
 	ret := _m.Called(ctx, account)
@@ -0,0 +1,122 @@
+package relayer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/agglayer/config"
+	rpcTypes "github.com/0xPolygon/agglayer/rpc/types"
+)
+
+// ErrProofNotFound is returned by a proof source without a proof from the batch yet
+var ErrProofNotFound = errors.New("proof not found")
+
+var (
+	_ ProofSource = (*dirProofSource)(nil)
+	_ ProofSource = (*httpProofSource)(nil)
+)
+
+// Proof is the proof of the batches from a last verified batch, exclusive, to NewVerifiedBatch
+type Proof struct {
+	NewVerifiedBatch rpcTypes.ArgUint64 `json:"newVerifiedBatch"`
+	Proof            rpcTypes.ArgBytes  `json:"proof"`
+}
+
+// ProofSource returns the proofs of the aggregator of the rollup
+type ProofSource interface {
+	// Proof returns the proof of the batches following lastVerifiedBatch, or ErrProofNotFound
+	Proof(ctx context.Context, lastVerifiedBatch uint64) (Proof, error)
+}
+
+// NewProofSource returns the proof source of the config
+func NewProofSource(cfg config.RelayerConfig) (ProofSource, error) {
+	switch cfg.ProofSource {
+	case config.ProofSourceDir:
+		if cfg.ProofDir == "" {
+			return nil, errors.New("ProofDir is required by the dir proof source")
+		}
+
+		return &dirProofSource{dir: cfg.ProofDir}, nil
+	case config.ProofSourceHTTP:
+		if cfg.ProofURL == "" {
+			return nil, errors.New("ProofURL is required by the http proof source")
+		}
+
+		return &httpProofSource{url: strings.TrimSuffix(cfg.ProofURL, "/"), httpClient: http.DefaultClient}, nil
+	default:
+		return nil, fmt.Errorf("unknown proof source %q", cfg.ProofSource)
+	}
+}
+
+// dirProofSource reads the proof following a batch from the <lastVerifiedBatch>.json file of the directory
+type dirProofSource struct {
+	dir string
+}
+
+func (s *dirProofSource) Proof(ctx context.Context, lastVerifiedBatch uint64) (Proof, error) {
+	path := filepath.Join(s.dir, strconv.FormatUint(lastVerifiedBatch, 10)+".json")
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return Proof{}, ErrProofNotFound
+	}
+	if err != nil {
+		return Proof{}, err
+	}
+
+	var proof Proof
+	if err := json.Unmarshal(data, &proof); err != nil {
+		return Proof{}, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	return proof, nil
+}
+
+// httpProofSource fetches the proof following a batch from <url>/<lastVerifiedBatch>, a 404 meaning it's not ready
+type httpProofSource struct {
+	url        string
+	httpClient *http.Client
+}
+
+func (s *httpProofSource) Proof(ctx context.Context, lastVerifiedBatch uint64) (Proof, error) {
+	url := s.url + "/" + strconv.FormatUint(lastVerifiedBatch, 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Proof{}, err
+	}
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return Proof{}, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return Proof{}, err
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return Proof{}, ErrProofNotFound
+	default:
+		return Proof{}, fmt.Errorf("failed to get proof from %s: %v - %v", url, res.StatusCode, string(body))
+	}
+
+	var proof Proof
+	if err := json.Unmarshal(body, &proof); err != nil {
+		return Proof{}, fmt.Errorf("failed to decode proof from %s: %w", url, err)
+	}
+
+	return proof, nil
+}
@@ -0,0 +1,115 @@
+package relayer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/agglayer/config"
+)
+
+func TestProofSource(t *testing.T) {
+	t.Parallel()
+
+	expected := Proof{NewVerifiedBatch: 5, Proof: []byte{0x01, 0x02}}
+
+	t.Run("dir", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "3.json"), []byte(`{"newVerifiedBatch":"0x5","proof":"0x0102"}`), 0600))
+
+		source, err := NewProofSource(config.RelayerConfig{ProofSource: config.ProofSourceDir, ProofDir: dir})
+		require.NoError(t, err)
+
+		proof, err := source.Proof(context.Background(), 3)
+		require.NoError(t, err)
+		require.Equal(t, expected, proof)
+
+		_, err = source.Proof(context.Background(), 5)
+		require.ErrorIs(t, err, ErrProofNotFound)
+	})
+
+	t.Run("http", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/proofs/3":
+				_, _ = w.Write([]byte(`{"newVerifiedBatch":"0x5","proof":"0x0102"}`))
+			case "/proofs/4":
+				http.Error(w, "prover down", http.StatusBadGateway)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		t.Cleanup(server.Close)
+
+		source, err := NewProofSource(config.RelayerConfig{ProofSource: config.ProofSourceHTTP, ProofURL: server.URL + "/proofs/"})
+		require.NoError(t, err)
+
+		proof, err := source.Proof(context.Background(), 3)
+		require.NoError(t, err)
+		require.Equal(t, expected, proof)
+
+		_, err = source.Proof(context.Background(), 4)
+		require.ErrorContains(t, err, "502 - prover down")
+
+		_, err = source.Proof(context.Background(), 5)
+		require.ErrorIs(t, err, ErrProofNotFound)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewProofSource(config.RelayerConfig{ProofSource: config.ProofSourceHTTP})
+		require.ErrorContains(t, err, "ProofURL is required")
+
+		_, err = NewProofSource(config.RelayerConfig{ProofSource: "s3"})
+		require.ErrorContains(t, err, `unknown proof source "s3"`)
+	})
+}
+
+func TestState(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "relayer.json")
+
+	_, found, err := loadState(path)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	state := State{
+		LastVerifiedBatch: 3,
+		Pending: &PendingTx{
+			Hash:             common.HexToHash("0x1"),
+			NewVerifiedBatch: 5,
+			SentAt:           time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		Rejected: &RejectedTx{
+			Hash:              common.HexToHash("0x2"),
+			LastVerifiedBatch: 3,
+			NewVerifiedBatch:  5,
+			Reason:            "invalid proof",
+			Rejections:        2,
+			RejectedAt:        time.Date(2024, 1, 2, 3, 4, 0, 0, time.UTC),
+		},
+	}
+	require.NoError(t, saveState(path, state))
+
+	loaded, found, err := loadState(path)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, state, loaded)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
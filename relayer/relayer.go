@@ -0,0 +1,337 @@
+// Package relayer submits the proofs of a rollup to the agglayer: it watches the zkEVM node
+// of the rollup, fetches the proofs of its aggregator and sends the signed txs verifying them
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	client "github.com/0xPolygon/agglayer/client/v2"
+	"github.com/0xPolygon/agglayer/config"
+	rpcTypes "github.com/0xPolygon/agglayer/rpc/types"
+	"github.com/0xPolygon/agglayer/tx"
+	"github.com/0xPolygon/agglayer/types"
+)
+
+// maxBackoffShift caps the backoff between the attempts to send a rejected batch range again
+const maxBackoffShift = 10
+
+// ErrRangeRejected when a batch range was rejected MaxRejections times in a row, it isn't sent
+// again until L1 moves past it
+var ErrRangeRejected = errors.New("batch range rejected too many times")
+
+// Signer signs the typed data hash of the txs, with a keystore or a KMS key
+type Signer interface {
+	SignHash(ctx context.Context, hash common.Hash) ([]byte, error)
+}
+
+// Relayer sends a tx for each proof of the rollup, one at a time as the agglayer rejects
+// overlapping batch ranges, and persists its progress to resume where it stopped
+type Relayer struct {
+	logger   *zap.SugaredLogger
+	cfg      config.RelayerConfig
+	domain   tx.SigningDomain
+	node     types.IZkEVMClient
+	agglayer client.ClientInterface
+	proofs   ProofSource
+	signer   Signer
+
+	state  State
+	loaded bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// New returns a relayer of the rollup of the config, signing the txs for the domain
+func New(
+	logger *zap.SugaredLogger,
+	cfg config.RelayerConfig,
+	domain tx.SigningDomain,
+	node types.IZkEVMClient,
+	agglayer client.ClientInterface,
+	proofs ProofSource,
+	signer Signer,
+) *Relayer {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Relayer{
+		logger:   logger,
+		cfg:      cfg,
+		domain:   domain,
+		node:     node,
+		agglayer: agglayer,
+		proofs:   proofs,
+		signer:   signer,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start relays the proofs until the relayer is stopped
+func (r *Relayer) Start() {
+	for {
+		if err := r.relay(r.ctx); err != nil && r.ctx.Err() == nil {
+			r.logger.Errorf("failed to relay the proofs of rollup %d: %s", r.cfg.RollupID, err)
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-time.After(r.cfg.FrequencyToPoll.Duration):
+		}
+	}
+}
+
+// Stop stops relaying, a tx being sent is picked up again on the next start
+func (r *Relayer) Stop() {
+	r.cancel()
+}
+
+// relay follows the tx in flight until it's settled, then sends the tx of the next proof if the
+// zkEVM node has its batch
+func (r *Relayer) relay(ctx context.Context) error {
+	if err := r.load(ctx); err != nil {
+		return err
+	}
+
+	if r.state.Pending != nil {
+		settled, err := r.follow(ctx)
+		if err != nil || !settled {
+			return err
+		}
+	}
+
+	last := uint64(r.state.LastVerifiedBatch)
+
+	latest, err := r.node.BatchByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get the latest batch: %w", err)
+	}
+	if latest == nil || uint64(latest.Number) <= last {
+		return nil
+	}
+
+	proof, err := r.proofs.Proof(ctx, last)
+	if errors.Is(err, ErrProofNotFound) {
+		r.logger.Debugf("no proof following batch %d yet", last)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the proof following batch %d: %w", last, err)
+	}
+
+	newVerifiedBatch := uint64(proof.NewVerifiedBatch)
+	if newVerifiedBatch <= last {
+		return fmt.Errorf("the proof following batch %d verifies up to batch %d", last, newVerifiedBatch)
+	}
+	if newVerifiedBatch > uint64(latest.Number) {
+		r.logger.Debugf("the zkEVM node doesn't have batch %d yet", newVerifiedBatch)
+		return nil
+	}
+
+	if rejected := r.state.Rejected; rejected != nil && rejected.sameRange(last, newVerifiedBatch) {
+		if rejected.Rejections >= r.cfg.MaxRejections {
+			return r.escalate(ctx, rejected)
+		}
+
+		if wait := r.backoff(rejected.Rejections); time.Since(rejected.RejectedAt) < wait {
+			r.logger.Debugf("batches %d to %d were rejected %d times, waiting %s to send them again", last+1, newVerifiedBatch, rejected.Rejections, wait)
+			return nil
+		}
+	}
+
+	batch, err := r.node.BatchByNumber(ctx, new(big.Int).SetUint64(newVerifiedBatch))
+	if err != nil {
+		return fmt.Errorf("failed to get batch %d: %w", newVerifiedBatch, err)
+	}
+	if batch == nil {
+		return nil
+	}
+
+	t := tx.Tx{
+		RollupID:          r.cfg.RollupID,
+		LastVerifiedBatch: rpcTypes.ArgUint64(last),
+		NewVerifiedBatch:  rpcTypes.ArgUint64(newVerifiedBatch),
+		ZKP: tx.ZKP{
+			NewStateRoot:     batch.StateRoot,
+			NewLocalExitRoot: batch.LocalExitRoot,
+			Proof:            proof.Proof,
+		},
+	}
+
+	sig, err := r.signer.SignHash(ctx, t.TypedDataHash(r.domain))
+	if err != nil {
+		return fmt.Errorf("failed to sign tx: %w", err)
+	}
+
+	hash, err := r.agglayer.SendTx(ctx, tx.SignedTx{Tx: t, Signature: sig})
+	if err != nil {
+		return fmt.Errorf("failed to send the tx verifying batches %d to %d: %w", last+1, newVerifiedBatch, err)
+	}
+	r.logger.Infof("sent tx %s verifying batches %d to %d", hash.Hex(), last+1, newVerifiedBatch)
+	if rejected := r.state.Rejected; rejected != nil && rejected.Hash == hash {
+		r.logger.Warnf("tx %s was rejected %d times already: %s", hash.Hex(), rejected.Rejections, rejected.Reason)
+	}
+
+	r.state.Pending = &PendingTx{
+		Hash:             hash,
+		NewVerifiedBatch: rpcTypes.ArgUint64(newVerifiedBatch),
+		SentAt:           time.Now().UTC(),
+	}
+
+	return r.save()
+}
+
+// follow checks the status of the tx in flight, it returns whether the relayer can move on
+// to the next proof. A tx rejected or failing on L1 is recorded with its reason and sent
+// again from the last batch settled, backing off if its batch range is the same
+func (r *Relayer) follow(ctx context.Context) (bool, error) {
+	pending := r.state.Pending
+
+	status, err := r.agglayer.GetTxStatus(ctx, pending.Hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the status of tx %s: %w", pending.Hash.Hex(), err)
+	}
+
+	switch status.Outcome {
+	case types.TxOutcomeSettled:
+		r.logger.Infof("tx %s settled batch %d", pending.Hash.Hex(), pending.NewVerifiedBatch)
+		r.state.LastVerifiedBatch = pending.NewVerifiedBatch
+		r.state.Pending = nil
+		r.state.Rejected = nil
+
+		return true, r.save()
+
+	case types.TxOutcomeRejected, types.TxOutcomeFailed:
+		rejected := &RejectedTx{
+			Hash:              pending.Hash,
+			LastVerifiedBatch: r.state.LastVerifiedBatch,
+			NewVerifiedBatch:  pending.NewVerifiedBatch,
+			Reason:            r.reason(ctx, pending.Hash, status.Status),
+			Rejections:        1,
+			RejectedAt:        time.Now().UTC(),
+		}
+		if previous := r.state.Rejected; previous != nil && previous.sameRange(uint64(rejected.LastVerifiedBatch), uint64(rejected.NewVerifiedBatch)) {
+			rejected.Rejections = previous.Rejections + 1
+		}
+		r.logger.Warnf("tx %s is %s (%s), resuming from the last batch settled", pending.Hash.Hex(), status.Status, rejected.Reason)
+
+		lastSettled, err := r.lastSettled(ctx)
+		if err != nil {
+			return false, err
+		}
+		r.state = State{LastVerifiedBatch: rpcTypes.ArgUint64(lastSettled), Rejected: rejected}
+
+		return true, r.save()
+
+	default:
+		return false, nil
+	}
+}
+
+// reason returns why the tx was rejected, its status if the agglayer doesn't tell
+func (r *Relayer) reason(ctx context.Context, hash common.Hash, status string) string {
+	details, err := r.agglayer.GetTxDetails(ctx, hash)
+	if err != nil {
+		r.logger.Debugf("failed to get the details of tx %s: %s", hash.Hex(), err)
+		return status
+	}
+	if details.Error == "" {
+		return status
+	}
+
+	return details.Error
+}
+
+// backoff returns how long to wait before sending again a batch range rejected the given number of times
+func (r *Relayer) backoff(rejections uint64) time.Duration {
+	shift := rejections
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+
+	return r.cfg.FrequencyToPoll.Duration << shift
+}
+
+// escalate stops sending a batch range rejected too many times, unless L1 moved past it meanwhile
+func (r *Relayer) escalate(ctx context.Context, rejected *RejectedTx) error {
+	lastSettled, err := r.lastSettled(ctx)
+	if err != nil {
+		return err
+	}
+
+	if lastSettled != uint64(rejected.LastVerifiedBatch) {
+		r.logger.Infof("L1 moved to batch %d, resuming after batches %d to %d were rejected", lastSettled, rejected.LastVerifiedBatch+1, rejected.NewVerifiedBatch)
+		r.state = State{LastVerifiedBatch: rpcTypes.ArgUint64(lastSettled)}
+
+		return r.save()
+	}
+
+	return fmt.Errorf("%w: batches %d to %d were rejected %d times, last by tx %s: %s",
+		ErrRangeRejected, rejected.LastVerifiedBatch+1, rejected.NewVerifiedBatch, rejected.Rejections, rejected.Hash.Hex(), rejected.Reason)
+}
+
+// load reads the persisted state once, starting from the last batch settled on L1 without one
+func (r *Relayer) load(ctx context.Context) error {
+	if r.loaded {
+		return nil
+	}
+
+	state, found, err := loadState(r.cfg.StatePath)
+	if err != nil {
+		return fmt.Errorf("failed to load the state from %s: %w", r.cfg.StatePath, err)
+	}
+
+	if found {
+		r.state = state
+	} else {
+		if err := r.sync(ctx); err != nil {
+			return err
+		}
+		if err := r.save(); err != nil {
+			return err
+		}
+	}
+	r.loaded = true
+
+	r.logger.Infof("relaying the proofs of rollup %d from batch %d", r.cfg.RollupID, r.state.LastVerifiedBatch)
+
+	return nil
+}
+
+// sync drops the tx in flight and resumes from the last batch verified on L1
+func (r *Relayer) sync(ctx context.Context) error {
+	lastSettled, err := r.lastSettled(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.state = State{LastVerifiedBatch: rpcTypes.ArgUint64(lastSettled)}
+
+	return nil
+}
+
+// lastSettled returns the last batch of the rollup verified on L1
+func (r *Relayer) lastSettled(ctx context.Context) (uint64, error) {
+	rollupState, err := r.agglayer.GetRollupState(ctx, r.cfg.RollupID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the state of rollup %d: %w", r.cfg.RollupID, err)
+	}
+
+	return uint64(rollupState.L1.LastVerifiedBatch), nil
+}
+
+func (r *Relayer) save() error {
+	if err := saveState(r.cfg.StatePath, r.state); err != nil {
+		return fmt.Errorf("failed to save the state to %s: %w", r.cfg.StatePath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,287 @@
+package relayer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	rpctypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	client "github.com/0xPolygon/agglayer/client/v2"
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	"github.com/0xPolygon/agglayer/tx"
+	"github.com/0xPolygon/agglayer/types"
+)
+
+// agglayer records the txs sent and answers with the statuses and rollup state set by the test
+type agglayer struct {
+	client.ClientInterface
+
+	lastVerifiedBatch uint64
+	statuses          map[common.Hash]string
+	reasons           map[common.Hash]string
+	sent              []tx.SignedTx
+}
+
+func (a *agglayer) SendTx(ctx context.Context, signedTx tx.SignedTx) (common.Hash, error) {
+	a.sent = append(a.sent, signedTx)
+
+	return signedTx.Tx.Hash(), nil
+}
+
+func (a *agglayer) GetTxStatus(ctx context.Context, hash common.Hash) (client.TxStatus, error) {
+	status, ok := a.statuses[hash]
+	if !ok {
+		return client.TxStatus{}, errors.New("not found")
+	}
+
+	outcome := types.TxOutcomePending
+	switch status {
+	case "rejected":
+		outcome = types.TxOutcomeRejected
+	case "confirmed", "done":
+		outcome = types.TxOutcomeSettled
+	case "failed":
+		outcome = types.TxOutcomeFailed
+	}
+
+	return client.TxStatus{Hash: hash, Status: status, Outcome: outcome}, nil
+}
+
+func (a *agglayer) GetTxDetails(ctx context.Context, hash common.Hash) (types.TxDetails, error) {
+	reason, ok := a.reasons[hash]
+	if !ok {
+		return types.TxDetails{}, errors.New("not found")
+	}
+
+	return types.TxDetails{Hash: hash, Error: reason}, nil
+}
+
+func (a *agglayer) GetRollupState(ctx context.Context, rollupID uint32) (types.RollupState, error) {
+	state := types.RollupState{RollupID: rollupID}
+	state.L1.LastVerifiedBatch = hexutil.Uint64(a.lastVerifiedBatch)
+
+	return state, nil
+}
+
+// proofs returns the proofs keyed by the last verified batch
+type proofs map[uint64]Proof
+
+func (p proofs) Proof(ctx context.Context, lastVerifiedBatch uint64) (Proof, error) {
+	proof, ok := p[lastVerifiedBatch]
+	if !ok {
+		return Proof{}, ErrProofNotFound
+	}
+
+	return proof, nil
+}
+
+type signer struct{}
+
+func (signer) SignHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	key, err := crypto.HexToECDSA("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Sign(hash[:], key)
+}
+
+func TestRelayer(t *testing.T) {
+	t.Parallel()
+
+	domain := tx.SigningDomain{L1ChainID: 1337, RollupManagerContract: common.HexToAddress("0xrollupmanager")}
+
+	batch := func(number uint64) *rpctypes.Batch {
+		return &rpctypes.Batch{
+			Number:        rpctypes.ArgUint64(number),
+			StateRoot:     common.BigToHash(new(big.Int).SetUint64(number)),
+			LocalExitRoot: common.HexToHash("0xlocalexitroot"),
+		}
+	}
+
+	newRelayer := func(t *testing.T, agglayer *agglayer, proofs proofs) (*Relayer, *mocks.ZkEVMClientMock) {
+		t.Helper()
+
+		cfg := config.RelayerConfig{RollupID: 1, StatePath: filepath.Join(t.TempDir(), "relayer.json"), MaxRejections: 2}
+		node := mocks.NewZkEVMClientMock(t)
+
+		return New(log.WithFields("test", "test"), cfg, domain, node, agglayer, proofs, signer{}), node
+	}
+
+	t.Run("sends the proofs one at a time and resumes after a restart", func(t *testing.T) {
+		t.Parallel()
+
+		agg := &agglayer{lastVerifiedBatch: 2, statuses: map[common.Hash]string{}}
+		r, node := newRelayer(t, agg, proofs{
+			2: {NewVerifiedBatch: 4, Proof: []byte{0x01}},
+			4: {NewVerifiedBatch: 6, Proof: []byte{0x02}},
+		})
+		node.On("BatchByNumber", mock.Anything, (*big.Int)(nil)).Return(batch(6), nil)
+		node.On("BatchByNumber", mock.Anything, big.NewInt(4)).Return(batch(4), nil).Once()
+		node.On("BatchByNumber", mock.Anything, big.NewInt(6)).Return(batch(6), nil).Once()
+
+		require.NoError(t, r.relay(context.Background()))
+		require.Len(t, agg.sent, 1)
+
+		first := agg.sent[0]
+		require.Equal(t, uint32(1), first.Tx.RollupID)
+		require.EqualValues(t, 2, first.Tx.LastVerifiedBatch)
+		require.EqualValues(t, 4, first.Tx.NewVerifiedBatch)
+		require.Equal(t, batch(4).StateRoot, first.Tx.ZKP.NewStateRoot)
+		require.Equal(t, batch(4).LocalExitRoot, first.Tx.ZKP.NewLocalExitRoot)
+
+		signerAddr, err := first.TypedDataSigner(domain)
+		require.NoError(t, err)
+		require.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), signerAddr)
+
+		// nothing is sent while the tx is in flight
+		agg.statuses[first.Tx.Hash()] = "sent"
+		require.NoError(t, r.relay(context.Background()))
+		require.Len(t, agg.sent, 1)
+
+		// a new relayer resumes from the persisted state
+		agg.statuses[first.Tx.Hash()] = "confirmed"
+		restarted := New(r.logger, r.cfg, domain, node, agg, r.proofs, signer{})
+		require.NoError(t, restarted.relay(context.Background()))
+		require.Len(t, agg.sent, 2)
+		require.EqualValues(t, 4, agg.sent[1].Tx.LastVerifiedBatch)
+		require.EqualValues(t, 6, agg.sent[1].Tx.NewVerifiedBatch)
+
+		state, found, err := loadState(r.cfg.StatePath)
+		require.NoError(t, err)
+		require.True(t, found)
+		require.EqualValues(t, 4, state.LastVerifiedBatch)
+		require.Equal(t, agg.sent[1].Tx.Hash(), state.Pending.Hash)
+	})
+
+	t.Run("waits for the proof and the batch", func(t *testing.T) {
+		t.Parallel()
+
+		agg := &agglayer{lastVerifiedBatch: 2}
+		r, node := newRelayer(t, agg, proofs{2: {NewVerifiedBatch: 4}})
+
+		node.On("BatchByNumber", mock.Anything, (*big.Int)(nil)).Return(batch(2), nil).Once()
+		require.NoError(t, r.relay(context.Background()))
+
+		node.On("BatchByNumber", mock.Anything, (*big.Int)(nil)).Return(batch(3), nil).Once()
+		require.NoError(t, r.relay(context.Background()))
+
+		require.Empty(t, agg.sent)
+	})
+
+	t.Run("resyncs with L1 after a rejected tx", func(t *testing.T) {
+		t.Parallel()
+
+		agg := &agglayer{lastVerifiedBatch: 2, statuses: map[common.Hash]string{}}
+		r, node := newRelayer(t, agg, proofs{
+			2: {NewVerifiedBatch: 4},
+			3: {NewVerifiedBatch: 5},
+		})
+		node.On("BatchByNumber", mock.Anything, (*big.Int)(nil)).Return(batch(5), nil)
+		node.On("BatchByNumber", mock.Anything, big.NewInt(4)).Return(batch(4), nil).Once()
+		node.On("BatchByNumber", mock.Anything, big.NewInt(5)).Return(batch(5), nil).Once()
+
+		require.NoError(t, r.relay(context.Background()))
+		require.Len(t, agg.sent, 1)
+
+		// another submitter settled batch 3 meanwhile
+		agg.statuses[agg.sent[0].Tx.Hash()] = "rejected"
+		agg.lastVerifiedBatch = 3
+
+		require.NoError(t, r.relay(context.Background()))
+		require.Len(t, agg.sent, 2)
+		require.EqualValues(t, 3, agg.sent[1].Tx.LastVerifiedBatch)
+		require.EqualValues(t, 5, agg.sent[1].Tx.NewVerifiedBatch)
+	})
+
+	t.Run("backs off before sending a rejected range again", func(t *testing.T) {
+		t.Parallel()
+
+		agg := &agglayer{lastVerifiedBatch: 2, statuses: map[common.Hash]string{}, reasons: map[common.Hash]string{}}
+		r, node := newRelayer(t, agg, proofs{2: {NewVerifiedBatch: 4}})
+		r.cfg.FrequencyToPoll.Duration = time.Hour
+		node.On("BatchByNumber", mock.Anything, (*big.Int)(nil)).Return(batch(4), nil)
+		node.On("BatchByNumber", mock.Anything, big.NewInt(4)).Return(batch(4), nil).Once()
+
+		require.NoError(t, r.relay(context.Background()))
+		require.Len(t, agg.sent, 1)
+
+		hash := agg.sent[0].Tx.Hash()
+		agg.statuses[hash] = "rejected"
+		agg.reasons[hash] = "invalid proof"
+
+		require.NoError(t, r.relay(context.Background()))
+		require.Len(t, agg.sent, 1)
+
+		state, _, err := loadState(r.cfg.StatePath)
+		require.NoError(t, err)
+		require.Nil(t, state.Pending)
+		require.NotNil(t, state.Rejected)
+		require.Equal(t, hash, state.Rejected.Hash)
+		require.Equal(t, "invalid proof", state.Rejected.Reason)
+		require.EqualValues(t, 1, state.Rejected.Rejections)
+	})
+
+	t.Run("stops when the agglayer returns an already rejected tx", func(t *testing.T) {
+		t.Parallel()
+
+		agg := &agglayer{lastVerifiedBatch: 2, statuses: map[common.Hash]string{}, reasons: map[common.Hash]string{}}
+		r, node := newRelayer(t, agg, proofs{
+			2: {NewVerifiedBatch: 4},
+			3: {NewVerifiedBatch: 5},
+		})
+		node.On("BatchByNumber", mock.Anything, (*big.Int)(nil)).Return(batch(5), nil)
+		node.On("BatchByNumber", mock.Anything, big.NewInt(4)).Return(batch(4), nil).Twice()
+		node.On("BatchByNumber", mock.Anything, big.NewInt(5)).Return(batch(5), nil).Once()
+
+		require.NoError(t, r.relay(context.Background()))
+		require.Len(t, agg.sent, 1)
+
+		hash := agg.sent[0].Tx.Hash()
+		agg.statuses[hash] = "rejected"
+		agg.reasons[hash] = "invalid proof"
+
+		// the same tx is sent again and the agglayer returns the hash it rejected
+		require.NoError(t, r.relay(context.Background()))
+		require.Len(t, agg.sent, 2)
+		require.Equal(t, hash, agg.sent[1].Tx.Hash())
+
+		// rejected again, the range isn't sent anymore
+		for i := 0; i < 2; i++ {
+			err := r.relay(context.Background())
+			require.ErrorIs(t, err, ErrRangeRejected)
+			require.ErrorContains(t, err, "batches 3 to 4 were rejected 2 times")
+			require.ErrorContains(t, err, "invalid proof")
+			require.Len(t, agg.sent, 2)
+		}
+
+		// another submitter settled batch 3 meanwhile
+		agg.lastVerifiedBatch = 3
+		require.NoError(t, r.relay(context.Background()))
+		require.NoError(t, r.relay(context.Background()))
+		require.Len(t, agg.sent, 3)
+		require.EqualValues(t, 3, agg.sent[2].Tx.LastVerifiedBatch)
+	})
+
+	t.Run("invalid proof", func(t *testing.T) {
+		t.Parallel()
+
+		agg := &agglayer{lastVerifiedBatch: 2}
+		r, node := newRelayer(t, agg, proofs{2: {NewVerifiedBatch: 2}})
+		node.On("BatchByNumber", mock.Anything, (*big.Int)(nil)).Return(batch(5), nil)
+
+		require.ErrorContains(t, r.relay(context.Background()), "the proof following batch 2 verifies up to batch 2")
+		require.Empty(t, agg.sent)
+	})
+}
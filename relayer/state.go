@@ -0,0 +1,95 @@
+package relayer
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	rpcTypes "github.com/0xPolygon/agglayer/rpc/types"
+)
+
+// State is the progress of the relayer, persisted after every change
+type State struct {
+	// LastVerifiedBatch is the last batch settled, the next tx verifies the batches following it
+	LastVerifiedBatch rpcTypes.ArgUint64 `json:"lastVerifiedBatch"`
+
+	// Pending is the tx sent and not settled yet
+	Pending *PendingTx `json:"pending,omitempty"`
+
+	// Rejected is the last tx rejected or failing on L1, until a tx is settled
+	Rejected *RejectedTx `json:"rejected,omitempty"`
+}
+
+// PendingTx is a tx sent to the agglayer that isn't settled yet
+type PendingTx struct {
+	Hash             common.Hash        `json:"hash"`
+	NewVerifiedBatch rpcTypes.ArgUint64 `json:"newVerifiedBatch"`
+	SentAt           time.Time          `json:"sentAt"`
+}
+
+// RejectedTx is the last tx rejected by the agglayer or failing on L1, with the number
+// of times its batch range was rejected in a row
+type RejectedTx struct {
+	Hash              common.Hash        `json:"hash"`
+	LastVerifiedBatch rpcTypes.ArgUint64 `json:"lastVerifiedBatch"`
+	NewVerifiedBatch  rpcTypes.ArgUint64 `json:"newVerifiedBatch"`
+	Reason            string             `json:"reason"`
+	Rejections        uint64             `json:"rejections"`
+	RejectedAt        time.Time          `json:"rejectedAt"`
+}
+
+// sameRange returns whether the tx verified the given batch range
+func (r *RejectedTx) sameRange(lastVerifiedBatch, newVerifiedBatch uint64) bool {
+	return uint64(r.LastVerifiedBatch) == lastVerifiedBatch && uint64(r.NewVerifiedBatch) == newVerifiedBatch
+}
+
+// loadState reads the state persisted at the path, it returns false if there's none
+func loadState(path string) (State, bool, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return State{}, false, nil
+	}
+	if err != nil {
+		return State{}, false, err
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, false, err
+	}
+
+	return state, true, nil
+}
+
+// saveState persists the state at the path, replacing the previous one atomically so a crash
+// never leaves a partial state behind
+func saveState(path string, state State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,440 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/jackc/pgx/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/0xPolygon/agglayer/config"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/0xPolygon/agglayer/types"
+)
+
+// ADMIN is the namespace of the admin service
+const ADMIN = "admin"
+
+// maxRequestSize bounds the body of the requests served by hand
+const maxRequestSize = 1 << 20
+
+var (
+	// ErrNoAdminAuth when the admin server has neither operator tokens nor a client CA to authenticate the requests
+	ErrNoAdminAuth = errors.New("admin server requires operator tokens or a client CA")
+	// ErrAdminClientCAWithoutTLS when the client certificates are required without serving over TLS
+	ErrAdminClientCAWithoutTLS = errors.New("admin client CA requires a TLS certificate and key")
+)
+
+// adminMethod performs an admin action persisting its changes with dbTx, returning the monitored tx it was performed on if any,
+// and the monitored tx whose status it changed, if any, to be notified once dbTx is committed
+type adminMethod func(ctx context.Context, params json.RawMessage, dbTx pgx.Tx) (txID string, result interface{}, changed *txmTypes.MonitoredTx, err error)
+
+// AdminServer serves the admin namespace operating the tx manager on its own listener.
+// Every action is authenticated and recorded in the audit table
+type AdminServer struct {
+	logger  *zap.SugaredLogger
+	cfg     config.AdminConfig
+	txMan   types.ITxManagerAdmin
+	db      types.IDB
+	meter   metric.Meter
+	methods map[string]adminMethod
+	tls     *tls.Config
+
+	mu  sync.Mutex
+	srv *http.Server
+}
+
+// NewAdminServer returns the admin server, failing if the requests can't be authenticated
+func NewAdminServer(logger *zap.SugaredLogger, cfg config.AdminConfig, txMan types.ITxManagerAdmin, db types.IDB) (*AdminServer, error) {
+	if len(cfg.Operators) == 0 && cfg.ClientCAFile == "" {
+		return nil, ErrNoAdminAuth
+	}
+
+	s := &AdminServer{
+		logger: logger,
+		cfg:    cfg,
+		txMan:  txMan,
+		db:     db,
+		meter:  otel.Meter(meterName),
+	}
+
+	if cfg.ClientCAFile != "" {
+		if cfg.TLSCertFile == "" || cfg.TLSKeyFile == "" {
+			return nil, ErrAdminClientCAWithoutTLS
+		}
+
+		pool, err := loadClientCAs(cfg.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		// the clients without a certificate can still authenticate with a token
+		s.tls = &tls.Config{
+			ClientCAs:  pool,
+			ClientAuth: tls.VerifyClientCertIfGiven,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	s.methods = map[string]adminMethod{
+		ADMIN + "_retryTx":         s.retryTx,
+		ADMIN + "_replaceTx":       s.replaceTx,
+		ADMIN + "_cancelTx":        s.cancelTx,
+		ADMIN + "_markTxDone":      s.markTxDone,
+		ADMIN + "_pauseTxManager":  s.pauseTxManager,
+		ADMIN + "_resumeTxManager": s.resumeTxManager,
+	}
+
+	return s, nil
+}
+
+// Start listens for admin requests until the server is stopped
+func (s *AdminServer) Start() error {
+	s.mu.Lock()
+	if s.srv != nil {
+		s.mu.Unlock()
+		return errors.New("admin server already started")
+	}
+
+	address := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("failed to create tcp listener: %w", err)
+	}
+
+	s.srv = &http.Server{
+		Handler:           s.mux(),
+		TLSConfig:         s.tls,
+		ReadHeaderTimeout: s.cfg.ReadTimeout.Duration,
+		ReadTimeout:       s.cfg.ReadTimeout.Duration,
+		WriteTimeout:      s.cfg.WriteTimeout.Duration,
+	}
+	srv := s.srv
+	s.mu.Unlock()
+
+	s.logger.Infof("admin server started: %s", address)
+	if s.cfg.TLSCertFile != "" {
+		err = srv.ServeTLS(lis, s.cfg.TLSCertFile, s.cfg.TLSKeyFile)
+	} else {
+		err = srv.Serve(lis)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Stop closes the listener, waiting for the requests in progress
+func (s *AdminServer) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.srv == nil {
+		return nil
+	}
+
+	err := s.srv.Shutdown(context.Background())
+	s.srv = nil
+
+	return err
+}
+
+func (s *AdminServer) mux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handle)
+
+	return mux
+}
+
+func (s *AdminServer) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	actor, ok := s.authenticate(r)
+	if !ok {
+		s.logger.Warnf("unauthenticated admin request from %s", r.RemoteAddr)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	serveRequest(w, r, s.logger, func(req jRPC.Request) (interface{}, jRPC.Error) {
+		return s.call(r.Context(), actor, r.RemoteAddr, req)
+	})
+}
+
+// serveRequest reads a single JSON-RPC request from the body and writes the response returned by call
+func serveRequest(w http.ResponseWriter, r *http.Request, logger *zap.SugaredLogger, call func(req jRPC.Request) (interface{}, jRPC.Error)) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	var (
+		req    jRPC.Request
+		result interface{}
+		rpcErr jRPC.Error
+	)
+	if err := json.Unmarshal(data, &req); err != nil {
+		rpcErr = jRPC.NewRPCError(jRPC.InvalidRequestErrorCode, "invalid json request")
+	} else {
+		result, rpcErr = call(req)
+	}
+
+	var res []byte
+	if rpcErr == nil {
+		if res, err = json.Marshal(result); err != nil {
+			rpcErr = jRPC.NewRPCError(jRPC.DefaultErrorCode, "failed to marshal result")
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(jRPC.NewResponse(req, res, rpcErr)); err != nil {
+		logger.Debugf("failed to write response: %s", err)
+	}
+}
+
+// authenticate returns the operator of the request, identified by its client certificate or its token
+func (s *AdminServer) authenticate(r *http.Request) (string, bool) {
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		return r.TLS.VerifiedChains[0][0].Subject.CommonName, true
+	}
+
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return "", false
+	}
+
+	for _, op := range s.cfg.Operators {
+		if op.Token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(op.Token)) == 1 {
+			return op.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// call performs the admin action and records it in the audit table within the same DB transaction,
+// an action that can't be recorded is rolled back. A failed action is recorded on its own
+func (s *AdminServer) call(ctx context.Context, actor, remoteAddr string, req jRPC.Request) (interface{}, jRPC.Error) {
+	method, ok := s.methods[req.Method]
+	if !ok {
+		return nil, jRPC.NewRPCError(jRPC.NotFoundErrorCode, fmt.Sprintf("the method %s does not exist/is not available", req.Method))
+	}
+
+	opts := metric.WithAttributes(attribute.Key("method").String(req.Method))
+	c, merr := s.meter.Int64Counter("admin_call")
+	if merr != nil {
+		s.logger.Warnf("failed to create admin_call counter: %s", merr)
+	}
+	c.Add(ctx, 1, opts)
+
+	dbTx, err := s.db.BeginStateTransaction(ctx)
+	if err != nil {
+		s.logger.Errorf("failed to begin dbTx for admin action %s by %s: %s", req.Method, actor, err)
+		return nil, jRPC.NewRPCError(jRPC.DefaultErrorCode, "failed to begin dbTx")
+	}
+
+	txID, result, changed, err := method(ctx, req.Params, dbTx)
+
+	entry := types.AdminAuditEntry{
+		Actor:         actor,
+		RemoteAddr:    remoteAddr,
+		Action:        req.Method,
+		MonitoredTxID: txID,
+		Params:        req.Params,
+	}
+
+	s.logger.Infof("admin action %s on %q by %s: %v", req.Method, txID, actor, err)
+	if err != nil {
+		if rbErr := dbTx.Rollback(ctx); rbErr != nil {
+			s.logger.Errorf("failed to rollback dbTx of admin action %s: %s", req.Method, rbErr)
+		}
+
+		entry.Error = err.Error()
+		if auditErr := s.db.AddAdminAuditEntry(ctx, entry, nil); auditErr != nil {
+			s.logger.Errorf("failed to record admin action %s by %s: %s", req.Method, actor, auditErr)
+		}
+	} else if auditErr := s.audit(ctx, entry, dbTx); auditErr != nil {
+		s.logger.Errorf("failed to record admin action %s by %s: %s", req.Method, actor, auditErr)
+		return nil, jRPC.NewRPCError(jRPC.DefaultErrorCode, fmt.Sprintf("action rolled back, it couldn't be recorded in the audit table: %s", auditErr))
+	} else if changed != nil {
+		s.txMan.NotifyStatusChange(*changed)
+	}
+
+	var paramsErr *paramsError
+	switch {
+	case err == nil:
+		return result, nil
+	case errors.As(err, &paramsErr):
+		return nil, jRPC.NewRPCError(jRPC.InvalidParamsErrorCode, err.Error())
+	case errors.Is(err, txmTypes.ErrNotFound):
+		return nil, jRPC.NewRPCError(jRPC.DefaultErrorCode, fmt.Sprintf("monitored tx %s not found", txID))
+	default:
+		return nil, jRPC.NewRPCError(jRPC.DefaultErrorCode, err.Error())
+	}
+}
+
+// audit records the action in the audit table and commits it along with the changes of the action
+func (s *AdminServer) audit(ctx context.Context, entry types.AdminAuditEntry, dbTx pgx.Tx) error {
+	if err := s.db.AddAdminAuditEntry(ctx, entry, dbTx); err != nil {
+		if rbErr := dbTx.Rollback(ctx); rbErr != nil {
+			s.logger.Errorf("failed to rollback dbTx of admin action %s: %s", entry.Action, rbErr)
+		}
+
+		return err
+	}
+
+	return dbTx.Commit(ctx)
+}
+
+func (s *AdminServer) retryTx(ctx context.Context, params json.RawMessage, dbTx pgx.Tx) (string, interface{}, *txmTypes.MonitoredTx, error) {
+	var hash common.Hash
+	if err := parseParams(params, &hash); err != nil {
+		return "", nil, nil, err
+	}
+
+	mTx, err := s.txMan.Retry(ctx, ethTxManOwner, hash.Hex(), dbTx)
+	if err != nil {
+		return hash.Hex(), nil, nil, err
+	}
+
+	return hash.Hex(), types.NewTxSummary(mTx), &mTx, nil
+}
+
+func (s *AdminServer) replaceTx(ctx context.Context, params json.RawMessage, dbTx pgx.Tx) (string, interface{}, *txmTypes.MonitoredTx, error) {
+	var (
+		hash     common.Hash
+		gasPrice hexutil.Big
+	)
+	if err := parseParams(params, &hash, &gasPrice); err != nil {
+		return "", nil, nil, err
+	}
+
+	mTx, err := s.txMan.Replace(ctx, ethTxManOwner, hash.Hex(), (*big.Int)(&gasPrice), dbTx)
+	if err != nil {
+		return hash.Hex(), nil, nil, err
+	}
+
+	// a replacement doesn't change the status of the tx
+	return hash.Hex(), types.NewTxSummary(mTx), nil, nil
+}
+
+func (s *AdminServer) cancelTx(ctx context.Context, params json.RawMessage, dbTx pgx.Tx) (string, interface{}, *txmTypes.MonitoredTx, error) {
+	var (
+		hash     common.Hash
+		gasPrice hexutil.Big
+	)
+	if err := parseParams(params, &hash, &gasPrice); err != nil {
+		return "", nil, nil, err
+	}
+
+	mTx, err := s.txMan.Cancel(ctx, ethTxManOwner, hash.Hex(), (*big.Int)(&gasPrice), dbTx)
+	if err != nil {
+		return hash.Hex(), nil, nil, err
+	}
+
+	return hash.Hex(), types.NewTxSummary(mTx), &mTx, nil
+}
+
+func (s *AdminServer) markTxDone(ctx context.Context, params json.RawMessage, dbTx pgx.Tx) (string, interface{}, *txmTypes.MonitoredTx, error) {
+	var hash common.Hash
+	if err := parseParams(params, &hash); err != nil {
+		return "", nil, nil, err
+	}
+
+	mTx, err := s.txMan.MarkDone(ctx, ethTxManOwner, hash.Hex(), dbTx)
+	if err != nil {
+		return hash.Hex(), nil, nil, err
+	}
+
+	return hash.Hex(), types.NewTxSummary(mTx), &mTx, nil
+}
+
+func (s *AdminServer) pauseTxManager(_ context.Context, params json.RawMessage, _ pgx.Tx) (string, interface{}, *txmTypes.MonitoredTx, error) {
+	if err := parseParams(params); err != nil {
+		return "", nil, nil, err
+	}
+
+	s.txMan.Pause()
+
+	return "", s.txMan.Paused(), nil, nil
+}
+
+func (s *AdminServer) resumeTxManager(_ context.Context, params json.RawMessage, _ pgx.Tx) (string, interface{}, *txmTypes.MonitoredTx, error) {
+	if err := parseParams(params); err != nil {
+		return "", nil, nil, err
+	}
+
+	s.txMan.Resume()
+
+	return "", s.txMan.Paused(), nil, nil
+}
+
+// paramsError when the params of a request served by hand can't be parsed
+type paramsError struct {
+	msg string
+}
+
+func (e *paramsError) Error() string {
+	return e.msg
+}
+
+// loadClientCAs reads the PEM certificates verifying the client certificates
+func loadClientCAs(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in client CA %s", file)
+	}
+
+	return pool, nil
+}
+
+// parseParams decodes the positional params of a request served by hand into the given values
+func parseParams(params json.RawMessage, values ...interface{}) error {
+	var raw []json.RawMessage
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &raw); err != nil {
+			return &paramsError{msg: "invalid params, expected an array"}
+		}
+	}
+
+	if len(raw) != len(values) {
+		return &paramsError{msg: fmt.Sprintf("invalid params, expected %d and got %d", len(values), len(raw))}
+	}
+
+	for i, v := range values {
+		if err := json.Unmarshal(raw[i], v); err != nil {
+			return &paramsError{msg: fmt.Sprintf("invalid param %d: %s", i, err)}
+		}
+	}
+
+	return nil
+}
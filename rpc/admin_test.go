@@ -0,0 +1,217 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	aggTypes "github.com/0xPolygon/agglayer/types"
+)
+
+func TestNewAdminServer(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewAdminServer(log.WithFields("module", "test"), config.AdminConfig{}, nil, nil)
+	require.ErrorIs(t, err, ErrNoAdminAuth)
+
+	_, err = NewAdminServer(log.WithFields("module", "test"), config.AdminConfig{ClientCAFile: "ca.pem"}, nil, nil)
+	require.ErrorIs(t, err, ErrAdminClientCAWithoutTLS)
+}
+
+func TestAdminServer(t *testing.T) {
+	t.Parallel()
+
+	const token = "secret"
+
+	txHash := common.HexToHash("0x1")
+
+	newServer := func(t *testing.T) (*mocks.TxManagerAdminMock, *mocks.DBMock, *mocks.TxMock, func(token, method string, params ...interface{}) (int, jRPC.Response)) {
+		t.Helper()
+
+		txManMock := mocks.NewTxManagerAdminMock(t)
+		dbMock := mocks.NewDBMock(t)
+		txMock := new(mocks.TxMock)
+		t.Cleanup(func() { txMock.AssertExpectations(t) })
+
+		s, err := NewAdminServer(log.WithFields("module", "test"), config.AdminConfig{
+			Operators: []config.AdminOperator{{Name: "alice", Token: token}},
+		}, txManMock, dbMock)
+		require.NoError(t, err)
+
+		srv := httptest.NewServer(s.mux())
+		t.Cleanup(srv.Close)
+
+		call := func(token, method string, params ...interface{}) (int, jRPC.Response) {
+			t.Helper()
+
+			if params == nil {
+				params = []interface{}{}
+			}
+			body, err := json.Marshal(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      1,
+				"method":  method,
+				"params":  params,
+			})
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, srv.URL, bytes.NewReader(body))
+			require.NoError(t, err)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+
+			httpRes, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer httpRes.Body.Close()
+
+			var res jRPC.Response
+			if httpRes.StatusCode == http.StatusOK {
+				require.NoError(t, json.NewDecoder(httpRes.Body).Decode(&res))
+			}
+
+			return httpRes.StatusCode, res
+		}
+
+		return txManMock, dbMock, txMock, call
+	}
+
+	t.Run("rejects unauthenticated requests", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, _, call := newServer(t)
+
+		status, _ := call("", "admin_pauseTxManager")
+		require.Equal(t, http.StatusUnauthorized, status)
+
+		status, _ = call("wrong", "admin_pauseTxManager")
+		require.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("retries a tx and audits the action", func(t *testing.T) {
+		t.Parallel()
+
+		txManMock, dbMock, txMock, call := newServer(t)
+
+		dbMock.EXPECT().BeginStateTransaction(mock.Anything).Return(txMock, nil).Once()
+		txManMock.EXPECT().Retry(mock.Anything, ethTxManOwner, txHash.Hex(), txMock).
+			Return(txmTypes.MonitoredTx{ID: txHash.Hex(), Status: txmTypes.MonitoredTxStatusSent}, nil).Once()
+		dbMock.EXPECT().AddAdminAuditEntry(mock.Anything, mock.MatchedBy(func(entry aggTypes.AdminAuditEntry) bool {
+			return entry.Actor == "alice" && entry.Action == "admin_retryTx" && entry.MonitoredTxID == txHash.Hex() && entry.Error == ""
+		}), txMock).Return(nil).Once()
+		commit := txMock.On("Commit", mock.Anything).Return(nil).Once()
+		// the new status is only pushed to the subscribers once committed
+		txManMock.EXPECT().NotifyStatusChange(mock.MatchedBy(func(mTx txmTypes.MonitoredTx) bool {
+			return mTx.ID == txHash.Hex() && mTx.Status == txmTypes.MonitoredTxStatusSent
+		})).NotBefore(commit).Once()
+
+		status, res := call(token, "admin_retryTx", txHash)
+		require.Equal(t, http.StatusOK, status)
+		require.Nil(t, res.Error)
+
+		var summary aggTypes.TxSummary
+		require.NoError(t, json.Unmarshal(res.Result, &summary))
+		require.Equal(t, txHash, summary.Hash)
+		require.Equal(t, txmTypes.MonitoredTxStatusSent.String(), summary.Status)
+	})
+
+	t.Run("audits the failed actions", func(t *testing.T) {
+		t.Parallel()
+
+		txManMock, dbMock, txMock, call := newServer(t)
+
+		gasPrice := big.NewInt(10)
+		dbMock.EXPECT().BeginStateTransaction(mock.Anything).Return(txMock, nil).Once()
+		txManMock.EXPECT().Replace(mock.Anything, ethTxManOwner, txHash.Hex(), gasPrice, txMock).
+			Return(txmTypes.MonitoredTx{}, txmTypes.ErrGasPriceTooLow).Once()
+		txMock.On("Rollback", mock.Anything).Return(nil).Once()
+		dbMock.EXPECT().AddAdminAuditEntry(mock.Anything, mock.MatchedBy(func(entry aggTypes.AdminAuditEntry) bool {
+			return entry.Action == "admin_replaceTx" && entry.Error == txmTypes.ErrGasPriceTooLow.Error()
+		}), nil).Return(nil).Once()
+
+		status, res := call(token, "admin_replaceTx", txHash, "0xa")
+		require.Equal(t, http.StatusOK, status)
+		require.NotNil(t, res.Error)
+		require.Equal(t, jRPC.DefaultErrorCode, res.Error.Code)
+	})
+
+	t.Run("rejects invalid params", func(t *testing.T) {
+		t.Parallel()
+
+		_, dbMock, txMock, call := newServer(t)
+
+		dbMock.EXPECT().BeginStateTransaction(mock.Anything).Return(txMock, nil).Once()
+		txMock.On("Rollback", mock.Anything).Return(nil).Once()
+		dbMock.EXPECT().AddAdminAuditEntry(mock.Anything, mock.Anything, nil).Return(nil).Once()
+
+		status, res := call(token, "admin_cancelTx", txHash)
+		require.Equal(t, http.StatusOK, status)
+		require.NotNil(t, res.Error)
+		require.Equal(t, jRPC.InvalidParamsErrorCode, res.Error.Code)
+	})
+
+	t.Run("rolls back an action it failed to audit", func(t *testing.T) {
+		t.Parallel()
+
+		txManMock, dbMock, txMock, call := newServer(t)
+
+		dbMock.EXPECT().BeginStateTransaction(mock.Anything).Return(txMock, nil).Once()
+		txManMock.EXPECT().MarkDone(mock.Anything, ethTxManOwner, txHash.Hex(), txMock).
+			Return(txmTypes.MonitoredTx{ID: txHash.Hex(), Status: txmTypes.MonitoredTxStatusDone}, nil).Once()
+		dbMock.EXPECT().AddAdminAuditEntry(mock.Anything, mock.Anything, txMock).Return(errors.New("db down")).Once()
+		txMock.On("Rollback", mock.Anything).Return(nil).Once()
+
+		status, res := call(token, "admin_markTxDone", txHash)
+		require.Equal(t, http.StatusOK, status)
+		require.NotNil(t, res.Error)
+		require.Contains(t, res.Error.Message, "action rolled back")
+		txManMock.AssertNotCalled(t, "NotifyStatusChange", mock.Anything)
+	})
+
+	t.Run("db unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		_, dbMock, _, call := newServer(t)
+
+		dbMock.EXPECT().BeginStateTransaction(mock.Anything).Return(nil, errors.New("db down")).Once()
+
+		status, res := call(token, "admin_retryTx", txHash)
+		require.Equal(t, http.StatusOK, status)
+		require.NotNil(t, res.Error)
+	})
+
+	t.Run("pauses and resumes the tx manager", func(t *testing.T) {
+		t.Parallel()
+
+		txManMock, dbMock, txMock, call := newServer(t)
+
+		txManMock.EXPECT().Pause().Once()
+		txManMock.EXPECT().Resume().Once()
+		txManMock.EXPECT().Paused().Return(true).Once()
+		txManMock.EXPECT().Paused().Return(false).Once()
+		dbMock.EXPECT().BeginStateTransaction(mock.Anything).Return(txMock, nil).Twice()
+		dbMock.EXPECT().AddAdminAuditEntry(mock.Anything, mock.Anything, txMock).Return(nil).Twice()
+		txMock.On("Commit", mock.Anything).Return(nil).Twice()
+
+		_, res := call(token, "admin_pauseTxManager")
+		require.Nil(t, res.Error)
+		require.Equal(t, json.RawMessage("true"), res.Result)
+
+		_, res = call(token, "admin_resumeTxManager")
+		require.Nil(t, res.Error)
+		require.Equal(t, json.RawMessage("false"), res.Result)
+	})
+}
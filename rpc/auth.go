@@ -0,0 +1,202 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
+	"go.uber.org/zap"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/tx"
+)
+
+var (
+	// ErrUnauthenticated when a tx is sent without a valid API key or client certificate
+	ErrUnauthenticated = errors.New("missing or invalid credentials")
+	// ErrRollupNotAllowed when the credentials of the caller aren't scoped to the rollup of the tx
+	ErrRollupNotAllowed = errors.New("credentials not allowed to send txs for the rollup")
+	// ErrClientCertsWithoutCA when the client certificates can't be verified
+	ErrClientCertsWithoutCA = errors.New("client certificates require a client CA")
+)
+
+// sendTxAuth authorizes the callers of interop_sendTx by their API key or client certificate
+type sendTxAuth struct {
+	cfg config.AuthConfig
+}
+
+func newSendTxAuth(cfg config.AuthConfig) *sendTxAuth {
+	return &sendTxAuth{cfg: cfg}
+}
+
+// authorize returns an error unless the request carries a credential scoped to the rollup
+func (a *sendTxAuth) authorize(r *http.Request, rollupID uint32) error {
+	if !a.cfg.Enabled {
+		return nil
+	}
+
+	if r == nil {
+		return ErrUnauthenticated
+	}
+
+	authenticated := false
+
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		commonName := r.TLS.VerifiedChains[0][0].Subject.CommonName
+		for _, cert := range a.cfg.ClientCerts {
+			if cert.CommonName != commonName {
+				continue
+			}
+			authenticated = true
+			if cert.RollupID == rollupID {
+				return nil
+			}
+		}
+	}
+
+	if key, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found && key != "" {
+		for _, apiKey := range a.cfg.APIKeys {
+			if apiKey.Key == "" || subtle.ConstantTimeCompare([]byte(key), []byte(apiKey.Key)) != 1 {
+				continue
+			}
+			authenticated = true
+			if apiKey.RollupID == rollupID {
+				return nil
+			}
+		}
+	}
+
+	if !authenticated {
+		return ErrUnauthenticated
+	}
+
+	return fmt.Errorf("%w %d", ErrRollupNotAllowed, rollupID)
+}
+
+// SendTxTLSServer serves interop_sendTx and interop_simulateTx over TLS on its own listener, so
+// the rollups can authenticate with a client certificate. The RPC server doesn't support TLS
+type SendTxTLSServer struct {
+	logger    *zap.SugaredLogger
+	cfg       config.AuthConfig
+	endpoints *InteropEndpoints
+	tls       *tls.Config
+
+	mu  sync.Mutex
+	srv *http.Server
+}
+
+// NewSendTxTLSServer returns the TLS server of interop_sendTx
+func NewSendTxTLSServer(logger *zap.SugaredLogger, cfg config.AuthConfig, endpoints *InteropEndpoints) (*SendTxTLSServer, error) {
+	s := &SendTxTLSServer{
+		logger:    logger,
+		cfg:       cfg,
+		endpoints: endpoints,
+		tls:       &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+
+	if cfg.ClientCAFile == "" {
+		if len(cfg.ClientCerts) > 0 {
+			return nil, ErrClientCertsWithoutCA
+		}
+
+		return s, nil
+	}
+
+	pool, err := loadClientCAs(cfg.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// the clients without a certificate can still authenticate with an API key
+	s.tls.ClientCAs = pool
+	s.tls.ClientAuth = tls.VerifyClientCertIfGiven
+
+	return s, nil
+}
+
+// Start listens for txs over TLS until the server is stopped
+func (s *SendTxTLSServer) Start() error {
+	s.mu.Lock()
+	if s.srv != nil {
+		s.mu.Unlock()
+		return errors.New("tls server already started")
+	}
+
+	address := fmt.Sprintf("%s:%d", s.cfg.TLSHost, s.cfg.TLSPort)
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("failed to create tcp listener: %w", err)
+	}
+
+	rpcCfg := s.endpoints.config.RPC
+	s.srv = &http.Server{
+		Handler:           s.mux(),
+		TLSConfig:         s.tls,
+		ReadHeaderTimeout: rpcCfg.ReadTimeout.Duration,
+		ReadTimeout:       rpcCfg.ReadTimeout.Duration,
+		WriteTimeout:      rpcCfg.WriteTimeout.Duration,
+	}
+	srv := s.srv
+	s.mu.Unlock()
+
+	s.logger.Infof("tls server started: %s", address)
+	if err := srv.ServeTLS(lis, s.cfg.TLSCertFile, s.cfg.TLSKeyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Stop closes the listener, waiting for the requests in progress
+func (s *SendTxTLSServer) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.srv == nil {
+		return nil
+	}
+
+	err := s.srv.Shutdown(context.Background())
+	s.srv = nil
+
+	return err
+}
+
+func (s *SendTxTLSServer) mux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handle)
+
+	return mux
+}
+
+func (s *SendTxTLSServer) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, fmt.Sprintf("method %s not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	serveRequest(w, r, s.logger, func(req jRPC.Request) (interface{}, jRPC.Error) {
+		if req.Method != INTEROP+"_sendTx" && req.Method != INTEROP+"_simulateTx" {
+			return nil, jRPC.NewRPCError(jRPC.NotFoundErrorCode, fmt.Sprintf("the method %s does not exist/is not available", req.Method))
+		}
+
+		var signedTx tx.SignedTx
+		if err := parseParams(req.Params, &signedTx); err != nil {
+			return nil, jRPC.NewRPCError(jRPC.InvalidParamsErrorCode, err.Error())
+		}
+
+		if req.Method == INTEROP+"_simulateTx" {
+			return s.endpoints.SimulateTx(r, signedTx)
+		}
+
+		return s.endpoints.SendTx(r, signedTx)
+	})
+}
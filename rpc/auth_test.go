@@ -0,0 +1,122 @@
+package rpc
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	"github.com/0xPolygon/agglayer/tx"
+)
+
+func requestWithCert(commonName string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.TLS = &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: commonName}}}},
+	}
+
+	return r
+}
+
+func TestSendTxAuth(t *testing.T) {
+	t.Parallel()
+
+	auth := newSendTxAuth(config.AuthConfig{
+		Enabled: true,
+		APIKeys: []config.RollupAPIKey{{RollupID: 1, Key: "key-1"}},
+		ClientCerts: []config.RollupClientCert{
+			{RollupID: 1, CommonName: "rollup-1"},
+			{RollupID: 2, CommonName: "rollup-2"},
+			{RollupID: 3, CommonName: "rollup-2"},
+		},
+	})
+
+	withKey := func(r *http.Request, key string) *http.Request {
+		r.Header.Set("Authorization", "Bearer "+key)
+		return r
+	}
+
+	require.NoError(t, newSendTxAuth(config.AuthConfig{}).authorize(nil, 1))
+
+	require.ErrorIs(t, auth.authorize(nil, 1), ErrUnauthenticated)
+	require.ErrorIs(t, auth.authorize(httptest.NewRequest(http.MethodPost, "/", nil), 1), ErrUnauthenticated)
+	require.ErrorIs(t, auth.authorize(withKey(httptest.NewRequest(http.MethodPost, "/", nil), "wrong"), 1), ErrUnauthenticated)
+	require.ErrorIs(t, auth.authorize(requestWithCert("unknown"), 1), ErrUnauthenticated)
+
+	require.NoError(t, auth.authorize(withKey(httptest.NewRequest(http.MethodPost, "/", nil), "key-1"), 1))
+	require.ErrorIs(t, auth.authorize(withKey(httptest.NewRequest(http.MethodPost, "/", nil), "key-1"), 2), ErrRollupNotAllowed)
+
+	require.NoError(t, auth.authorize(requestWithCert("rollup-1"), 1))
+	require.NoError(t, auth.authorize(requestWithCert("rollup-2"), 2))
+	require.NoError(t, auth.authorize(requestWithCert("rollup-2"), 3))
+	require.ErrorIs(t, auth.authorize(requestWithCert("rollup-2"), 1), ErrRollupNotAllowed)
+
+	// an API key can authorize a caller whose certificate isn't scoped to the rollup
+	require.NoError(t, auth.authorize(withKey(requestWithCert("rollup-2"), "key-1"), 1))
+}
+
+func TestSendTxTLSServer(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSendTxTLSServer(log.WithFields("module", "test"), config.AuthConfig{
+		ClientCerts: []config.RollupClientCert{{RollupID: 1, CommonName: "rollup-1"}},
+	}, nil)
+	require.ErrorIs(t, err, ErrClientCertsWithoutCA)
+
+	c := &config.Config{Auth: config.AuthConfig{
+		Enabled:     true,
+		ClientCerts: []config.RollupClientCert{{RollupID: 1, CommonName: "rollup-1"}},
+	}}
+	i := NewInteropEndpoints(log.WithFields("module", "test"), nil, nil, mocks.NewDBMock(t), c)
+
+	s, err := NewSendTxTLSServer(log.WithFields("module", "test"), config.AuthConfig{}, i)
+	require.NoError(t, err)
+
+	call := func(r *http.Request, method string, params ...interface{}) jRPC.Response {
+		t.Helper()
+
+		body, err := json.Marshal(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  method,
+			"params":  params,
+		})
+		require.NoError(t, err)
+		r.Body = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)).Body
+
+		w := httptest.NewRecorder()
+		s.mux().ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res jRPC.Response
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+
+		return res
+	}
+
+	res := call(requestWithCert("rollup-1"), "interop_getTxStatus")
+	require.NotNil(t, res.Error)
+	require.Equal(t, jRPC.NotFoundErrorCode, res.Error.Code)
+
+	res = call(requestWithCert("rollup-1"), "interop_sendTx")
+	require.NotNil(t, res.Error)
+	require.Equal(t, jRPC.InvalidParamsErrorCode, res.Error.Code)
+
+	res = call(requestWithCert("rollup-1"), "interop_sendTx", tx.SignedTx{Tx: tx.Tx{RollupID: 2}})
+	require.NotNil(t, res.Error)
+	require.Equal(t, jRPC.AccessDeniedCode, res.Error.Code)
+
+	res = call(requestWithCert("rollup-1"), "interop_simulateTx", tx.SignedTx{Tx: tx.Tx{RollupID: 2}})
+	require.NotNil(t, res.Error)
+	require.Equal(t, jRPC.AccessDeniedCode, res.Error.Code)
+}
@@ -0,0 +1,416 @@
+package rpc
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/websocket"
+
+	rpcTypes "github.com/0xPolygon/agglayer/rpc/types"
+)
+
+const (
+	// RPC is the namespace of the discovery service
+	RPC = "rpc"
+
+	openRPCVersion = "1.2.6"
+	openRPCTitle   = "AggLayer"
+	// openRPCAPIVersion is the version of the API described, bumped when it changes
+	openRPCAPIVersion = "0.1.0"
+)
+
+// OpenRPCDocument is the OpenRPC description of the registered services
+type OpenRPCDocument struct {
+	OpenRPC    string            `json:"openrpc"`
+	Info       OpenRPCInfo       `json:"info"`
+	Methods    []OpenRPCMethod   `json:"methods"`
+	Components OpenRPCComponents `json:"components"`
+}
+
+// OpenRPCInfo is the metadata of the API
+type OpenRPCInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenRPCMethod is a method of a service
+type OpenRPCMethod struct {
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	Params      []OpenRPCContentDescriptor `json:"params"`
+	Result      OpenRPCContentDescriptor   `json:"result"`
+	Errors      []OpenRPCSchema            `json:"errors,omitempty"`
+}
+
+// OpenRPCContentDescriptor describes a param or the result of a method
+type OpenRPCContentDescriptor struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *OpenRPCSchema `json:"schema"`
+}
+
+// OpenRPCSchema is the JSON schema of a value, or a reference to a component
+type OpenRPCSchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Title                string                    `json:"title,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty"`
+	Items                *OpenRPCSchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenRPCSchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties *OpenRPCSchema            `json:"additionalProperties,omitempty"`
+}
+
+// OpenRPCError is an error returned by the methods
+type OpenRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// OpenRPCComponents are the schemas and the errors referenced by the methods
+type OpenRPCComponents struct {
+	Schemas map[string]*OpenRPCSchema `json:"schemas"`
+	Errors  map[string]OpenRPCError   `json:"errors"`
+}
+
+// methodDoc describes a method of a service, what reflection can't tell
+type methodDoc struct {
+	description string
+	params      []paramDoc
+	// result is a value of the type returned, as the methods return an interface{}
+	result paramDoc
+	// errors are the codes of the rpc/types catalogue the method may fail with
+	errors []int
+}
+
+type paramDoc struct {
+	name        string
+	description string
+	value       interface{}
+}
+
+// documentedService is implemented by the services described in the OpenRPC document
+type documentedService interface {
+	methodDocs() map[string]methodDoc
+}
+
+var (
+	hashSchema     = &OpenRPCSchema{Type: "string", Pattern: "^0x[0-9a-fA-F]{64}$"}
+	addressSchema  = &OpenRPCSchema{Type: "string", Pattern: "^0x[0-9a-fA-F]{40}$"}
+	quantitySchema = &OpenRPCSchema{Type: "string", Pattern: "^0x(0|[1-9a-fA-F][0-9a-fA-F]*)$"}
+	bytesSchema    = &OpenRPCSchema{Type: "string", Pattern: "^0x([0-9a-fA-F]{2})*$"}
+	timeSchema     = &OpenRPCSchema{Type: "string", Format: "date-time"}
+
+	// knownSchemas are the types encoded as strings by their marshaller
+	knownSchemas = map[reflect.Type]*OpenRPCSchema{
+		reflect.TypeOf(common.Hash{}):              hashSchema,
+		reflect.TypeOf(rpcTypes.ArgHash{}):         hashSchema,
+		reflect.TypeOf(common.Address{}):           addressSchema,
+		reflect.TypeOf(rpcTypes.ArgUint64(0)):      quantitySchema,
+		reflect.TypeOf(hexutil.Uint64(0)):          quantitySchema,
+		reflect.TypeOf(hexutil.Big{}):              quantitySchema,
+		reflect.TypeOf(rpcTypes.ArgBytes{}):        bytesSchema,
+		reflect.TypeOf(hexutil.Bytes{}):            bytesSchema,
+		reflect.TypeOf(time.Time{}):                timeSchema,
+		reflect.TypeOf((*interface{})(nil)).Elem(): {},
+		// described by the OpenRPC specification rather than by its fields
+		reflect.TypeOf(OpenRPCDocument{}): {Type: "object"},
+	}
+
+	httpRequestType   = reflect.TypeOf(&http.Request{})
+	wsConnType        = reflect.TypeOf(&websocket.Conn{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// GenerateOpenRPC returns the OpenRPC document of the services, their methods and params found
+// by reflection as registered by the RPC server, and their schemas from their JSON tags
+func GenerateOpenRPC(services []jRPC.Service) (OpenRPCDocument, error) {
+	g := &openRPCGenerator{
+		schemas: map[string]*OpenRPCSchema{},
+		types:   map[string]reflect.Type{},
+	}
+
+	doc := OpenRPCDocument{
+		OpenRPC: openRPCVersion,
+		Info:    OpenRPCInfo{Title: openRPCTitle, Version: openRPCAPIVersion},
+		Methods: []OpenRPCMethod{},
+		Components: OpenRPCComponents{
+			Schemas: g.schemas,
+			Errors:  map[string]OpenRPCError{},
+		},
+	}
+
+	for _, service := range services {
+		documented, ok := service.Service.(documentedService)
+		if !ok {
+			return OpenRPCDocument{}, fmt.Errorf("service %s is not documented", service.Name)
+		}
+		docs := documented.methodDocs()
+
+		st := reflect.TypeOf(service.Service)
+		for i := 0; i < st.NumMethod(); i++ {
+			m := st.Method(i)
+			name := service.Name + "_" + lowerCaseFirst(m.Name)
+
+			md, ok := docs[m.Name]
+			if !ok {
+				return OpenRPCDocument{}, fmt.Errorf("method %s is not documented", name)
+			}
+
+			method, err := g.method(name, m.Type, md)
+			if err != nil {
+				return OpenRPCDocument{}, err
+			}
+
+			for _, code := range md.errors {
+				errName, rpcErr, err := catalogueError(code)
+				if err != nil {
+					return OpenRPCDocument{}, fmt.Errorf("method %s: %w", name, err)
+				}
+				doc.Components.Errors[errName] = rpcErr
+				method.Errors = append(method.Errors, OpenRPCSchema{Ref: "#/components/errors/" + errName})
+			}
+
+			doc.Methods = append(doc.Methods, method)
+		}
+	}
+
+	return doc, nil
+}
+
+type openRPCGenerator struct {
+	schemas map[string]*OpenRPCSchema
+	types   map[string]reflect.Type
+}
+
+// method describes the method from its signature, skipping the receiver and the params
+// injected by the RPC server
+func (g *openRPCGenerator) method(name string, ft reflect.Type, md methodDoc) (OpenRPCMethod, error) {
+	var params []reflect.Type
+	for i := 1; i < ft.NumIn(); i++ {
+		in := ft.In(i)
+		if i == 1 && (in == httpRequestType || in == wsConnType) {
+			continue
+		}
+		params = append(params, in)
+	}
+
+	if len(params) != len(md.params) {
+		return OpenRPCMethod{}, fmt.Errorf("method %s has %d params but %d are documented", name, len(params), len(md.params))
+	}
+
+	method := OpenRPCMethod{
+		Name:        name,
+		Description: md.description,
+		Params:      make([]OpenRPCContentDescriptor, len(params)),
+		Result: OpenRPCContentDescriptor{
+			Name:        md.result.name,
+			Description: md.result.description,
+			Schema:      g.schema(reflect.TypeOf(md.result.value)),
+		},
+	}
+
+	for i, param := range params {
+		method.Params[i] = OpenRPCContentDescriptor{
+			Name:        md.params[i].name,
+			Description: md.params[i].description,
+			Required:    true,
+			Schema:      g.schema(param),
+		}
+	}
+
+	return method, nil
+}
+
+// schema returns the schema of the type as encoded by encoding/json, the structs
+// are added to the components and referenced
+func (g *openRPCGenerator) schema(t reflect.Type) *OpenRPCSchema {
+	if t == nil {
+		return &OpenRPCSchema{}
+	}
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if s, ok := knownSchemas[t]; ok {
+		return s
+	}
+
+	// the types encoding themselves can't be described by their fields
+	if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		if t.Kind() == reflect.Struct {
+			return &OpenRPCSchema{Type: "object"}
+		}
+
+		return &OpenRPCSchema{}
+	}
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return &OpenRPCSchema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &OpenRPCSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &OpenRPCSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &OpenRPCSchema{Type: "number"}
+	case reflect.String:
+		return &OpenRPCSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoded in base64
+			return &OpenRPCSchema{Type: "string"}
+		}
+
+		return &OpenRPCSchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &OpenRPCSchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		return g.ref(t)
+	default:
+		return &OpenRPCSchema{}
+	}
+}
+
+// ref adds the struct to the components, named after its type, and returns a reference to it
+func (g *openRPCGenerator) ref(t reflect.Type) *OpenRPCSchema {
+	name := t.Name()
+	if existing, ok := g.types[name]; ok && existing != t {
+		name = upperCaseFirst(t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]) + name
+	}
+
+	ref := &OpenRPCSchema{Ref: "#/components/schemas/" + name}
+	if _, ok := g.types[name]; ok {
+		return ref
+	}
+
+	// registered before its fields so recursive types terminate
+	s := &OpenRPCSchema{Title: lowerCaseFirst(name), Type: "object", Properties: map[string]*OpenRPCSchema{}}
+	g.types[name] = t
+	g.schemas[name] = s
+
+	g.addFields(s, t)
+	sort.Strings(s.Required)
+
+	return ref
+}
+
+// addFields adds the exported fields of the struct to the schema, as named by their JSON tags
+func (g *openRPCGenerator) addFields(s *OpenRPCSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// the fields of the embedded structs are promoted, unless they are named
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(s, ft)
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		s.Properties[name] = g.schema(f.Type)
+		if !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Ptr {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// catalogueError returns the name and the description of the error of the code, from rpc/types
+func catalogueError(code int) (string, OpenRPCError, error) {
+	sentinel := rpcTypes.NewError(code, "", nil).Unwrap()
+	if sentinel == nil {
+		return "", OpenRPCError{}, fmt.Errorf("unknown error code %d", code)
+	}
+
+	words := strings.Fields(sentinel.Error())
+	for i, word := range words {
+		words[i] = upperCaseFirst(word)
+	}
+
+	return strings.Join(words, ""), OpenRPCError{Code: code, Message: sentinel.Error()}, nil
+}
+
+func lowerCaseFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+
+	return string(r)
+}
+
+func upperCaseFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+
+	return string(r)
+}
+
+// DiscoverEndpoints serves rpc_discover, the OpenRPC document of the registered services
+type DiscoverEndpoints struct {
+	services []jRPC.Service
+}
+
+// NewDiscoverEndpoints returns the discovery service of the services, it describes itself as well
+func NewDiscoverEndpoints(services ...jRPC.Service) *DiscoverEndpoints {
+	d := &DiscoverEndpoints{}
+	d.services = append(append([]jRPC.Service{}, services...), jRPC.Service{Name: RPC, Service: d})
+
+	return d
+}
+
+// Discover returns the OpenRPC document of the registered services
+func (d *DiscoverEndpoints) Discover() (interface{}, jRPC.Error) {
+	doc, err := GenerateOpenRPC(d.services)
+	if err != nil {
+		return nil, jRPC.NewRPCError(rpcTypes.ErrorCodeInternal, err.Error())
+	}
+
+	return doc, nil
+}
+
+func (d *DiscoverEndpoints) methodDocs() map[string]methodDoc {
+	return map[string]methodDoc{
+		"Discover": {
+			description: "Get the OpenRPC document of the API, generated from the registered services",
+			result:      paramDoc{name: "openrpc", description: "The OpenRPC document", value: OpenRPCDocument{}},
+		},
+	}
+}
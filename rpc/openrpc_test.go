@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+const openRPCFile = "../docs/openrpc.json"
+
+var updateOpenRPC = flag.Bool("update", false, "rewrite docs/openrpc.json with the generated document")
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	discover := NewDiscoverEndpoints(
+		jRPC.Service{Name: INTEROP, Service: &InteropEndpoints{}},
+		(&WebSocketServer{}).Service(),
+	)
+
+	result, rpcErr := discover.Discover()
+	require.Nil(t, rpcErr)
+
+	served, err := json.MarshalIndent(result, "", "    ")
+	require.NoError(t, err)
+	served = append(served, '\n')
+
+	if *updateOpenRPC {
+		require.NoError(t, os.WriteFile(openRPCFile, served, 0o644))
+	}
+
+	checkedIn, err := os.ReadFile(openRPCFile)
+	require.NoError(t, err)
+	require.JSONEq(t, string(checkedIn), string(served), "docs/openrpc.json is outdated, run make generate-openrpc")
+
+	doc := result.(OpenRPCDocument)
+	names := make([]string, 0, len(doc.Methods))
+	for _, method := range doc.Methods {
+		names = append(names, method.Name)
+	}
+	require.Contains(t, names, "interop_sendTx")
+	require.Contains(t, names, "interop_subscribe")
+	require.Contains(t, names, "rpc_discover")
+
+	// the params are named after the JSON tags, and fields without tags after the fields
+	require.Contains(t, doc.Components.Schemas["SignedTx"].Properties, "tx")
+	require.Contains(t, doc.Components.Schemas["Tx"].Properties, "RollupID")
+}
+
+func TestGenerateOpenRPCUndocumented(t *testing.T) {
+	t.Parallel()
+
+	_, err := GenerateOpenRPC([]jRPC.Service{{Name: "undocumented", Service: &struct{}{}}})
+	require.ErrorContains(t, err, "service undocumented is not documented")
+}
@@ -2,7 +2,10 @@ package rpc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/0xPolygon/agglayer/log"
 	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
@@ -14,7 +17,9 @@ import (
 
 	"github.com/0xPolygon/agglayer/config"
 	"github.com/0xPolygon/agglayer/interop"
+	rpcTypes "github.com/0xPolygon/agglayer/rpc/types"
 	"github.com/0xPolygon/agglayer/tx"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
 	"github.com/0xPolygon/agglayer/types"
 )
 
@@ -28,8 +33,10 @@ const (
 // InteropEndpoints contains implementations for the "interop" RPC endpoints
 type InteropEndpoints struct {
 	executor *interop.Executor
+	pipeline *interop.Pipeline
 	db       types.IDB
 	config   *config.Config
+	auth     *sendTxAuth
 	meter    metric.Meter
 	logger   *zap.SugaredLogger
 }
@@ -38,6 +45,7 @@ type InteropEndpoints struct {
 func NewInteropEndpoints(
 	logger *zap.SugaredLogger,
 	executor *interop.Executor,
+	pipeline *interop.Pipeline,
 	db types.IDB,
 	conf *config.Config,
 ) *InteropEndpoints {
@@ -45,14 +53,22 @@ func NewInteropEndpoints(
 
 	return &InteropEndpoints{
 		executor: executor,
+		pipeline: pipeline,
 		db:       db,
 		config:   conf,
+		auth:     newSendTxAuth(conf.Auth),
 		meter:    meter,
 		logger:   logger,
 	}
 }
 
-func (i *InteropEndpoints) SendTx(signedTx tx.SignedTx) (interface{}, jRPC.Error) {
+func (i *InteropEndpoints) SendTx(r *http.Request, signedTx tx.SignedTx) (interface{}, jRPC.Error) {
+	// Authenticate the caller before anything is queried on its behalf
+	if err := i.auth.authorize(r, signedTx.Tx.RollupID); err != nil {
+		i.logger.Debugf("rejected tx of rollup %d: %s", signedTx.Tx.RollupID, err)
+		return "0x0", newRPCError(rpcTypes.ErrorCodeAccessDenied, err.Error(), txErrorData(signedTx, err))
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), i.config.RPC.WriteTimeout.Duration)
 	defer cancel()
 
@@ -64,58 +80,153 @@ func (i *InteropEndpoints) SendTx(signedTx tx.SignedTx) (interface{}, jRPC.Error
 	}
 	c.Add(ctx, 1, opts)
 
-	// Check if the RPC is actually registered, if not it won't be possible to assert soundness (in the future once we are stateless won't be needed)
+	// Reject the malformed or wrongly signed txs before any network I/O
+	if err = i.executor.Prevalidate(signedTx); err != nil {
+		return "0x0", newRPCError(interop.ErrorCode(err), err.Error(), txErrorData(signedTx, err))
+	}
+
+	// Check the rollup is known and the soundness of the tx can be asserted
 	if err = i.executor.CheckTx(signedTx); err != nil {
-		return "0x0", jRPC.NewRPCError(jRPC.DefaultErrorCode, fmt.Sprintf("there is no RPC registered for %d", signedTx.Tx.RollupID))
+		return "0x0", newRPCError(interop.ErrorCode(err), err.Error(), txErrorData(signedTx, err))
 	}
 
-	// Verify ZKP using eth_call
-	if err = i.executor.Verify(ctx, signedTx); err != nil {
-		return "0x0", jRPC.NewRPCError(jRPC.DefaultErrorCode, fmt.Sprintf("failed to verify tx: %s", err))
+	// Verification and settlement happen asynchronously, the tx is only persisted here
+	itx := types.NewIntakeTx(signedTx)
+	if err = i.db.AddIntakeTx(ctx, itx, nil); err != nil {
+		if errors.Is(err, types.ErrIntakeTxAlreadyExists) {
+			log.Debugf("tx %s is already in the intake queue", itx.Hash.Hex())
+
+			return itx.Hash, nil
+		}
+
+		log.Errorf("failed to add tx to the intake queue, error: %s", err)
+		return "0x0", newRPCError(rpcTypes.ErrorCodeDB, "failed to add tx to the intake queue", txErrorData(signedTx, nil))
 	}
 
-	if err = i.executor.Execute(ctx, signedTx); err != nil {
-		return "0x0", jRPC.NewRPCError(jRPC.DefaultErrorCode, fmt.Sprintf("failed to execute tx: %s", err))
+	i.pipeline.Notify(itx)
+
+	log.Debugf("successfuly added tx %s to the intake queue", itx.Hash.Hex())
+
+	return itx.Hash, nil
+}
+
+// SimulateTx runs the checks of the tx and builds the L1 tx that would settle it, without
+// queueing or settling it
+func (i *InteropEndpoints) SimulateTx(r *http.Request, signedTx tx.SignedTx) (interface{}, jRPC.Error) {
+	// The simulation queries L1 and the full nodes like the tx would, so the same credentials are required
+	if err := i.auth.authorize(r, signedTx.Tx.RollupID); err != nil {
+		i.logger.Debugf("rejected simulation of rollup %d: %s", signedTx.Tx.RollupID, err)
+		return "0x0", newRPCError(rpcTypes.ErrorCodeAccessDenied, err.Error(), txErrorData(signedTx, err))
 	}
 
-	// Send L1 tx
-	dbTx, err := i.db.BeginStateTransaction(ctx)
-	if err != nil {
-		log.Errorf("failed to begin dbTx, error: %s", err)
-		return "0x0", jRPC.NewRPCError(jRPC.DefaultErrorCode, "failed to begin dbTx")
+	ctx, cancel := context.WithTimeout(context.Background(), i.config.RPC.WriteTimeout.Duration)
+	defer cancel()
+
+	i.logger.Debugf("simulating tx %v", signedTx.Tx)
+
+	return i.executor.Simulate(ctx, signedTx), nil
+}
+
+func (i *InteropEndpoints) GetTxStatus(hash common.Hash) (result interface{}, err jRPC.Error) {
+	ctx, cancel := context.WithTimeout(context.Background(), i.config.RPC.ReadTimeout.Duration)
+	defer cancel()
+
+	c, merr := i.meter.Int64Counter("get_tx_status")
+	if merr != nil {
+		i.logger.Warnf("failed to create get_tx_status counter: %s", merr)
+	}
+	c.Add(ctx, 1)
+
+	dbTx, innerErr := i.db.BeginStateTransaction(ctx)
+	if innerErr != nil {
+		log.Errorf("failed to begin dbTx, error: %s", innerErr)
+		return "0x0", newRPCError(rpcTypes.ErrorCodeDB, "failed to begin dbTx", nil)
 	}
 
-	if _, err = i.executor.Settle(ctx, signedTx, dbTx); err != nil {
-		if errRollback := dbTx.Rollback(ctx); errRollback != nil {
-			log.Error("rollback err: ", errRollback)
+	defer func() {
+		if innerErr := dbTx.Rollback(ctx); innerErr != nil {
+			log.Errorf("failed to rollback dbTx, error: %s", innerErr)
+
+			result = "0x0"
+			err = newRPCError(rpcTypes.ErrorCodeDB, "failed to rollback dbTx", nil)
 		}
-		return "0x0", jRPC.NewRPCError(jRPC.DefaultErrorCode, fmt.Sprintf("failed to add tx to ethTxMan, error: %s", err))
+	}()
+
+	// Once handed over to ethTxMan the status is the one of the monitored tx
+	itx, innerErr := i.db.GetIntakeTx(ctx, hash, dbTx)
+	if innerErr == nil && itx.Status != types.IntakeTxStatusSettling {
+		return itx.Status.String(), nil
+	} else if innerErr != nil && !errors.Is(innerErr, types.ErrIntakeTxNotFound) {
+		return "0x0", newRPCError(rpcTypes.ErrorCodeDB, fmt.Sprintf("failed to get tx, error: %s", innerErr), &rpcTypes.ErrorData{TxHash: &hash})
+	}
+
+	status, rpcErr := i.executor.GetTxStatus(ctx, hash, dbTx)
+	if rpcErr != nil {
+		return "0x0", rpcErr
+	}
+
+	return status, nil
+}
+
+func (i *InteropEndpoints) GetTxDetails(hash common.Hash) (result interface{}, err jRPC.Error) {
+	ctx, cancel := context.WithTimeout(context.Background(), i.config.RPC.ReadTimeout.Duration)
+	defer cancel()
+
+	c, merr := i.meter.Int64Counter("get_tx_details")
+	if merr != nil {
+		i.logger.Warnf("failed to create get_tx_details counter: %s", merr)
+	}
+	c.Add(ctx, 1)
+
+	dbTx, innerErr := i.db.BeginStateTransaction(ctx)
+	if innerErr != nil {
+		log.Errorf("failed to begin dbTx, error: %s", innerErr)
+		return "0x0", newRPCError(rpcTypes.ErrorCodeDB, "failed to begin dbTx", nil)
 	}
 
-	if err = dbTx.Commit(ctx); err != nil {
-		log.Errorf("failed to commit dbTx, error: %s", err)
-		return "0x0", jRPC.NewRPCError(jRPC.DefaultErrorCode, "failed to commit dbTx")
+	defer func() {
+		if innerErr := dbTx.Rollback(ctx); innerErr != nil {
+			log.Errorf("failed to rollback dbTx, error: %s", innerErr)
+
+			result = "0x0"
+			err = newRPCError(rpcTypes.ErrorCodeDB, "failed to rollback dbTx", nil)
+		}
+	}()
+
+	itx, innerErr := i.db.GetIntakeTx(ctx, hash, dbTx)
+	if errors.Is(innerErr, types.ErrIntakeTxNotFound) {
+		return "0x0", newRPCError(rpcTypes.ErrorCodeTxNotFound, fmt.Sprintf("tx %s not found", hash.Hex()), &rpcTypes.ErrorData{TxHash: &hash})
+	} else if innerErr != nil {
+		return "0x0", newRPCError(rpcTypes.ErrorCodeDB, fmt.Sprintf("failed to get tx, error: %s", innerErr), &rpcTypes.ErrorData{TxHash: &hash})
 	}
 
-	log.Debugf("successfuly added tx %s to ethTxMan", signedTx.Tx.Hash().Hex())
+	details, innerErr := i.executor.GetTxDetails(ctx, itx, dbTx)
+	if innerErr != nil {
+		return "0x0", newRPCError(rpcTypes.ErrorCodeDB, fmt.Sprintf("failed to get tx details, error: %s", innerErr), &rpcTypes.ErrorData{TxHash: &hash})
+	}
 
-	return signedTx.Tx.Hash(), nil
+	return details, nil
 }
 
-func (i *InteropEndpoints) GetTxStatus(hash common.Hash) (result interface{}, err jRPC.Error) {
+// ListTxs returns a page of the txs settled on L1 selected by the filter, newest first
+func (i *InteropEndpoints) ListTxs(filter types.TxListFilter) (result interface{}, err jRPC.Error) {
 	ctx, cancel := context.WithTimeout(context.Background(), i.config.RPC.ReadTimeout.Duration)
 	defer cancel()
 
-	c, merr := i.meter.Int64Counter("get_tx_status")
+	c, merr := i.meter.Int64Counter("list_txs")
 	if merr != nil {
-		i.logger.Warnf("failed to create get_tx_status counter: %s", merr)
+		i.logger.Warnf("failed to create list_txs counter: %s", merr)
 	}
 	c.Add(ctx, 1)
 
+	if innerErr := filter.Validate(); innerErr != nil {
+		return "0x0", jRPC.NewRPCError(jRPC.InvalidParamsErrorCode, innerErr.Error())
+	}
+
 	dbTx, innerErr := i.db.BeginStateTransaction(ctx)
 	if innerErr != nil {
 		log.Errorf("failed to begin dbTx, error: %s", innerErr)
-		return "0x0", jRPC.NewRPCError(jRPC.DefaultErrorCode, "failed to begin dbTx")
+		return "0x0", newRPCError(rpcTypes.ErrorCodeDB, "failed to begin dbTx", nil)
 	}
 
 	defer func() {
@@ -123,14 +234,140 @@ func (i *InteropEndpoints) GetTxStatus(hash common.Hash) (result interface{}, er
 			log.Errorf("failed to rollback dbTx, error: %s", innerErr)
 
 			result = "0x0"
-			err = jRPC.NewRPCError(jRPC.DefaultErrorCode, "failed to rollback dbTx")
+			err = newRPCError(rpcTypes.ErrorCodeDB, "failed to rollback dbTx", nil)
 		}
 	}()
 
-	result, innerErr = i.executor.GetTxStatus(ctx, hash, dbTx)
+	list, innerErr := i.executor.ListTxs(ctx, filter, dbTx)
+	if errors.Is(innerErr, txmTypes.ErrInvalidCursor) {
+		return "0x0", jRPC.NewRPCError(jRPC.InvalidParamsErrorCode, innerErr.Error())
+	} else if innerErr != nil {
+		return "0x0", newRPCError(rpcTypes.ErrorCodeDB, fmt.Sprintf("failed to list txs, error: %s", innerErr), nil)
+	}
+
+	return list, nil
+}
+
+// GetRollupState returns the last batch of the rollup settled by the agglayer, the settlements
+// in flight and the rollup data of the rollup manager
+func (i *InteropEndpoints) GetRollupState(rollupID uint32) (result interface{}, err jRPC.Error) {
+	ctx, cancel := context.WithTimeout(context.Background(), i.config.RPC.ReadTimeout.Duration)
+	defer cancel()
+
+	opts := metric.WithAttributes(attribute.Key("rollup_id").Int(int(rollupID)))
+	c, merr := i.meter.Int64Counter("get_rollup_state")
+	if merr != nil {
+		i.logger.Warnf("failed to create get_rollup_state counter: %s", merr)
+	}
+	c.Add(ctx, 1, opts)
+
+	dbTx, innerErr := i.db.BeginStateTransaction(ctx)
 	if innerErr != nil {
-		return "0x0", jRPC.NewRPCError(jRPC.DefaultErrorCode, fmt.Sprintf("failed to get tx, error: %s", innerErr))
+		log.Errorf("failed to begin dbTx, error: %s", innerErr)
+		return "0x0", newRPCError(rpcTypes.ErrorCodeDB, "failed to begin dbTx", nil)
+	}
+
+	defer func() {
+		if innerErr := dbTx.Rollback(ctx); innerErr != nil {
+			log.Errorf("failed to rollback dbTx, error: %s", innerErr)
+
+			result = "0x0"
+			err = newRPCError(rpcTypes.ErrorCodeDB, "failed to rollback dbTx", nil)
+		}
+	}()
+
+	state, innerErr := i.executor.GetRollupState(ctx, rollupID, i.db, dbTx)
+	if errors.Is(innerErr, types.ErrUnknownRollup) {
+		return "0x0", newRPCError(rpcTypes.ErrorCodeUnknownRollup, fmt.Sprintf("rollup %d not found", rollupID), &rpcTypes.ErrorData{RollupID: &rollupID})
+	} else if innerErr != nil {
+		return "0x0", newRPCError(rpcTypes.ErrorCodeInternal, fmt.Sprintf("failed to get rollup state, error: %s", innerErr), &rpcTypes.ErrorData{RollupID: &rollupID})
 	}
 
-	return result, nil
+	return state, nil
+}
+
+// txErrorData returns the data of the error reporting the failure of the tx
+func txErrorData(signedTx tx.SignedTx, reason error) *rpcTypes.ErrorData {
+	data := &rpcTypes.ErrorData{RollupID: &signedTx.Tx.RollupID}
+	if hash := signedTx.Tx.Hash(); hash != (common.Hash{}) {
+		data.TxHash = &hash
+	}
+	if reason != nil {
+		data.Reason = reason.Error()
+	}
+
+	return data
+}
+
+// newRPCError returns the error of the code from the catalogue in rpc/types, with its data encoded as JSON
+func newRPCError(code int, message string, data *rpcTypes.ErrorData) jRPC.Error {
+	if data == nil {
+		return jRPC.NewRPCError(code, message)
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return jRPC.NewRPCError(code, message)
+	}
+
+	return jRPC.NewRPCErrorWithData(code, message, &encoded)
+}
+
+func (i *InteropEndpoints) methodDocs() map[string]methodDoc {
+	signedTx := paramDoc{name: "signedTx", description: "The signed transaction to send"}
+	hash := paramDoc{name: "hash", description: "The hash of the transaction"}
+
+	return map[string]methodDoc{
+		"SendTx": {
+			description: "Send a transaction to the AggLayer",
+			params:      []paramDoc{signedTx},
+			result:      paramDoc{name: "txHash", description: "Hex representation of the transaction hash", value: common.Hash{}},
+			errors: []int{
+				rpcTypes.ErrorCodeUnknownRollup,
+				rpcTypes.ErrorCodeInvalidSignature,
+				rpcTypes.ErrorCodeUnauthorizedSigner,
+				rpcTypes.ErrorCodeDB,
+				rpcTypes.ErrorCodeInvalidParams,
+				rpcTypes.ErrorCodeAccessDenied,
+			},
+		},
+		"SimulateTx": {
+			description: "Run a transaction through the checks of the AggLayer and build the L1 transaction that would settle it, " +
+				"without queueing or settling it",
+			params: []paramDoc{signedTx},
+			result: paramDoc{
+				name:        "simulation",
+				description: "The outcome of each step, the calldata and the gas estimate of the L1 transaction",
+				value:       types.TxSimulation{},
+			},
+			errors: []int{rpcTypes.ErrorCodeAccessDenied},
+		},
+		"GetTxStatus": {
+			description: "Get the status of a transaction",
+			params:      []paramDoc{hash},
+			result:      paramDoc{name: "status", description: "The status of the transaction", value: ""},
+			errors:      []int{rpcTypes.ErrorCodeTxNotFound, rpcTypes.ErrorCodeDB},
+		},
+		"GetTxDetails": {
+			description: "Get the lifecycle of a transaction: the transaction as received, its signer, when it reached each stage " +
+				"and every L1 transaction sent to settle it",
+			params: []paramDoc{hash},
+			result: paramDoc{name: "details", description: "The details of the transaction", value: types.TxDetails{}},
+			errors: []int{rpcTypes.ErrorCodeTxNotFound, rpcTypes.ErrorCodeDB},
+		},
+		"ListTxs": {
+			description: "List the transactions handed over to L1, newest first, optionally filtered by rollup, settlement status, " +
+				"batch range and time window",
+			params: []paramDoc{{name: "filter", description: "The filters of the listing, all of them optional"}},
+			result: paramDoc{name: "list", description: "A page of transactions and the cursor of the next one", value: types.TxList{}},
+			errors: []int{rpcTypes.ErrorCodeDB, rpcTypes.ErrorCodeInvalidParams},
+		},
+		"GetRollupState": {
+			description: "Get the last batch of a rollup settled by the agglayer, its settlements in flight and its rollup data " +
+				"in the rollup manager",
+			params: []paramDoc{{name: "rollupId", description: "The ID of the rollup in the rollup manager"}},
+			result: paramDoc{name: "state", description: "The state of the rollup", value: types.RollupState{}},
+			errors: []int{rpcTypes.ErrorCodeUnknownRollup, rpcTypes.ErrorCodeInternal, rpcTypes.ErrorCodeDB},
+		},
+	}
 }
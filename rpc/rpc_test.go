@@ -1,18 +1,22 @@
 package rpc
 
 import (
+	"encoding/json"
 	"errors"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/0xPolygon/agglayer/config"
 	"github.com/0xPolygon/agglayer/interop"
 	"github.com/0xPolygon/agglayer/mocks"
 	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	aggTypes "github.com/0xPolygon/agglayer/types"
 
 	"github.com/0xPolygon/agglayer/log"
 	agglayerTypes "github.com/0xPolygon/agglayer/rpc/types"
-	validiumTypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -39,7 +43,7 @@ func TestInteropEndpointsGetTxStatus(t *testing.T) {
 			mocks.NewEthermanMock(t),
 			mocks.NewEthTxManagerMock(t),
 		)
-		i := NewInteropEndpoints(log.WithFields("module", "rpc"), e, dbMock, cfg)
+		i := NewInteropEndpoints(log.WithFields("module", "rpc"), e, nil, dbMock, cfg)
 
 		result, err := i.GetTxStatus(common.HexToHash("0xsomeTxHash"))
 
@@ -59,6 +63,8 @@ func TestInteropEndpointsGetTxStatus(t *testing.T) {
 
 		dbMock := mocks.NewDBMock(t)
 		dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+		dbMock.On("GetIntakeTx", mock.Anything, txHash, txMock).
+			Return(aggTypes.IntakeTx{}, aggTypes.ErrIntakeTxNotFound).Once()
 
 		txManagerMock := mocks.NewEthTxManagerMock(t)
 		txManagerMock.On("Result", mock.Anything, ethTxManOwner, txHash.Hex(), txMock).
@@ -72,11 +78,12 @@ func TestInteropEndpointsGetTxStatus(t *testing.T) {
 			mocks.NewEthermanMock(t),
 			txManagerMock,
 		)
-		i := NewInteropEndpoints(log.WithFields("module", "rpc"), e, dbMock, cfg)
+		i := NewInteropEndpoints(log.WithFields("module", "rpc"), e, nil, dbMock, cfg)
 
 		result, err := i.GetTxStatus(txHash)
 
 		require.Equal(t, "0x0", result)
+		require.Equal(t, agglayerTypes.ErrorCodeDB, err.ErrorCode())
 		require.ErrorContains(t, err, "failed to get tx")
 
 		dbMock.AssertExpectations(t)
@@ -104,6 +111,8 @@ func TestInteropEndpointsGetTxStatus(t *testing.T) {
 
 		dbMock := mocks.NewDBMock(t)
 		dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+		dbMock.On("GetIntakeTx", mock.Anything, txHash, txMock).
+			Return(aggTypes.IntakeTx{}, aggTypes.ErrIntakeTxNotFound).Once()
 
 		txManagerMock := mocks.NewEthTxManagerMock(t)
 		txManagerMock.On("Result", mock.Anything, ethTxManOwner, txHash.Hex(), txMock).
@@ -117,7 +126,7 @@ func TestInteropEndpointsGetTxStatus(t *testing.T) {
 			mocks.NewEthermanMock(t),
 			txManagerMock,
 		)
-		i := NewInteropEndpoints(log.WithFields("module", "rpc"), e, dbMock, cfg)
+		i := NewInteropEndpoints(log.WithFields("module", "rpc"), e, nil, dbMock, cfg)
 
 		status, err := i.GetTxStatus(txHash)
 
@@ -128,522 +137,517 @@ func TestInteropEndpointsGetTxStatus(t *testing.T) {
 		txMock.AssertExpectations(t)
 		txManagerMock.AssertExpectations(t)
 	})
-}
+	t.Run("failed to get intake tx", func(t *testing.T) {
+		t.Parallel()
 
-func TestInteropEndpointsSendTx(t *testing.T) {
-	t.Parallel()
+		txHash := common.HexToHash("0xsomeTxHash")
 
-	type testConfig struct {
-		isL1ContractInMap   bool
-		canBuildZKProof     bool
-		isZKProofValid      bool
-		isTxSigned          bool
-		isAdminRetrieved    bool
-		isSignerValid       bool
-		canGetBatch         bool
-		isBatchValid        bool
-		isDbTxOpen          bool
-		isTxAddedToEthTxMan bool
-		isTxCommitted       bool
-
-		expectedError string
-	}
+		txMock := new(mocks.TxMock)
+		txMock.On("Rollback", mock.Anything).Return(nil).Once()
 
-	testFn := func(cfg testConfig) {
-		fullNodeRPCs := config.FullNodeRPCs{
-			1: "someRPC",
-		}
-		tnx := tx.Tx{
-			LastVerifiedBatch: agglayerTypes.ArgUint64(1),
-			NewVerifiedBatch:  *agglayerTypes.ArgUint64Ptr(2),
-			ZKP: tx.ZKP{
-				NewStateRoot:     common.BigToHash(big.NewInt(11)),
-				NewLocalExitRoot: common.BigToHash(big.NewInt(11)),
-			},
-			RollupID: 1,
-		}
-		signedTx := &tx.SignedTx{Tx: tnx}
-		ethermanMock := mocks.NewEthermanMock(t)
-		zkEVMClientCreatorMock := mocks.NewZkEVMClientClientCreatorMock(t)
-		zkEVMClientMock := mocks.NewZkEVMClientMock(t)
 		dbMock := mocks.NewDBMock(t)
-		txMock := new(mocks.TxMock)
-		ethTxManagerMock := mocks.NewEthTxManagerMock(t)
+		dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+		dbMock.On("GetIntakeTx", mock.Anything, txHash, txMock).
+			Return(aggTypes.IntakeTx{}, errors.New("error")).Once()
+
+		cfg := &config.Config{}
+		e := interop.New(
+			log.WithFields("module", "test"),
+			cfg,
+			common.HexToAddress("0xadmin"),
+			mocks.NewEthermanMock(t),
+			mocks.NewEthTxManagerMock(t),
+		)
+		i := NewInteropEndpoints(log.WithFields("module", "rpc"), e, nil, dbMock, cfg)
+
+		result, err := i.GetTxStatus(txHash)
+
+		require.Equal(t, "0x0", result)
+		require.Equal(t, agglayerTypes.ErrorCodeDB, err.ErrorCode())
+		require.ErrorContains(t, err, "failed to get tx")
+
+		dbMock.AssertExpectations(t)
+		txMock.AssertExpectations(t)
+	})
+
+	for _, status := range []aggTypes.IntakeTxStatus{
+		aggTypes.IntakeTxStatusReceived,
+		aggTypes.IntakeTxStatusVerified,
+		aggTypes.IntakeTxStatusRejected,
+	} {
+		status := status
+
+		t.Run("intake tx "+status.String(), func(t *testing.T) {
+			t.Parallel()
+
+			txHash := common.HexToHash("0xsomeTxHash")
 
-		executeTestFn := func() {
-			c := &config.Config{
-				FullNodeRPCs: fullNodeRPCs,
-				L1:           config.L1Config{RollupManagerContract: common.HexToAddress("0xdeadbeef")},
-			}
+			txMock := new(mocks.TxMock)
+			txMock.On("Rollback", mock.Anything).Return(nil).Once()
 
+			dbMock := mocks.NewDBMock(t)
+			dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+			dbMock.On("GetIntakeTx", mock.Anything, txHash, txMock).
+				Return(aggTypes.IntakeTx{Hash: txHash, Status: status}, nil).Once()
+
+			cfg := &config.Config{}
 			e := interop.New(
 				log.WithFields("module", "test"),
-				c,
+				cfg,
 				common.HexToAddress("0xadmin"),
-				ethermanMock,
-				ethTxManagerMock,
+				mocks.NewEthermanMock(t),
+				mocks.NewEthTxManagerMock(t),
 			)
-			i := NewInteropEndpoints(log.WithFields("module", "rpc"), e, dbMock, c)
-			i.executor.ZkEVMClientCreator = zkEVMClientCreatorMock
-
-			result, err := i.SendTx(*signedTx)
-
-			if cfg.expectedError != "" {
-				require.Equal(t, "0x0", result)
-				require.ErrorContains(t, err, cfg.expectedError)
-			} else {
-				require.NoError(t, err)
-				require.Equal(t, signedTx.Tx.Hash(), result)
-			}
-
-			ethermanMock.AssertExpectations(t)
-			zkEVMClientCreatorMock.AssertExpectations(t)
-			zkEVMClientMock.AssertExpectations(t)
+			i := NewInteropEndpoints(log.WithFields("module", "rpc"), e, nil, dbMock, cfg)
+
+			result, err := i.GetTxStatus(txHash)
+
+			require.Nil(t, err)
+			require.Equal(t, status.String(), result)
+
 			dbMock.AssertExpectations(t)
 			txMock.AssertExpectations(t)
-			ethTxManagerMock.AssertExpectations(t)
-		}
-
-		if !cfg.isL1ContractInMap {
-			fullNodeRPCs = config.FullNodeRPCs{}
-			executeTestFn()
+		})
+	}
 
-			return
-		}
+	t.Run("intake tx settling", func(t *testing.T) {
+		t.Parallel()
 
-		if !cfg.canBuildZKProof {
-			ethermanMock.On(
-				"BuildTrustedVerifyBatchesTxData",
-				uint64(tnx.LastVerifiedBatch),
-				uint64(tnx.NewVerifiedBatch),
-				mock.Anything,
-				uint32(1),
-			).Return(
-				[]byte{},
-				errors.New("error"),
-			).Once()
-
-			executeTestFn()
-
-			return
-		}
+		txHash := common.HexToHash("0xsomeTxHash")
 
-		ethermanMock.On(
-			"BuildTrustedVerifyBatchesTxData",
-			uint64(tnx.LastVerifiedBatch),
-			uint64(tnx.NewVerifiedBatch),
-			mock.Anything,
-			uint32(1),
-		).Return(
-			[]byte{1, 2},
-			nil,
-		).Once()
-
-		if !cfg.isZKProofValid {
-			ethermanMock.On(
-				"CallContract",
-				mock.Anything,
-				mock.Anything,
-				mock.Anything,
-			).Return(
-				[]byte{},
-				errors.New("error"),
-			).Once()
-
-			executeTestFn()
-
-			return
-		}
+		txMock := new(mocks.TxMock)
+		txMock.On("Rollback", mock.Anything).Return(nil).Once()
 
-		ethermanMock.On(
-			"CallContract",
-			mock.Anything,
-			mock.Anything,
-			mock.Anything,
-		).Return(
-			[]byte{1, 2},
-			nil,
-		).Once()
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+		dbMock.On("GetIntakeTx", mock.Anything, txHash, txMock).
+			Return(aggTypes.IntakeTx{Hash: txHash, Status: aggTypes.IntakeTxStatusSettling}, nil).Once()
 
-		if !cfg.isTxSigned {
-			executeTestFn()
+		txManagerMock := mocks.NewEthTxManagerMock(t)
+		txManagerMock.On("Result", mock.Anything, ethTxManOwner, txHash.Hex(), txMock).
+			Return(txmTypes.MonitoredTxResult{Status: txmTypes.MonitoredTxStatusSent}, nil).Once()
 
-			return
-		}
+		cfg := &config.Config{}
+		e := interop.New(
+			log.WithFields("module", "test"),
+			cfg,
+			common.HexToAddress("0xadmin"),
+			mocks.NewEthermanMock(t),
+			txManagerMock,
+		)
+		i := NewInteropEndpoints(log.WithFields("module", "rpc"), e, nil, dbMock, cfg)
 
-		privateKey, err := crypto.GenerateKey()
-		require.NoError(t, err)
+		result, err := i.GetTxStatus(txHash)
 
-		stx, err := tnx.Sign(privateKey)
-		require.NoError(t, err)
+		require.Nil(t, err)
+		require.Equal(t, "sent", result)
 
-		signedTx = stx
+		dbMock.AssertExpectations(t)
+		txMock.AssertExpectations(t)
+		txManagerMock.AssertExpectations(t)
+	})
+}
 
-		if !cfg.isAdminRetrieved {
-			ethermanMock.On(
-				"GetSequencerAddr",
-				uint32(1),
-			).Return(
-				common.Address{},
-				errors.New("error"),
-			).Once()
+func TestInteropEndpointsSendTx(t *testing.T) {
+	t.Parallel()
 
-			executeTestFn()
+	tnx := tx.Tx{
+		LastVerifiedBatch: agglayerTypes.ArgUint64(1),
+		NewVerifiedBatch:  *agglayerTypes.ArgUint64Ptr(2),
+		ZKP: tx.ZKP{
+			NewStateRoot:     common.BigToHash(big.NewInt(11)),
+			NewLocalExitRoot: common.BigToHash(big.NewInt(11)),
+		},
+		RollupID: 1,
+	}
+	tnx.ZKP.Proof = make([]byte, 24*32)
 
-			return
-		}
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
 
-		if !cfg.isSignerValid {
-			ethermanMock.On(
-				"GetSequencerAddr",
-				uint32(1),
-			).Return(
-				common.BytesToAddress([]byte{1, 2, 3, 4}),
-				nil,
-			).Once()
+	signedTnx, err := tnx.Sign(privateKey)
+	require.NoError(t, err)
 
-			executeTestFn()
+	newEndpoints := func(t *testing.T, fullNodeRPCs config.FullNodeRPCs, dbMock *mocks.DBMock) *InteropEndpoints {
+		t.Helper()
 
-			return
-		}
+		ethermanMock := mocks.NewEthermanMock(t)
+		ethermanMock.On("GetCachedSequencerAddr", tnx.RollupID).Return(common.Address{}, false).Maybe()
 
-		ethermanMock.On(
-			"GetSequencerAddr",
-			uint32(1),
-		).Return(
-			crypto.PubkeyToAddress(privateKey.PublicKey),
-			nil,
-		).Once()
-
-		zkEVMClientCreatorMock.On(
-			"NewClient",
-			mock.Anything,
-		).Return(
-			zkEVMClientMock,
+		c := &config.Config{FullNodeRPCs: fullNodeRPCs}
+		e := interop.New(
+			log.WithFields("module", "test"),
+			c,
+			common.HexToAddress("0xadmin"),
+			ethermanMock,
+			mocks.NewEthTxManagerMock(t),
 		)
+		p := interop.NewPipeline(log.WithFields("module", "test"), c, e, dbMock)
 
-		if !cfg.canGetBatch {
-			zkEVMClientMock.On(
-				"BatchByNumber",
-				mock.Anything,
-				big.NewInt(int64(signedTx.Tx.NewVerifiedBatch)),
-			).Return(
-				nil,
-				errors.New("error"),
-			).Once()
-
-			executeTestFn()
+		return NewInteropEndpoints(log.WithFields("module", "rpc"), e, p, dbMock, c)
+	}
 
-			return
-		}
+	intakeTxFor := func(signedTx tx.SignedTx) interface{} {
+		return mock.MatchedBy(func(itx aggTypes.IntakeTx) bool {
+			return itx.Hash == signedTx.Tx.Hash() &&
+				itx.Status == aggTypes.IntakeTxStatusReceived &&
+				itx.SignedTx.Tx.RollupID == signedTx.Tx.RollupID
+		})
+	}
 
-		if !cfg.isBatchValid {
-			zkEVMClientMock.On(
-				"BatchByNumber",
-				mock.Anything,
-				big.NewInt(int64(signedTx.Tx.NewVerifiedBatch)),
-			).Return(
-				&validiumTypes.Batch{
-					StateRoot: common.BigToHash(big.NewInt(12)),
-				},
-				nil,
-			).Once()
+	t.Run("don't have given contract in map", func(t *testing.T) {
+		t.Parallel()
 
-			executeTestFn()
+		dbMock := mocks.NewDBMock(t)
+		i := newEndpoints(t, config.FullNodeRPCs{}, dbMock)
 
-			return
-		}
+		result, err := i.SendTx(nil, *signedTnx)
 
-		zkEVMClientMock.On(
-			"BatchByNumber",
-			mock.Anything,
-			big.NewInt(int64(signedTx.Tx.NewVerifiedBatch)),
-		).Return(
-			&validiumTypes.Batch{
-				StateRoot:     common.BigToHash(big.NewInt(11)),
-				LocalExitRoot: common.BigToHash(big.NewInt(11)),
-			},
-			nil,
-		).Once()
+		require.Equal(t, "0x0", result)
+		require.Equal(t, agglayerTypes.ErrorCodeUnknownRollup, err.ErrorCode())
+		require.ErrorContains(t, err, "there is no RPC registered")
+
+		var data agglayerTypes.ErrorData
+		require.NotNil(t, err.ErrorData())
+		require.NoError(t, json.Unmarshal(*err.ErrorData(), &data))
+		require.Equal(t, signedTnx.Tx.RollupID, *data.RollupID)
+		require.Equal(t, signedTnx.Tx.Hash(), *data.TxHash)
+		require.Contains(t, data.Reason, "there is no RPC registered")
+	})
 
-		if !cfg.isDbTxOpen {
-			dbMock.On(
-				"BeginStateTransaction",
-				mock.Anything,
-			).Return(
-				nil,
-				errors.New("error"),
-			).Once()
+	t.Run("failed to add tx to the intake queue", func(t *testing.T) {
+		t.Parallel()
 
-			executeTestFn()
+		signedTx := *signedTnx
 
-			return
-		}
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("AddIntakeTx", mock.Anything, intakeTxFor(signedTx), nil).
+			Return(errors.New("error")).Once()
 
-		dbMock.On(
-			"BeginStateTransaction",
-			mock.Anything,
-		).Return(
-			txMock,
-			nil,
-		).Once()
-
-		if !cfg.isTxAddedToEthTxMan {
-			ethTxManagerMock.On(
-				"Add",
-				mock.Anything,
-				ethTxManOwner,
-				signedTx.Tx.Hash().Hex(),
-				mock.Anything,
-				mock.Anything,
-				mock.Anything,
-				mock.Anything,
-				mock.Anything,
-				txMock,
-			).Return(
-				errors.New("error"),
-			).Once()
-
-			txMock.On(
-				"Rollback",
-				mock.Anything,
-			).Return(
-				nil,
-			).Once()
-
-			ethermanMock.On(
-				"BuildTrustedVerifyBatchesTxData",
-				uint64(tnx.LastVerifiedBatch),
-				uint64(tnx.NewVerifiedBatch),
-				mock.Anything,
-				uint32(1),
-			).Return(
-				[]byte{1, 2},
-				nil,
-			).Once()
-
-			executeTestFn()
-
-			return
-		}
+		i := newEndpoints(t, config.FullNodeRPCs{1: {"someRPC"}}, dbMock)
 
-		ethTxManagerMock.On(
-			"Add",
-			mock.Anything,
-			ethTxManOwner,
-			signedTx.Tx.Hash().Hex(),
-			mock.Anything,
-			mock.Anything,
-			mock.Anything,
-			mock.Anything,
-			mock.Anything,
-			txMock,
-		).Return(
-			nil,
-		).Once()
-
-		if !cfg.isTxCommitted {
-			txMock.On(
-				"Commit",
-				mock.Anything,
-			).Return(
-				errors.New("error"),
-			).Once()
-
-			ethermanMock.On(
-				"BuildTrustedVerifyBatchesTxData",
-				uint64(tnx.LastVerifiedBatch),
-				uint64(tnx.NewVerifiedBatch),
-				mock.Anything,
-				uint32(1),
-			).Return(
-				[]byte{1, 2},
-				nil,
-			).Once()
-
-			executeTestFn()
-
-			return
-		}
+		result, err := i.SendTx(nil, signedTx)
 
-		ethermanMock.On(
-			"BuildTrustedVerifyBatchesTxData",
-			uint64(tnx.LastVerifiedBatch),
-			uint64(tnx.NewVerifiedBatch),
-			mock.Anything,
-			uint32(1),
-		).Return(
-			[]byte{1, 2},
-			nil,
-		).Once()
-
-		txMock.On(
-			"Commit",
-			mock.Anything,
-		).Return(
-			nil,
-		).Once()
-
-		executeTestFn()
-	}
+		require.Equal(t, "0x0", result)
+		require.Equal(t, agglayerTypes.ErrorCodeDB, err.ErrorCode())
+		require.ErrorContains(t, err, "failed to add tx to the intake queue")
+	})
 
-	t.Run("don't have given contract in map", func(t *testing.T) {
+	t.Run("tx already in the intake queue", func(t *testing.T) {
 		t.Parallel()
 
-		testFn(testConfig{
-			isL1ContractInMap: false,
-			expectedError:     "there is no RPC registered",
-		})
+		signedTx := *signedTnx
+
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("AddIntakeTx", mock.Anything, intakeTxFor(signedTx), nil).
+			Return(aggTypes.ErrIntakeTxAlreadyExists).Once()
+
+		i := newEndpoints(t, config.FullNodeRPCs{1: {"someRPC"}}, dbMock)
+
+		result, err := i.SendTx(nil, signedTx)
+
+		require.Nil(t, err)
+		require.Equal(t, signedTx.Tx.Hash(), result)
 	})
 
-	t.Run("could not build verified ZKP tx data", func(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
 		t.Parallel()
 
-		testFn(testConfig{
-			isL1ContractInMap: true,
-			canBuildZKProof:   false,
-			expectedError:     "failed to build verify ZKP tx",
-		})
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+
+		signedTx, err := tnx.Sign(privateKey)
+		require.NoError(t, err)
+
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("AddIntakeTx", mock.Anything, intakeTxFor(*signedTx), nil).
+			Return(nil).Once()
+
+		i := newEndpoints(t, config.FullNodeRPCs{1: {"someRPC"}}, dbMock)
+
+		result, rpcErr := i.SendTx(nil, *signedTx)
+
+		require.Nil(t, rpcErr)
+		require.Equal(t, signedTx.Tx.Hash(), result)
 	})
 
-	t.Run("could not verified ZKP", func(t *testing.T) {
+	t.Run("rejects txs without credentials scoped to their rollup", func(t *testing.T) {
 		t.Parallel()
 
-		testFn(testConfig{
-			isL1ContractInMap: true,
-			canBuildZKProof:   true,
-			isZKProofValid:    false,
-			expectedError:     "failed to call verify ZKP response",
+		signedTx := *signedTnx
+
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("AddIntakeTx", mock.Anything, intakeTxFor(signedTx), nil).
+			Return(nil).Once()
+
+		i := newEndpoints(t, config.FullNodeRPCs{1: {"someRPC"}}, dbMock)
+		i.auth = newSendTxAuth(config.AuthConfig{
+			Enabled: true,
+			APIKeys: []config.RollupAPIKey{{RollupID: 1, Key: "key-1"}, {RollupID: 2, Key: "key-2"}},
 		})
+
+		requestWithKey := func(key string) *http.Request {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.Header.Set("Authorization", "Bearer "+key)
+
+			return r
+		}
+
+		result, rpcErr := i.SendTx(nil, signedTx)
+		require.Equal(t, "0x0", result)
+		require.Equal(t, jRPC.AccessDeniedCode, rpcErr.ErrorCode())
+
+		result, rpcErr = i.SendTx(requestWithKey("key-2"), signedTx)
+		require.Equal(t, "0x0", result)
+		require.Equal(t, jRPC.AccessDeniedCode, rpcErr.ErrorCode())
+		require.ErrorContains(t, rpcErr, ErrRollupNotAllowed.Error())
+
+		result, rpcErr = i.SendTx(requestWithKey("key-1"), signedTx)
+		require.Nil(t, rpcErr)
+		require.Equal(t, signedTx.Tx.Hash(), result)
 	})
+}
+
+func TestInteropEndpointsSimulateTx(t *testing.T) {
+	t.Parallel()
+
+	signedTx := tx.SignedTx{Tx: tx.Tx{RollupID: 1, LastVerifiedBatch: 1, NewVerifiedBatch: 2}}
 
-	t.Run("could not get signer", func(t *testing.T) {
+	t.Run("requires credentials scoped to the rollup", func(t *testing.T) {
 		t.Parallel()
 
-		testFn(testConfig{
-			isL1ContractInMap: true,
-			canBuildZKProof:   true,
-			isZKProofValid:    true,
-			isTxSigned:        false,
-			expectedError:     "failed to get signer",
-		})
+		c := &config.Config{Auth: config.AuthConfig{
+			Enabled: true,
+			APIKeys: []config.RollupAPIKey{{RollupID: 2, Key: "key-2"}},
+		}}
+		i := NewInteropEndpoints(log.WithFields("module", "rpc"), nil, nil, mocks.NewDBMock(t), c)
+
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set("Authorization", "Bearer key-2")
+
+		result, err := i.SimulateTx(r, signedTx)
+
+		require.Equal(t, "0x0", result)
+		require.Equal(t, agglayerTypes.ErrorCodeAccessDenied, err.ErrorCode())
 	})
 
-	t.Run("failed to get admin from L1", func(t *testing.T) {
+	t.Run("reports the failed steps without queueing the tx", func(t *testing.T) {
 		t.Parallel()
 
-		testFn(testConfig{
-			isL1ContractInMap: true,
-			canBuildZKProof:   true,
-			isZKProofValid:    true,
-			isTxSigned:        true,
-			isAdminRetrieved:  false,
-			expectedError:     "failed to get admin from L1",
-		})
+		// the db isn't expected to be called
+		dbMock := mocks.NewDBMock(t)
+
+		c := &config.Config{}
+		e := interop.New(
+			log.WithFields("module", "test"),
+			c,
+			common.HexToAddress("0xadmin"),
+			mocks.NewEthermanMock(t),
+			mocks.NewEthTxManagerMock(t),
+		)
+		i := NewInteropEndpoints(log.WithFields("module", "rpc"), e, nil, dbMock, c)
+
+		result, err := i.SimulateTx(nil, signedTx)
+
+		require.Nil(t, err)
+		sim, ok := result.(aggTypes.TxSimulation)
+		require.True(t, ok)
+		require.False(t, sim.Success)
+		require.Len(t, sim.Steps, 1)
+		require.Equal(t, aggTypes.SimulationStepPrevalidate, sim.Steps[0].Step)
+		require.Equal(t, agglayerTypes.ErrorCodeInvalidParams, sim.Steps[0].ErrorCode)
 	})
+}
+
+func TestInteropEndpointsGetTxDetails(t *testing.T) {
+	t.Parallel()
+
+	newEndpoints := func(t *testing.T, cfg *config.Config, dbMock *mocks.DBMock) *InteropEndpoints {
+		t.Helper()
 
-	t.Run("unexpected signer", func(t *testing.T) {
+		e := interop.New(
+			log.WithFields("module", "test"),
+			cfg,
+			common.HexToAddress("0xadmin"),
+			mocks.NewEthermanMock(t),
+			mocks.NewEthTxManagerMock(t),
+		)
+
+		return NewInteropEndpoints(log.WithFields("module", "rpc"), e, nil, dbMock, cfg)
+	}
+
+	t.Run("tx not found", func(t *testing.T) {
 		t.Parallel()
 
-		testFn(testConfig{
-			isL1ContractInMap: true,
-			canBuildZKProof:   true,
-			isZKProofValid:    true,
-			isTxSigned:        true,
-			isAdminRetrieved:  true,
-			isSignerValid:     false,
-			expectedError:     "unexpected signer",
-		})
-	})
+		txHash := common.HexToHash("0xsomeTxHash")
 
-	t.Run("error on batch retrieval", func(t *testing.T) {
-		testFn(testConfig{
-			isL1ContractInMap: true,
-			canBuildZKProof:   true,
-			isZKProofValid:    true,
-			isTxSigned:        true,
-			isAdminRetrieved:  true,
-			isSignerValid:     true,
-			canGetBatch:       false,
-			expectedError:     "failed to get batch from our node",
-		})
+		txMock := new(mocks.TxMock)
+		txMock.On("Rollback", mock.Anything).Return(nil).Once()
+
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+		dbMock.On("GetIntakeTx", mock.Anything, txHash, txMock).
+			Return(aggTypes.IntakeTx{}, aggTypes.ErrIntakeTxNotFound).Once()
+
+		result, err := newEndpoints(t, &config.Config{}, dbMock).GetTxDetails(txHash)
+
+		require.Equal(t, "0x0", result)
+		require.Equal(t, agglayerTypes.ErrorCodeTxNotFound, err.ErrorCode())
+		require.ErrorContains(t, err, "not found")
+
+		txMock.AssertExpectations(t)
 	})
 
-	t.Run("unexpected batch", func(t *testing.T) {
-		testFn(testConfig{
-			isL1ContractInMap: true,
-			canBuildZKProof:   true,
-			isZKProofValid:    true,
-			isTxSigned:        true,
-			isAdminRetrieved:  true,
-			isSignerValid:     true,
-			canGetBatch:       true,
-			isBatchValid:      false,
-			expectedError:     "mismatch detected",
-		})
+	t.Run("verified tx", func(t *testing.T) {
+		t.Parallel()
+
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+
+		txn := tx.Tx{RollupID: 1, LastVerifiedBatch: 1, NewVerifiedBatch: 2, ZKP: tx.ZKP{Proof: []byte{1}}}
+		signedTx, err := txn.SignTypedData(privateKey, tx.SigningDomain{})
+		require.NoError(t, err)
+
+		itx := aggTypes.NewIntakeTx(*signedTx)
+		itx.Status = aggTypes.IntakeTxStatusVerified
+		itx.VerifiedAt = &itx.UpdatedAt
+
+		txMock := new(mocks.TxMock)
+		txMock.On("Rollback", mock.Anything).Return(nil).Once()
+
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+		dbMock.On("GetIntakeTx", mock.Anything, itx.Hash, txMock).Return(itx, nil).Once()
+
+		cfg := &config.Config{ProofSigners: config.ProofSigners{1: crypto.PubkeyToAddress(privateKey.PublicKey)}}
+		result, rpcErr := newEndpoints(t, cfg, dbMock).GetTxDetails(itx.Hash)
+		require.Nil(t, rpcErr)
+
+		// the details are decoded by the client from their JSON encoding
+		encoded, err := json.Marshal(result)
+		require.NoError(t, err)
+
+		var details aggTypes.TxDetails
+		require.NoError(t, json.Unmarshal(encoded, &details))
+
+		require.Equal(t, itx.Hash, details.Hash)
+		require.Equal(t, txn, details.Tx)
+		require.Equal(t, crypto.PubkeyToAddress(privateKey.PublicKey), details.Signer)
+		require.Equal(t, "verified", details.Status)
+		require.Equal(t, aggTypes.TxOutcomePending, details.Outcome)
+		require.True(t, itx.ReceivedAt.Equal(details.Stages.ReceivedAt))
+		require.NotNil(t, details.Stages.VerifiedAt)
+		require.Nil(t, details.Settlement)
+
+		txMock.AssertExpectations(t)
 	})
+}
 
-	t.Run("failed to begin dbTx", func(t *testing.T) {
-		testFn(testConfig{
-			isL1ContractInMap: true,
-			canBuildZKProof:   true,
-			isZKProofValid:    true,
-			isTxSigned:        true,
-			isAdminRetrieved:  true,
-			isSignerValid:     true,
-			canGetBatch:       true,
-			isBatchValid:      true,
-			isDbTxOpen:        false,
-			expectedError:     "failed to begin dbTx",
-		})
+func TestInteropEndpointsListTxs(t *testing.T) {
+	t.Parallel()
+
+	newEndpoints := func(t *testing.T, dbMock *mocks.DBMock, txManagerMock *mocks.EthTxManagerMock) *InteropEndpoints {
+		t.Helper()
+
+		cfg := &config.Config{}
+		e := interop.New(
+			log.WithFields("module", "test"),
+			cfg,
+			common.HexToAddress("0xadmin"),
+			mocks.NewEthermanMock(t),
+			txManagerMock,
+		)
+
+		return NewInteropEndpoints(log.WithFields("module", "rpc"), e, nil, dbMock, cfg)
+	}
+
+	t.Run("invalid filter", func(t *testing.T) {
+		t.Parallel()
+
+		i := newEndpoints(t, mocks.NewDBMock(t), mocks.NewEthTxManagerMock(t))
+
+		result, err := i.ListTxs(aggTypes.TxListFilter{Statuses: []string{"unknown"}})
+
+		require.Equal(t, "0x0", result)
+		require.Equal(t, jRPC.InvalidParamsErrorCode, err.ErrorCode())
 	})
 
-	t.Run("failed to add tx to ethTxMan", func(t *testing.T) {
-		testFn(testConfig{
-			isL1ContractInMap:   true,
-			canBuildZKProof:     true,
-			isZKProofValid:      true,
-			isTxSigned:          true,
-			isAdminRetrieved:    true,
-			isSignerValid:       true,
-			canGetBatch:         true,
-			isBatchValid:        true,
-			isDbTxOpen:          true,
-			isTxAddedToEthTxMan: false,
-			expectedError:       "failed to add tx to ethTxMan",
-		})
+	t.Run("invalid cursor", func(t *testing.T) {
+		t.Parallel()
+
+		txMock := new(mocks.TxMock)
+		txMock.On("Rollback", mock.Anything).Return(nil).Once()
+
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+
+		result, err := newEndpoints(t, dbMock, mocks.NewEthTxManagerMock(t)).ListTxs(aggTypes.TxListFilter{Cursor: "!"})
+
+		require.Equal(t, "0x0", result)
+		require.Equal(t, jRPC.InvalidParamsErrorCode, err.ErrorCode())
+
+		txMock.AssertExpectations(t)
 	})
 
-	t.Run("failed to commit tx", func(t *testing.T) {
-		testFn(testConfig{
-			isL1ContractInMap:   true,
-			canBuildZKProof:     true,
-			isZKProofValid:      true,
-			isTxSigned:          true,
-			isAdminRetrieved:    true,
-			isSignerValid:       true,
-			canGetBatch:         true,
-			isBatchValid:        true,
-			isDbTxOpen:          true,
-			isTxAddedToEthTxMan: true,
-			isTxCommitted:       false,
-			expectedError:       "failed to commit dbTx",
-		})
+	t.Run("returns a page", func(t *testing.T) {
+		t.Parallel()
+
+		txMock := new(mocks.TxMock)
+		txMock.On("Rollback", mock.Anything).Return(nil).Once()
+
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+
+		txHash := common.HexToHash("0x1")
+		txManagerMock := mocks.NewEthTxManagerMock(t)
+		txManagerMock.On("List", mock.Anything, mock.Anything, txMock).
+			Return([]txmTypes.MonitoredTx{{ID: txHash.Hex(), Status: txmTypes.MonitoredTxStatusDone}}, nil, nil).Once()
+
+		result, rpcErr := newEndpoints(t, dbMock, txManagerMock).ListTxs(aggTypes.TxListFilter{})
+		require.Nil(t, rpcErr)
+
+		list, ok := result.(aggTypes.TxList)
+		require.True(t, ok)
+		require.Len(t, list.Txs, 1)
+		require.Equal(t, txHash, list.Txs[0].Hash)
+		require.Empty(t, list.NextCursor)
+
+		txMock.AssertExpectations(t)
 	})
+}
 
-	t.Run("happy path", func(t *testing.T) {
-		testFn(testConfig{
-			isL1ContractInMap:   true,
-			canBuildZKProof:     true,
-			isZKProofValid:      true,
-			isTxSigned:          true,
-			isAdminRetrieved:    true,
-			isSignerValid:       true,
-			canGetBatch:         true,
-			isBatchValid:        true,
-			isDbTxOpen:          true,
-			isTxAddedToEthTxMan: true,
-			isTxCommitted:       true,
-		})
+func TestInteropEndpointsGetRollupState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown rollup", func(t *testing.T) {
+		t.Parallel()
+
+		txMock := new(mocks.TxMock)
+		txMock.On("Rollback", mock.Anything).Return(nil).Once()
+
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("BeginStateTransaction", mock.Anything).Return(txMock, nil).Once()
+
+		ethermanMock := mocks.NewEthermanMock(t)
+		ethermanMock.On("GetRollupL1State", uint32(7)).
+			Return(aggTypes.RollupL1State{}, aggTypes.ErrUnknownRollup).Once()
+
+		cfg := &config.Config{}
+		e := interop.New(
+			log.WithFields("module", "test"),
+			cfg,
+			common.HexToAddress("0xadmin"),
+			ethermanMock,
+			mocks.NewEthTxManagerMock(t),
+		)
+		i := NewInteropEndpoints(log.WithFields("module", "rpc"), e, nil, dbMock, cfg)
+
+		result, err := i.GetRollupState(7)
+
+		require.Equal(t, "0x0", result)
+		require.Equal(t, agglayerTypes.ErrorCodeUnknownRollup, err.ErrorCode())
+		require.ErrorContains(t, err, "rollup 7 not found")
+
+		txMock.AssertExpectations(t)
 	})
 }
@@ -0,0 +1,260 @@
+package rpc
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+
+	"github.com/0xPolygon/agglayer/config"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/0xPolygon/agglayer/types"
+)
+
+const (
+	// statusChangesBuffer is the number of status changes waiting to be pushed to the subscribers
+	statusChangesBuffer = 1000
+	// rollupLookupTimeout bounds the lookup of the rollup of a tx whose status changed
+	rollupLookupTimeout = 5 * time.Second
+)
+
+// ErrTooManySubscriptions when a connection reached its maximum number of subscriptions
+var ErrTooManySubscriptions = errors.New("too many subscriptions")
+
+// Subscription receives the status changes selected by its filter. Its channel is closed
+// once unsubscribed or when it falls behind and is dropped
+type Subscription struct {
+	ID     string
+	Filter types.TxStatusFilter
+
+	changes chan types.TxStatusChange
+}
+
+// Changes returns the channel the status changes are pushed to
+func (s *Subscription) Changes() <-chan types.TxStatusChange {
+	return s.changes
+}
+
+// StatusFeed fans out the status changes persisted by the intake pipeline and by the eth tx manager
+// to the interop_subscribe subscribers
+type StatusFeed struct {
+	logger *zap.SugaredLogger
+	db     types.IDB
+	buffer int
+
+	changes       chan txmTypes.MonitoredTx
+	intakeChanges chan types.TxStatusChange
+
+	mu   sync.RWMutex
+	subs map[string]*Subscription
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewStatusFeed returns a feed resolving the rollup of the txs from the intake queue
+func NewStatusFeed(logger *zap.SugaredLogger, cfg config.WebSocketConfig, db types.IDB) *StatusFeed {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	buffer := cfg.SubscriptionBuffer
+	if buffer <= 0 {
+		buffer = 1
+	}
+
+	return &StatusFeed{
+		logger:        logger,
+		db:            db,
+		buffer:        buffer,
+		changes:       make(chan txmTypes.MonitoredTx, statusChangesBuffer),
+		intakeChanges: make(chan types.TxStatusChange, statusChangesBuffer),
+		subs:          make(map[string]*Subscription),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start pushes the status changes to the subscribers until the feed is stopped
+func (f *StatusFeed) Start() {
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case mTx := <-f.changes:
+			change, rollupKnown := f.newStatusChange(mTx)
+			f.publish(change, rollupKnown)
+		case change := <-f.intakeChanges:
+			f.publish(change, true)
+		}
+	}
+}
+
+// Stop stops pushing status changes and drops all the subscriptions
+func (f *StatusFeed) Stop() {
+	f.cancel()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for id, sub := range f.subs {
+		delete(f.subs, id)
+		close(sub.changes)
+	}
+}
+
+// OnStatusChange queues the status change of a monitored tx, it never blocks the eth tx manager
+func (f *StatusFeed) OnStatusChange(mTx txmTypes.MonitoredTx) {
+	if mTx.Owner != ethTxManOwner {
+		return
+	}
+
+	select {
+	case f.changes <- mTx:
+	default:
+		f.logger.Warnf("status changes queue is full, dropping status %s of tx %s", mTx.Status, mTx.ID)
+	}
+}
+
+// OnIntakeStatusChange queues the status change of a tx in the intake queue, it never blocks the pipeline
+func (f *StatusFeed) OnIntakeStatusChange(itx types.IntakeTx) {
+	change := types.TxStatusChange{
+		Hash:      itx.Hash,
+		RollupID:  itx.SignedTx.Tx.RollupID,
+		Status:    itx.Status.String(),
+		Error:     itx.Error,
+		ErrorCode: itx.ErrorCode,
+		UpdatedAt: time.Now().UTC().Round(time.Microsecond),
+	}
+
+	select {
+	case f.intakeChanges <- change:
+	default:
+		f.logger.Warnf("status changes queue is full, dropping status %s of tx %s", itx.Status, itx.Hash.Hex())
+	}
+}
+
+// Subscribe registers a subscription for the status changes selected by the filter
+func (f *StatusFeed) Subscribe(filter types.TxStatusFilter) (*Subscription, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	id, err := newSubscriptionID()
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &Subscription{
+		ID:      id,
+		Filter:  filter,
+		changes: make(chan types.TxStatusChange, f.buffer),
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.ctx.Err() != nil {
+		return nil, errors.New("status feed stopped")
+	}
+	f.subs[id] = sub
+
+	return sub, nil
+}
+
+// Unsubscribe drops the subscription, returning whether it existed
+func (f *StatusFeed) Unsubscribe(id string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sub, ok := f.subs[id]
+	if !ok {
+		return false
+	}
+	delete(f.subs, id)
+	close(sub.changes)
+
+	return true
+}
+
+// publish pushes the status change to the matching subscribers, the ones falling behind are dropped.
+// Subscribers of a rollup are skipped if the rollup of the tx couldn't be resolved
+func (f *StatusFeed) publish(change types.TxStatusChange, rollupKnown bool) {
+	var slow []string
+
+	f.mu.RLock()
+	for id, sub := range f.subs {
+		if !sub.Filter.Matches(change) || (sub.Filter.RollupID != nil && !rollupKnown) {
+			continue
+		}
+
+		select {
+		case sub.changes <- change:
+		default:
+			slow = append(slow, id)
+		}
+	}
+	f.mu.RUnlock()
+
+	for _, id := range slow {
+		if f.Unsubscribe(id) {
+			f.logger.Warnf("subscription %s fell behind and was dropped", id)
+		}
+	}
+}
+
+// newStatusChange builds the status change of a monitored tx, whose ID is the hash of the tx it settles.
+// It returns whether the rollup of the tx was resolved
+func (f *StatusFeed) newStatusChange(mTx txmTypes.MonitoredTx) (types.TxStatusChange, bool) {
+	change := types.TxStatusChange{
+		Hash:      common.HexToHash(mTx.ID),
+		Status:    mTx.Status.String(),
+		UpdatedAt: time.Now().UTC().Round(time.Microsecond),
+	}
+	if mTx.BlockNumber != nil {
+		change.BlockNumber = (*hexutil.Big)(mTx.BlockNumber)
+	}
+
+	if !f.hasRollupSubscriptions() {
+		return change, false
+	}
+
+	ctx, cancel := context.WithTimeout(f.ctx, rollupLookupTimeout)
+	defer cancel()
+
+	// without its rollup, the change still reaches the subscribers of the tx
+	itx, err := f.db.GetIntakeTx(ctx, change.Hash, nil)
+	if err != nil {
+		f.logger.Errorf("failed to get the rollup of tx %s: %s", change.Hash.Hex(), err)
+		return change, false
+	}
+	change.RollupID = itx.SignedTx.Tx.RollupID
+
+	return change, true
+}
+
+func (f *StatusFeed) hasRollupSubscriptions() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for _, sub := range f.subs {
+		if sub.Filter.RollupID != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newSubscriptionID returns a random hex identifier
+func newSubscriptionID() (string, error) {
+	b := make([]byte, 16) //nolint:gomnd
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hexutil.Encode(b), nil
+}
@@ -0,0 +1,368 @@
+package rpc
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/agglayer/config"
+	"github.com/0xPolygon/agglayer/log"
+	"github.com/0xPolygon/agglayer/mocks"
+	"github.com/0xPolygon/agglayer/tx"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	aggTypes "github.com/0xPolygon/agglayer/types"
+)
+
+func newTestStatusFeed(t *testing.T, buffer int, dbMock *mocks.DBMock) *StatusFeed {
+	t.Helper()
+
+	feed := NewStatusFeed(log.WithFields("module", "test"), config.WebSocketConfig{SubscriptionBuffer: buffer}, dbMock)
+	go feed.Start()
+	t.Cleanup(feed.Stop)
+
+	return feed
+}
+
+func receiveChange(t *testing.T, sub *Subscription) aggTypes.TxStatusChange {
+	t.Helper()
+
+	select {
+	case change, ok := <-sub.Changes():
+		require.True(t, ok, "subscription was closed")
+		return change
+	case <-time.After(time.Second):
+		require.FailNow(t, "no status change received")
+	}
+
+	return aggTypes.TxStatusChange{}
+}
+
+func rollupIDPtr(id uint32) *uint32 {
+	return &id
+}
+
+func TestStatusFeed(t *testing.T) {
+	t.Parallel()
+
+	txHash := common.HexToHash("0x1")
+	otherTxHash := common.HexToHash("0x2")
+
+	t.Run("rejects a filter selecting neither or both a tx and a rollup", func(t *testing.T) {
+		t.Parallel()
+
+		feed := newTestStatusFeed(t, 1, mocks.NewDBMock(t))
+
+		_, err := feed.Subscribe(aggTypes.TxStatusFilter{})
+		require.ErrorIs(t, err, aggTypes.ErrInvalidTxStatusFilter)
+
+		_, err = feed.Subscribe(aggTypes.TxStatusFilter{TxHash: &txHash, RollupID: rollupIDPtr(1)})
+		require.ErrorIs(t, err, aggTypes.ErrInvalidTxStatusFilter)
+	})
+
+	t.Run("pushes the changes of the subscribed tx only", func(t *testing.T) {
+		t.Parallel()
+
+		feed := newTestStatusFeed(t, 10, mocks.NewDBMock(t))
+
+		sub, err := feed.Subscribe(aggTypes.TxStatusFilter{TxHash: &txHash})
+		require.NoError(t, err)
+
+		feed.OnStatusChange(txmTypes.MonitoredTx{Owner: ethTxManOwner, ID: otherTxHash.Hex(), Status: txmTypes.MonitoredTxStatusSent})
+		feed.OnStatusChange(txmTypes.MonitoredTx{Owner: "other", ID: txHash.Hex(), Status: txmTypes.MonitoredTxStatusSent})
+		feed.OnStatusChange(txmTypes.MonitoredTx{Owner: ethTxManOwner, ID: txHash.Hex(), Status: txmTypes.MonitoredTxStatusSent})
+		feed.OnStatusChange(txmTypes.MonitoredTx{
+			Owner:       ethTxManOwner,
+			ID:          txHash.Hex(),
+			Status:      txmTypes.MonitoredTxStatusConfirmed,
+			BlockNumber: big.NewInt(10),
+		})
+
+		change := receiveChange(t, sub)
+		require.Equal(t, txHash, change.Hash)
+		require.Equal(t, txmTypes.MonitoredTxStatusSent.String(), change.Status)
+		require.Nil(t, change.BlockNumber)
+
+		change = receiveChange(t, sub)
+		require.Equal(t, txmTypes.MonitoredTxStatusConfirmed.String(), change.Status)
+		require.Equal(t, int64(10), change.BlockNumber.ToInt().Int64())
+	})
+
+	t.Run("pushes the changes of the txs of the subscribed rollup", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("GetIntakeTx", mock.Anything, otherTxHash, nil).
+			Return(aggTypes.IntakeTx{SignedTx: tx.SignedTx{Tx: tx.Tx{RollupID: 2}}}, nil).Once()
+		dbMock.On("GetIntakeTx", mock.Anything, txHash, nil).
+			Return(aggTypes.IntakeTx{SignedTx: tx.SignedTx{Tx: tx.Tx{RollupID: 1}}}, nil).Once()
+
+		feed := newTestStatusFeed(t, 10, dbMock)
+
+		sub, err := feed.Subscribe(aggTypes.TxStatusFilter{RollupID: rollupIDPtr(1)})
+		require.NoError(t, err)
+
+		feed.OnStatusChange(txmTypes.MonitoredTx{Owner: ethTxManOwner, ID: otherTxHash.Hex(), Status: txmTypes.MonitoredTxStatusSent})
+		feed.OnStatusChange(txmTypes.MonitoredTx{Owner: ethTxManOwner, ID: txHash.Hex(), Status: txmTypes.MonitoredTxStatusSent})
+
+		change := receiveChange(t, sub)
+		require.Equal(t, txHash, change.Hash)
+		require.Equal(t, uint32(1), change.RollupID)
+	})
+
+	t.Run("pushes the intake changes, with the reason of a rejection", func(t *testing.T) {
+		t.Parallel()
+
+		feed := newTestStatusFeed(t, 10, mocks.NewDBMock(t))
+
+		sub, err := feed.Subscribe(aggTypes.TxStatusFilter{RollupID: rollupIDPtr(1)})
+		require.NoError(t, err)
+
+		itx := aggTypes.IntakeTx{Hash: txHash, SignedTx: tx.SignedTx{Tx: tx.Tx{RollupID: 1}}, Status: aggTypes.IntakeTxStatusReceived}
+		feed.OnIntakeStatusChange(itx)
+
+		itx.Status = aggTypes.IntakeTxStatusRejected
+		itx.Error = "invalid signature"
+		itx.ErrorCode = -32011
+		feed.OnIntakeStatusChange(itx)
+
+		change := receiveChange(t, sub)
+		require.Equal(t, txHash, change.Hash)
+		require.Equal(t, aggTypes.IntakeTxStatusReceived.String(), change.Status)
+		require.Empty(t, change.Error)
+
+		change = receiveChange(t, sub)
+		require.Equal(t, aggTypes.IntakeTxStatusRejected.String(), change.Status)
+		require.Equal(t, "invalid signature", change.Error)
+		require.Equal(t, -32011, change.ErrorCode)
+	})
+
+	t.Run("skips the subscribers of a rollup if the rollup of the tx is unknown", func(t *testing.T) {
+		t.Parallel()
+
+		dbMock := mocks.NewDBMock(t)
+		dbMock.On("GetIntakeTx", mock.Anything, txHash, nil).
+			Return(aggTypes.IntakeTx{}, errors.New("error")).Once()
+
+		feed := newTestStatusFeed(t, 10, dbMock)
+
+		rollupSub, err := feed.Subscribe(aggTypes.TxStatusFilter{RollupID: rollupIDPtr(0)})
+		require.NoError(t, err)
+		txSub, err := feed.Subscribe(aggTypes.TxStatusFilter{TxHash: &txHash})
+		require.NoError(t, err)
+
+		feed.OnStatusChange(txmTypes.MonitoredTx{Owner: ethTxManOwner, ID: txHash.Hex(), Status: txmTypes.MonitoredTxStatusSent})
+
+		require.Equal(t, txHash, receiveChange(t, txSub).Hash)
+		require.Empty(t, rollupSub.Changes())
+	})
+
+	t.Run("drops a subscription falling behind", func(t *testing.T) {
+		t.Parallel()
+
+		feed := newTestStatusFeed(t, 1, mocks.NewDBMock(t))
+
+		sub, err := feed.Subscribe(aggTypes.TxStatusFilter{TxHash: &txHash})
+		require.NoError(t, err)
+
+		feed.OnStatusChange(txmTypes.MonitoredTx{Owner: ethTxManOwner, ID: txHash.Hex(), Status: txmTypes.MonitoredTxStatusSent})
+		feed.OnStatusChange(txmTypes.MonitoredTx{Owner: ethTxManOwner, ID: txHash.Hex(), Status: txmTypes.MonitoredTxStatusConfirmed})
+
+		require.Eventually(t, func() bool {
+			feed.mu.RLock()
+			defer feed.mu.RUnlock()
+
+			_, ok := feed.subs[sub.ID]
+			return !ok
+		}, time.Second, 10*time.Millisecond)
+
+		change, ok := <-sub.Changes()
+		require.True(t, ok)
+		require.Equal(t, txmTypes.MonitoredTxStatusSent.String(), change.Status)
+
+		_, ok = <-sub.Changes()
+		require.False(t, ok)
+	})
+
+	t.Run("unsubscribe closes the subscription", func(t *testing.T) {
+		t.Parallel()
+
+		feed := newTestStatusFeed(t, 1, mocks.NewDBMock(t))
+
+		sub, err := feed.Subscribe(aggTypes.TxStatusFilter{TxHash: &txHash})
+		require.NoError(t, err)
+
+		require.True(t, feed.Unsubscribe(sub.ID))
+		require.False(t, feed.Unsubscribe(sub.ID))
+
+		_, ok := <-sub.Changes()
+		require.False(t, ok)
+	})
+}
+
+func TestWebSocketServer(t *testing.T) {
+	t.Parallel()
+
+	txHash := common.HexToHash("0x1")
+
+	feed := newTestStatusFeed(t, 10, mocks.NewDBMock(t))
+	s := NewWebSocketServer(log.WithFields("module", "test"), config.WebSocketConfig{MaxSubscriptionsPerConn: 1}, feed)
+
+	srv := httptest.NewServer(s.mux())
+	t.Cleanup(srv.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	call := func(method string, params ...interface{}) map[string]interface{} {
+		t.Helper()
+
+		require.NoError(t, conn.WriteJSON(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"method":  method,
+			"params":  params,
+		}))
+
+		var res map[string]interface{}
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		require.NoError(t, conn.ReadJSON(&res))
+
+		return res
+	}
+
+	res := call("interop_subscribe", aggTypes.TxStatusFilter{})
+	require.NotNil(t, res["error"])
+
+	res = call("interop_sendTx")
+	require.NotNil(t, res["error"])
+
+	res = call("interop_subscribe", aggTypes.TxStatusFilter{TxHash: &txHash})
+	require.Nil(t, res["error"])
+	id, ok := res["result"].(string)
+	require.True(t, ok)
+
+	res = call("interop_subscribe", aggTypes.TxStatusFilter{TxHash: &txHash})
+	require.NotNil(t, res["error"])
+
+	feed.OnStatusChange(txmTypes.MonitoredTx{Owner: ethTxManOwner, ID: txHash.Hex(), Status: txmTypes.MonitoredTxStatusConfirmed})
+
+	var notification struct {
+		Method string `json:"method"`
+		Params struct {
+			Subscription string                  `json:"subscription"`
+			Result       aggTypes.TxStatusChange `json:"result"`
+		} `json:"params"`
+	}
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	require.NoError(t, conn.ReadJSON(&notification))
+	require.Equal(t, "interop_subscription", notification.Method)
+	require.Equal(t, id, notification.Params.Subscription)
+	require.Equal(t, txHash, notification.Params.Result.Hash)
+	require.Equal(t, txmTypes.MonitoredTxStatusConfirmed.String(), notification.Params.Result.Status)
+
+	res = call("interop_unsubscribe", id)
+	require.Equal(t, true, res["result"])
+
+	res = call("interop_unsubscribe", id)
+	require.Equal(t, false, res["result"])
+}
+
+func TestWebSocketServer_Limits(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(t *testing.T, cfg config.WebSocketConfig) string {
+		t.Helper()
+
+		s := NewWebSocketServer(log.WithFields("module", "test"), cfg, newTestStatusFeed(t, 10, mocks.NewDBMock(t)))
+		srv := httptest.NewServer(s.mux())
+		t.Cleanup(srv.Close)
+
+		return "ws" + strings.TrimPrefix(srv.URL, "http")
+	}
+
+	dial := func(t *testing.T, url, origin string) (*websocket.Conn, *http.Response, error) {
+		t.Helper()
+
+		header := http.Header{}
+		if origin != "" {
+			header.Set("Origin", origin)
+		}
+
+		conn, res, err := websocket.DefaultDialer.Dial(url, header)
+		if err == nil {
+			t.Cleanup(func() { conn.Close() })
+		}
+
+		return conn, res, err
+	}
+
+	t.Run("rejects other origins by default", func(t *testing.T) {
+		t.Parallel()
+
+		url := newServer(t, config.WebSocketConfig{})
+
+		_, _, err := dial(t, url, "")
+		require.NoError(t, err)
+
+		_, res, err := dial(t, url, "http://evil.example")
+		require.Error(t, err)
+		require.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("accepts the allowed origins", func(t *testing.T) {
+		t.Parallel()
+
+		url := newServer(t, config.WebSocketConfig{AllowedOrigins: []string{"https://app.example"}})
+
+		_, _, err := dial(t, url, "https://app.example")
+		require.NoError(t, err)
+
+		_, res, err := dial(t, url, "http://evil.example")
+		require.Error(t, err)
+		require.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("caps the connections", func(t *testing.T) {
+		t.Parallel()
+
+		url := newServer(t, config.WebSocketConfig{MaxConnections: 1})
+
+		conn, _, err := dial(t, url, "")
+		require.NoError(t, err)
+
+		_, res, err := dial(t, url, "")
+		require.Error(t, err)
+		require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+
+		// the slot is released once the connection is closed
+		require.NoError(t, conn.Close())
+		require.Eventually(t, func() bool {
+			_, _, err := dial(t, url, "")
+			return err == nil
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("closes the connections sending messages above the read limit", func(t *testing.T) {
+		t.Parallel()
+
+		url := newServer(t, config.WebSocketConfig{ReadLimit: 64})
+
+		conn, _, err := dial(t, url, "")
+		require.NoError(t, err)
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(strings.Repeat("a", 128))))
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		_, _, err = conn.ReadMessage()
+		require.True(t, websocket.IsCloseError(err, websocket.CloseMessageTooBig), err)
+	})
+}
@@ -0,0 +1,130 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Error codes returned by the interop endpoints, on top of the standard JSON-RPC ones
+const (
+	// ErrorCodeInternal is an unexpected failure of the agglayer
+	ErrorCodeInternal = -32000
+	// ErrorCodeUnknownRollup when the rollup isn't registered in the rollup manager or configured in the agglayer
+	ErrorCodeUnknownRollup = -32010
+	// ErrorCodeInvalidSignature when the signer of the tx can't be recovered
+	ErrorCodeInvalidSignature = -32011
+	// ErrorCodeUnauthorizedSigner when the tx isn't signed by the proof signer or the trusted sequencer of its rollup
+	ErrorCodeUnauthorizedSigner = -32012
+	// ErrorCodeProofRejected when the rollup manager rejects the ZKP of the tx
+	ErrorCodeProofRejected = -32013
+	// ErrorCodeStateRootMismatch when the roots of the tx don't match the batch of the full nodes
+	ErrorCodeStateRootMismatch = -32014
+	// ErrorCodeFullNodeUnavailable when the full nodes of the rollup can't tell whether the tx is sound
+	ErrorCodeFullNodeUnavailable = -32015
+	// ErrorCodeSettlementQueue when the tx can't be queued for settlement, its batch range included
+	ErrorCodeSettlementQueue = -32016
+	// ErrorCodeDB when the database fails
+	ErrorCodeDB = -32017
+	// ErrorCodeTxNotFound when the tx isn't known by the agglayer
+	ErrorCodeTxNotFound = -32018
+	// ErrorCodeFullNodeDivergence when the full nodes of the rollup return different batches and not enough of them agree
+	ErrorCodeFullNodeDivergence = -32019
+	// ErrorCodeInvalidPendingState when the pending state of the tx doesn't exist on L1 or doesn't end at its last verified batch
+	ErrorCodeInvalidPendingState = -32020
+	// ErrorCodeInvalidParams when the params are malformed, the proof and the batch range of a tx included
+	ErrorCodeInvalidParams = -32602
+	// ErrorCodeAccessDenied when the caller isn't allowed to send the txs of the rollup
+	ErrorCodeAccessDenied = -32800
+)
+
+var (
+	// ErrInternal is matched by the errors with the ErrorCodeInternal code
+	ErrInternal = errors.New("internal error")
+	// ErrUnknownRollup is matched by the errors with the ErrorCodeUnknownRollup code
+	ErrUnknownRollup = errors.New("unknown rollup")
+	// ErrInvalidSignature is matched by the errors with the ErrorCodeInvalidSignature code
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrUnauthorizedSigner is matched by the errors with the ErrorCodeUnauthorizedSigner code
+	ErrUnauthorizedSigner = errors.New("unauthorized signer")
+	// ErrProofRejected is matched by the errors with the ErrorCodeProofRejected code
+	ErrProofRejected = errors.New("proof rejected")
+	// ErrStateRootMismatch is matched by the errors with the ErrorCodeStateRootMismatch code
+	ErrStateRootMismatch = errors.New("state root mismatch")
+	// ErrFullNodeUnavailable is matched by the errors with the ErrorCodeFullNodeUnavailable code
+	ErrFullNodeUnavailable = errors.New("full node unavailable")
+	// ErrSettlementQueue is matched by the errors with the ErrorCodeSettlementQueue code
+	ErrSettlementQueue = errors.New("settlement queue error")
+	// ErrDB is matched by the errors with the ErrorCodeDB code
+	ErrDB = errors.New("database error")
+	// ErrTxNotFound is matched by the errors with the ErrorCodeTxNotFound code
+	ErrTxNotFound = errors.New("tx not found")
+	// ErrFullNodeDivergence is matched by the errors with the ErrorCodeFullNodeDivergence code
+	ErrFullNodeDivergence = errors.New("full nodes diverge")
+	// ErrInvalidPendingState is matched by the errors with the ErrorCodeInvalidPendingState code
+	ErrInvalidPendingState = errors.New("invalid pending state")
+	// ErrInvalidParams is matched by the errors with the ErrorCodeInvalidParams code
+	ErrInvalidParams = errors.New("invalid params")
+	// ErrAccessDenied is matched by the errors with the ErrorCodeAccessDenied code
+	ErrAccessDenied = errors.New("access denied")
+)
+
+var errorsByCode = map[int]error{
+	ErrorCodeInternal:            ErrInternal,
+	ErrorCodeUnknownRollup:       ErrUnknownRollup,
+	ErrorCodeInvalidSignature:    ErrInvalidSignature,
+	ErrorCodeUnauthorizedSigner:  ErrUnauthorizedSigner,
+	ErrorCodeProofRejected:       ErrProofRejected,
+	ErrorCodeStateRootMismatch:   ErrStateRootMismatch,
+	ErrorCodeFullNodeUnavailable: ErrFullNodeUnavailable,
+	ErrorCodeSettlementQueue:     ErrSettlementQueue,
+	ErrorCodeDB:                  ErrDB,
+	ErrorCodeTxNotFound:          ErrTxNotFound,
+	ErrorCodeFullNodeDivergence:  ErrFullNodeDivergence,
+	ErrorCodeInvalidPendingState: ErrInvalidPendingState,
+	ErrorCodeInvalidParams:       ErrInvalidParams,
+	ErrorCodeAccessDenied:        ErrAccessDenied,
+}
+
+// ErrorData is the data of the errors returned by the interop endpoints, hex encoded as JSON
+type ErrorData struct {
+	// RollupID is the rollup of the tx that failed
+	RollupID *uint32 `json:"rollupId,omitempty"`
+	// TxHash is the tx that failed
+	TxHash *common.Hash `json:"txHash,omitempty"`
+	// Reason is the cause of the failure, not set for the internal ones
+	Reason string `json:"reason,omitempty"`
+}
+
+// Error is an error returned by the interop endpoints, it matches the sentinel error of its code
+type Error struct {
+	Code    int
+	Message string
+	Data    *ErrorData
+}
+
+// NewError returns the error of the code, decoding its data if any
+func NewError(code int, message string, data []byte) *Error {
+	e := &Error{Code: code, Message: message}
+
+	if len(data) > 0 {
+		var d ErrorData
+		if err := json.Unmarshal(data, &d); err == nil {
+			e.Data = &d
+		}
+	}
+
+	return e
+}
+
+// Error returns the code and the message of the error
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v %v", e.Code, e.Message)
+}
+
+// Unwrap returns the sentinel error of the code, if it's known
+func (e *Error) Unwrap() error {
+	return errorsByCode[e.Code]
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("matches the sentinel error of its code", func(t *testing.T) {
+		t.Parallel()
+
+		for code, sentinel := range errorsByCode {
+			err := fmt.Errorf("wrapped: %w", NewError(code, "message", nil))
+
+			assert.ErrorIs(t, err, sentinel)
+			assert.Equal(t, fmt.Sprintf("wrapped: %d message", code), err.Error())
+		}
+	})
+
+	t.Run("unknown code", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewError(-1, "message", nil)
+
+		require.Nil(t, errors.Unwrap(err))
+		require.NotErrorIs(t, err, ErrInternal)
+	})
+
+	t.Run("decodes its data", func(t *testing.T) {
+		t.Parallel()
+
+		hash := common.HexToHash("0x1")
+		err := NewError(ErrorCodeStateRootMismatch, "message",
+			[]byte(`{"rollupId":1,"txHash":"`+hash.Hex()+`","reason":"mismatch"}`))
+
+		require.ErrorIs(t, err, ErrStateRootMismatch)
+		require.NotNil(t, err.Data)
+		require.Equal(t, uint32(1), *err.Data.RollupID)
+		require.Equal(t, hash, *err.Data.TxHash)
+		require.Equal(t, "mismatch", err.Data.Reason)
+	})
+
+	t.Run("ignores malformed data", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewError(ErrorCodeDB, "message", []byte("not json"))
+
+		require.ErrorIs(t, err, ErrDB)
+		require.Nil(t, err.Data)
+	})
+}
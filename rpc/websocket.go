@@ -0,0 +1,368 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	jRPC "github.com/0xPolygon/cdk-rpc/rpc"
+	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/0xPolygon/agglayer/config"
+	rpcTypes "github.com/0xPolygon/agglayer/rpc/types"
+	"github.com/0xPolygon/agglayer/types"
+)
+
+const (
+	subscribeMethod    = INTEROP + "_subscribe"
+	unsubscribeMethod  = INTEROP + "_unsubscribe"
+	subscriptionMethod = INTEROP + "_subscription"
+
+	// wsWriteTimeout bounds each message written to a connection
+	wsWriteTimeout = 10 * time.Second
+)
+
+// ErrTooManyConnections when the WebSocket server already serves its maximum number of connections
+var ErrTooManyConnections = errors.New("too many connections")
+
+// subscriptionNotification is the message pushing a status change to a subscriber
+type subscriptionNotification struct {
+	JSONRPC string             `json:"jsonrpc"`
+	Method  string             `json:"method"`
+	Params  subscriptionResult `json:"params"`
+}
+
+type subscriptionResult struct {
+	Subscription string               `json:"subscription"`
+	Result       types.TxStatusChange `json:"result"`
+}
+
+// WebSocketServer serves the interop_subscribe and interop_unsubscribe methods over WebSocket
+type WebSocketServer struct {
+	logger   *zap.SugaredLogger
+	cfg      config.WebSocketConfig
+	feed     *StatusFeed
+	meter    metric.Meter
+	upgrader websocket.Upgrader
+	conns    atomic.Int64
+
+	mu  sync.Mutex
+	srv *http.Server
+}
+
+// NewWebSocketServer returns a WebSocket server subscribing to the given feed
+func NewWebSocketServer(logger *zap.SugaredLogger, cfg config.WebSocketConfig, feed *StatusFeed) *WebSocketServer {
+	s := &WebSocketServer{
+		logger: logger,
+		cfg:    cfg,
+		feed:   feed,
+		meter:  otel.Meter(meterName),
+	}
+
+	// without allowed origins the upgrader only accepts the requests from the host of the server
+	if len(cfg.AllowedOrigins) > 0 {
+		s.upgrader.CheckOrigin = s.checkOrigin
+	}
+
+	return s
+}
+
+// checkOrigin accepts the requests without an Origin header, which don't come from a
+// browser, and the ones from the allowed origins
+func (s *WebSocketServer) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	for _, allowed := range s.cfg.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Start listens for WebSocket connections until the server is stopped
+func (s *WebSocketServer) Start() error {
+	s.mu.Lock()
+	if s.srv != nil {
+		s.mu.Unlock()
+		return errors.New("websocket server already started")
+	}
+
+	address := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("failed to create tcp listener: %w", err)
+	}
+
+	s.srv = &http.Server{
+		Handler:           s.mux(),
+		ReadHeaderTimeout: wsWriteTimeout,
+	}
+	srv := s.srv
+	s.mu.Unlock()
+
+	s.logger.Infof("websocket server started: %s", address)
+	if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Stop closes the listener and the open connections
+func (s *WebSocketServer) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.srv == nil {
+		return nil
+	}
+
+	// hijacked connections aren't tracked by the http server, they're closed once their subscriptions are dropped
+	err := s.srv.Close()
+	s.srv = nil
+
+	return err
+}
+
+// Service describes the methods served over WebSocket for rpc_discover, they're dispatched
+// per connection rather than registered on the RPC server
+func (s *WebSocketServer) Service() jRPC.Service {
+	return jRPC.Service{Name: INTEROP, Service: (*wsConn)(nil)}
+}
+
+func (s *WebSocketServer) mux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handle)
+
+	return mux
+}
+
+func (s *WebSocketServer) handle(w http.ResponseWriter, r *http.Request) {
+	if conns := s.conns.Add(1); s.cfg.MaxConnections > 0 && conns > int64(s.cfg.MaxConnections) {
+		s.conns.Add(-1)
+		http.Error(w, ErrTooManyConnections.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer s.conns.Add(-1)
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.logger.Debugf("failed to upgrade connection from %s: %s", r.RemoteAddr, err)
+		return
+	}
+	if s.cfg.ReadLimit > 0 {
+		conn.SetReadLimit(s.cfg.ReadLimit)
+	}
+
+	c := &wsConn{
+		server: s,
+		conn:   conn,
+		subs:   make(map[string]struct{}),
+	}
+	c.serve()
+}
+
+// wsConn holds the subscriptions of a single connection
+type wsConn struct {
+	server *WebSocketServer
+	conn   *websocket.Conn
+
+	writeMu sync.Mutex
+
+	subsMu sync.Mutex
+	subs   map[string]struct{}
+}
+
+// serve handles the requests of the connection until it's closed, then drops its subscriptions
+func (c *wsConn) serve() {
+	defer func() {
+		c.subsMu.Lock()
+		for id := range c.subs {
+			c.server.feed.Unsubscribe(id)
+		}
+		c.subs = nil
+		c.subsMu.Unlock()
+
+		if err := c.conn.Close(); err != nil {
+			c.server.logger.Debugf("failed to close connection: %s", err)
+		}
+	}()
+
+	for {
+		_, msg, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req jRPC.Request
+		if err := json.Unmarshal(msg, &req); err != nil {
+			c.respond(req, nil, jRPC.NewRPCError(jRPC.InvalidRequestErrorCode, "invalid json request"))
+			continue
+		}
+
+		result, rpcErr := c.handleRequest(req)
+
+		// changes are only pushed once the client knows the ID of the subscription
+		if sub, ok := result.(*Subscription); ok {
+			c.respond(req, sub.ID, nil)
+			go c.push(sub)
+			continue
+		}
+
+		c.respond(req, result, rpcErr)
+	}
+}
+
+func (c *wsConn) handleRequest(req jRPC.Request) (interface{}, jRPC.Error) {
+	switch req.Method {
+	case subscribeMethod:
+		var params []types.TxStatusFilter
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) != 1 {
+			return nil, jRPC.NewRPCError(jRPC.InvalidParamsErrorCode, "invalid params, expected a single filter")
+		}
+
+		return c.Subscribe(params[0])
+	case unsubscribeMethod:
+		var params []string
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) != 1 {
+			return nil, jRPC.NewRPCError(jRPC.InvalidParamsErrorCode, "invalid params, expected a subscription ID")
+		}
+
+		return c.Unsubscribe(params[0]), nil
+	default:
+		return nil, jRPC.NewRPCError(jRPC.NotFoundErrorCode, fmt.Sprintf("the method %s does not exist/is not available", req.Method))
+	}
+}
+
+// Subscribe pushes the status changes matching the filter to the connection
+func (c *wsConn) Subscribe(filter types.TxStatusFilter) (interface{}, jRPC.Error) {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if c.server.cfg.MaxSubscriptionsPerConn > 0 && len(c.subs) >= c.server.cfg.MaxSubscriptionsPerConn {
+		return nil, jRPC.NewRPCError(jRPC.DefaultErrorCode, ErrTooManySubscriptions.Error())
+	}
+
+	sub, err := c.server.feed.Subscribe(filter)
+	if err != nil {
+		return nil, jRPC.NewRPCError(jRPC.InvalidParamsErrorCode, err.Error())
+	}
+	c.subs[sub.ID] = struct{}{}
+
+	mc, merr := c.server.meter.Int64Counter("subscribe")
+	if merr != nil {
+		c.server.logger.Warnf("failed to create subscribe counter: %s", merr)
+	}
+	mc.Add(context.Background(), 1)
+
+	return sub, nil
+}
+
+// Unsubscribe cancels a subscription of the connection, returning whether it existed
+func (c *wsConn) Unsubscribe(id string) bool {
+	c.subsMu.Lock()
+	defer c.subsMu.Unlock()
+
+	if _, ok := c.subs[id]; !ok {
+		return false
+	}
+	delete(c.subs, id)
+
+	return c.server.feed.Unsubscribe(id)
+}
+
+// push writes the status changes of the subscription to the connection. A subscription dropped
+// by the feed, rather than unsubscribed, closes the connection so the client notices it
+func (c *wsConn) push(sub *Subscription) {
+	for change := range sub.Changes() {
+		err := c.write(subscriptionNotification{
+			JSONRPC: "2.0",
+			Method:  subscriptionMethod,
+			Params: subscriptionResult{
+				Subscription: sub.ID,
+				Result:       change,
+			},
+		})
+		if err != nil {
+			c.server.logger.Debugf("failed to push status change to subscription %s: %s", sub.ID, err)
+		}
+	}
+
+	c.subsMu.Lock()
+	_, dropped := c.subs[sub.ID]
+	if dropped {
+		delete(c.subs, sub.ID)
+	}
+	c.subsMu.Unlock()
+
+	if dropped {
+		if err := c.conn.Close(); err != nil {
+			c.server.logger.Debugf("failed to close connection: %s", err)
+		}
+	}
+}
+
+func (c *wsConn) respond(req jRPC.Request, result interface{}, rpcErr jRPC.Error) {
+	var data []byte
+	if rpcErr == nil {
+		d, err := json.Marshal(result)
+		if err != nil {
+			rpcErr = jRPC.NewRPCError(jRPC.DefaultErrorCode, "failed to marshal result")
+		}
+		data = d
+	}
+
+	if err := c.write(jRPC.NewResponse(req, data, rpcErr)); err != nil {
+		c.server.logger.Debugf("failed to write response: %s", err)
+	}
+}
+
+func (c *wsConn) write(v interface{}) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return err
+	}
+
+	return c.conn.WriteJSON(v)
+}
+
+func (c *wsConn) methodDocs() map[string]methodDoc {
+	return map[string]methodDoc{
+		"Subscribe": {
+			description: "Subscribe over WebSocket to the status changes of a transaction or of all the transactions of a rollup. " +
+				"Each change is pushed as an interop_subscription notification carrying the subscription ID and a TxStatusChange: " +
+				"received, verified or rejected, with the error and its code, while the transaction is in the intake queue, " +
+				"then the statuses of the L1 transaction settling it",
+			params: []paramDoc{
+				{name: "filter", description: "Either the hash of a transaction or the ID of a rollup"},
+			},
+			result: paramDoc{name: "subscription", description: "The ID of the subscription", value: ""},
+			errors: []int{rpcTypes.ErrorCodeInvalidParams},
+		},
+		"Unsubscribe": {
+			description: "Cancel a subscription created with interop_subscribe on the same connection",
+			params: []paramDoc{
+				{name: "subscription", description: "The ID of the subscription"},
+			},
+			result: paramDoc{name: "unsubscribed", description: "Whether the subscription existed", value: false},
+		},
+	}
+}
@@ -27,20 +27,41 @@ type Tx struct {
 	LastVerifiedBatch types.ArgUint64 `json:"lastVerifiedBatch"`
 	NewVerifiedBatch  types.ArgUint64 `json:"newVerifiedBatch"`
 	ZKP               ZKP             `json:"ZKP"`
+	// PendingStateNum is the pending state of the rollup the batches are verified from,
+	// when it's not set they're verified from the last consolidated state
+	PendingStateNum *types.ArgUint64 `json:"pendingStateNum,omitempty"`
 }
 
 // Hash returns a hash that uniquely identifies the tx
 func (t *Tx) Hash() common.Hash {
-	return common.BytesToHash(crypto.Keccak256(
+	data := [][]byte{
 		[]byte(t.LastVerifiedBatch.Hex()),
 		[]byte(t.NewVerifiedBatch.Hex()),
 		t.ZKP.NewStateRoot[:],
 		t.ZKP.NewLocalExitRoot[:],
 		[]byte(t.ZKP.Proof.Hex()),
-	))
+	}
+
+	// appended only when it's not the consolidated state so its hash doesn't change,
+	// whether the pending state is unset or 0
+	if t.PendingState() != 0 {
+		data = append(data, []byte(t.PendingStateNum.Hex()))
+	}
+
+	return common.BytesToHash(crypto.Keccak256(data...))
+}
+
+// PendingState returns the pending state the batches are verified from, 0 meaning the consolidated state
+func (t *Tx) PendingState() uint64 {
+	if t.PendingStateNum == nil {
+		return 0
+	}
+
+	return uint64(*t.PendingStateNum)
 }
 
-// Sign returns a signed batch by the private key
+// Sign returns a signed batch by the private key.
+// It signs the legacy hash, prefer SignTypedData which binds the rollup and the L1 chain
 func (t *Tx) Sign(privateKey *ecdsa.PrivateKey) (*SignedTx, error) {
 	hashToSign := t.Hash()
 	sig, err := crypto.Sign(hashToSign.Bytes(), privateKey)
@@ -0,0 +1,45 @@
+package tx
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/0xPolygon/agglayer/rpc/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxPendingStateNum(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hash only changes when not the consolidated state", func(t *testing.T) {
+		t.Parallel()
+
+		tnx := sampleTx()
+		consolidated := tnx.Hash()
+
+		tnx.PendingStateNum = types.ArgUint64Ptr(3)
+		require.NotEqual(t, consolidated, tnx.Hash())
+		require.Equal(t, uint64(3), tnx.PendingState())
+
+		tnx.PendingStateNum = nil
+		require.Equal(t, consolidated, tnx.Hash())
+
+		tnx.PendingStateNum = types.ArgUint64Ptr(0)
+		require.Equal(t, consolidated, tnx.Hash())
+		require.Equal(t, uint64(0), tnx.PendingState())
+	})
+
+	t.Run("optional in JSON", func(t *testing.T) {
+		t.Parallel()
+
+		tnx := sampleTx()
+
+		data, err := json.Marshal(tnx)
+		require.NoError(t, err)
+		require.NotContains(t, string(data), "pendingStateNum")
+
+		var decoded Tx
+		require.NoError(t, json.Unmarshal([]byte(`{"lastVerifiedBatch":"0x1","newVerifiedBatch":"0x2","pendingStateNum":"0x3"}`), &decoded))
+		require.Equal(t, uint64(3), decoded.PendingState())
+	})
+}
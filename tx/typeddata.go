@@ -0,0 +1,99 @@
+package tx
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// TypedDataName is the EIP-712 domain name used to sign txs
+	TypedDataName = "AggLayer"
+	// TypedDataVersion is the EIP-712 domain version used to sign txs,
+	// it must be bumped whenever the typed structs below change
+	TypedDataVersion = "2"
+)
+
+var (
+	domainTypeHash = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	zkpTypeHash    = crypto.Keccak256Hash([]byte(zkpType))
+	txTypeHash     = crypto.Keccak256Hash([]byte("Tx(uint32 rollupID,uint64 lastVerifiedBatch,uint64 newVerifiedBatch,uint64 pendingStateNum,ZKP zkp)" + zkpType))
+)
+
+const zkpType = "ZKP(bytes32 newStateRoot,bytes32 newLocalExitRoot,bytes proof)"
+
+// SigningDomain binds a typed data signature to a given L1 deployment
+// of the agglayer, so it can't be replayed on another chain or against
+// another rollup manager
+type SigningDomain struct {
+	L1ChainID             uint64
+	RollupManagerContract common.Address
+}
+
+// Separator returns the EIP-712 domain separator
+func (d SigningDomain) Separator() common.Hash {
+	return crypto.Keccak256Hash(
+		domainTypeHash[:],
+		crypto.Keccak256([]byte(TypedDataName)),
+		crypto.Keccak256([]byte(TypedDataVersion)),
+		encodeUint(d.L1ChainID),
+		common.LeftPadBytes(d.RollupManagerContract[:], common.HashLength),
+	)
+}
+
+// TypedDataHash returns the EIP-712 hash of the tx for the given domain.
+// Unlike Hash, it covers the rollup ID, the L1 chain ID and the rollup manager address.
+// An unset pending state is signed as 0, the consolidated state
+func (t *Tx) TypedDataHash(domain SigningDomain) common.Hash {
+	zkpHash := crypto.Keccak256(
+		zkpTypeHash[:],
+		t.ZKP.NewStateRoot[:],
+		t.ZKP.NewLocalExitRoot[:],
+		crypto.Keccak256(t.ZKP.Proof),
+	)
+
+	txHash := crypto.Keccak256(
+		txTypeHash[:],
+		encodeUint(uint64(t.RollupID)),
+		encodeUint(uint64(t.LastVerifiedBatch)),
+		encodeUint(uint64(t.NewVerifiedBatch)),
+		encodeUint(t.PendingState()),
+		zkpHash,
+	)
+
+	separator := domain.Separator()
+
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, separator[:], txHash)
+}
+
+// SignTypedData returns a signed tx using the typed data scheme for the given domain
+func (t *Tx) SignTypedData(privateKey *ecdsa.PrivateKey, domain SigningDomain) (*SignedTx, error) {
+	hashToSign := t.TypedDataHash(domain)
+	sig, err := crypto.Sign(hashToSign.Bytes(), privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedTx{
+		Tx:        *t,
+		Signature: sig,
+	}, nil
+}
+
+// TypedDataSigner returns the address of the signer assuming the tx
+// was signed using the typed data scheme for the given domain
+func (s *SignedTx) TypedDataSigner(domain SigningDomain) (common.Address, error) {
+	pubKey, err := crypto.SigToPub(s.Tx.TypedDataHash(domain).Bytes(), s.Signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// encodeUint encodes an unsigned integer as a 32 bytes ABI word
+func encodeUint(v uint64) []byte {
+	word := make([]byte, common.HashLength)
+	binary.BigEndian.PutUint64(word[common.HashLength-8:], v)
+	return word
+}
@@ -0,0 +1,129 @@
+package tx
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/0xPolygon/agglayer/rpc/types"
+)
+
+func sampleTx() Tx {
+	return Tx{
+		RollupID:          7,
+		LastVerifiedBatch: 10,
+		NewVerifiedBatch:  20,
+		ZKP: ZKP{
+			NewStateRoot:     common.HexToHash("0x01"),
+			NewLocalExitRoot: common.HexToHash("0x02"),
+			Proof:            []byte("sampleProof"),
+		},
+	}
+}
+
+func TestTypedDataHash(t *testing.T) {
+	t.Parallel()
+
+	domain := SigningDomain{
+		L1ChainID:             1337,
+		RollupManagerContract: common.HexToAddress("0xB7f8BC63BbcaD18155201308C8f3540b07f84F5e"),
+	}
+	tnx := sampleTx()
+	tnx.PendingStateNum = types.ArgUint64Ptr(3)
+
+	t.Run("matches the EIP-712 reference implementation", func(t *testing.T) {
+		t.Parallel()
+
+		typedData := apitypes.TypedData{
+			Types: apitypes.Types{
+				"EIP712Domain": {
+					{Name: "name", Type: "string"},
+					{Name: "version", Type: "string"},
+					{Name: "chainId", Type: "uint256"},
+					{Name: "verifyingContract", Type: "address"},
+				},
+				"ZKP": {
+					{Name: "newStateRoot", Type: "bytes32"},
+					{Name: "newLocalExitRoot", Type: "bytes32"},
+					{Name: "proof", Type: "bytes"},
+				},
+				"Tx": {
+					{Name: "rollupID", Type: "uint32"},
+					{Name: "lastVerifiedBatch", Type: "uint64"},
+					{Name: "newVerifiedBatch", Type: "uint64"},
+					{Name: "pendingStateNum", Type: "uint64"},
+					{Name: "zkp", Type: "ZKP"},
+				},
+			},
+			PrimaryType: "Tx",
+			Domain: apitypes.TypedDataDomain{
+				Name:              TypedDataName,
+				Version:           TypedDataVersion,
+				ChainId:           (*math.HexOrDecimal256)(big.NewInt(1337)),
+				VerifyingContract: domain.RollupManagerContract.Hex(),
+			},
+			Message: apitypes.TypedDataMessage{
+				"rollupID":          "7",
+				"lastVerifiedBatch": "10",
+				"newVerifiedBatch":  "20",
+				"pendingStateNum":   "3",
+				"zkp": map[string]interface{}{
+					"newStateRoot":     tnx.ZKP.NewStateRoot.Hex(),
+					"newLocalExitRoot": tnx.ZKP.NewLocalExitRoot.Hex(),
+					"proof":            hexutil.Encode(tnx.ZKP.Proof),
+				},
+			},
+		}
+
+		expected, _, err := apitypes.TypedDataAndHash(typedData)
+		require.NoError(t, err)
+		require.Equal(t, common.BytesToHash(expected), tnx.TypedDataHash(domain))
+	})
+
+	t.Run("binds rollup ID, pending state and domain", func(t *testing.T) {
+		t.Parallel()
+
+		otherRollup := tnx
+		otherRollup.RollupID = 8
+		require.NotEqual(t, tnx.TypedDataHash(domain), otherRollup.TypedDataHash(domain))
+		require.Equal(t, tnx.Hash(), otherRollup.Hash())
+
+		otherChain := domain
+		otherChain.L1ChainID = 1
+		require.NotEqual(t, tnx.TypedDataHash(domain), tnx.TypedDataHash(otherChain))
+
+		consolidated := sampleTx()
+		require.NotEqual(t, tnx.TypedDataHash(domain), consolidated.TypedDataHash(domain))
+		zeroPendingState := sampleTx()
+		zeroPendingState.PendingStateNum = types.ArgUint64Ptr(0)
+		require.Equal(t, consolidated.TypedDataHash(domain), zeroPendingState.TypedDataHash(domain))
+
+		otherManager := domain
+		otherManager.RollupManagerContract = common.HexToAddress("0x01")
+		require.NotEqual(t, tnx.TypedDataHash(domain), tnx.TypedDataHash(otherManager))
+	})
+
+	t.Run("sign and recover", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+
+		signedTx, err := tnx.SignTypedData(key, domain)
+		require.NoError(t, err)
+
+		signer, err := signedTx.TypedDataSigner(domain)
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+		legacySigner, err := signedTx.Signer()
+		require.NoError(t, err)
+		require.NotEqual(t, crypto.PubkeyToAddress(key.PublicKey), legacySigner)
+	})
+}
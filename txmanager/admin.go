@@ -0,0 +1,181 @@
+package txmanager
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+)
+
+// cancelGas is the gas of the self transfer replacing a canceled tx
+const cancelGas = 21000
+
+// Pause stops processing the monitored txs until Resume is called, the cycle in progress is completed
+func (c *Client) Pause() {
+	c.paused.Store(true)
+}
+
+// Resume restarts processing the monitored txs
+func (c *Client) Resume() {
+	c.paused.Store(false)
+}
+
+// Paused returns whether the monitored txs processing is paused
+func (c *Client) Paused() bool {
+	return c.paused.Load()
+}
+
+// Retry monitors again a failed tx, as if it had just been sent. Its batch range is reserved
+// again first, the retry is refused if a newer tx settled or is settling the range meanwhile
+func (c *Client) Retry(ctx context.Context, owner, id string, dbTx pgx.Tx) (txmTypes.MonitoredTx, error) {
+	return c.operate(ctx, owner, id, dbTx, func(mTx *txmTypes.MonitoredTx) error {
+		if mTx.Status != txmTypes.MonitoredTxStatusFailed {
+			return fmt.Errorf("%w: %s", txmTypes.ErrStatusNotAllowed, mTx.Status)
+		}
+
+		if c.Settlements != nil {
+			if err := c.Settlements.ReserveSettlement(ctx, *mTx); err != nil {
+				return fmt.Errorf("failed to reserve the batch range again: %w", err)
+			}
+		}
+
+		mTx.Status = txmTypes.MonitoredTxStatusCreated
+		if len(mTx.History) > 0 {
+			mTx.Status = txmTypes.MonitoredTxStatusSent
+		}
+
+		// a zero count is taken by the monitoring loop as a legacy tx and replaced by the history length
+		mTx.NumRetries = 1
+
+		return nil
+	})
+}
+
+// Replace sets a higher gas price to a pending tx, the monitoring loop sends the
+// replacement with the same nonce in its next cycle
+func (c *Client) Replace(ctx context.Context, owner, id string, gasPrice *big.Int, dbTx pgx.Tx) (txmTypes.MonitoredTx, error) {
+	return c.operate(ctx, owner, id, dbTx, func(mTx *txmTypes.MonitoredTx) error {
+		if mTx.Status != txmTypes.MonitoredTxStatusCreated && mTx.Status != txmTypes.MonitoredTxStatusSent {
+			return fmt.Errorf("%w: %s", txmTypes.ErrStatusNotAllowed, mTx.Status)
+		}
+
+		if gasPrice.Cmp(mTx.GasPrice) <= 0 {
+			return txmTypes.ErrGasPriceTooLow
+		}
+		mTx.GasPrice = gasPrice
+
+		return nil
+	})
+}
+
+// Cancel stops monitoring a pending tx and marks it as failed. The tx is replaced by a
+// self transfer with the same nonce and the given gas price, which is added to its history,
+// so a tx that wasn't sent yet doesn't leave a nonce gap blocking the next ones.
+// The original tx may still be mined if it beats the replacement. The self transfer
+// is sent before dbTx is committed, it isn't undone if the commit fails
+func (c *Client) Cancel(ctx context.Context, owner, id string, gasPrice *big.Int, dbTx pgx.Tx) (txmTypes.MonitoredTx, error) {
+	return c.operate(ctx, owner, id, dbTx, func(mTx *txmTypes.MonitoredTx) error {
+		if mTx.Status != txmTypes.MonitoredTxStatusCreated && mTx.Status != txmTypes.MonitoredTxStatusSent {
+			return fmt.Errorf("%w: %s", txmTypes.ErrStatusNotAllowed, mTx.Status)
+		}
+
+		if gasPrice.Cmp(mTx.GasPrice) <= 0 {
+			return txmTypes.ErrGasPriceTooLow
+		}
+
+		tx := types.NewTx(&types.LegacyTx{
+			To:       &mTx.From,
+			Nonce:    mTx.Nonce,
+			Value:    big.NewInt(0),
+			Gas:      cancelGas,
+			GasPrice: gasPrice,
+		})
+		signedTx, err := c.etherman.SignTx(ctx, mTx.From, tx)
+		if err != nil {
+			return fmt.Errorf("failed to sign cancel tx: %w", err)
+		}
+
+		if err := c.etherman.SendTx(ctx, signedTx); err != nil {
+			return fmt.Errorf("failed to send cancel tx %s: %w", signedTx.Hash().String(), err)
+		}
+
+		if err := mTx.AddHistory(signedTx); err != nil {
+			return fmt.Errorf("failed to add cancel tx %s to the history: %w", signedTx.Hash().String(), err)
+		}
+		mTx.Status = txmTypes.MonitoredTxStatusFailed
+
+		return nil
+	})
+}
+
+// MarkDone stops monitoring a failed or confirmed tx and marks it as done. A pending tx
+// must be canceled first, its nonce would otherwise be left unused and block the next txs
+func (c *Client) MarkDone(ctx context.Context, owner, id string, dbTx pgx.Tx) (txmTypes.MonitoredTx, error) {
+	return c.operate(ctx, owner, id, dbTx, func(mTx *txmTypes.MonitoredTx) error {
+		if mTx.Status != txmTypes.MonitoredTxStatusFailed && mTx.Status != txmTypes.MonitoredTxStatusConfirmed {
+			return fmt.Errorf("%w: %s", txmTypes.ErrStatusNotAllowed, mTx.Status)
+		}
+
+		mTx.Status = txmTypes.MonitoredTxStatusDone
+
+		return nil
+	})
+}
+
+// NotifyStatusChange notifies the status listener of the new status of a tx changed by an operator
+// with a dbTx, it must only be called once the dbTx is committed
+func (c *Client) NotifyStatusChange(mTx txmTypes.MonitoredTx) {
+	if c.StatusListener != nil {
+		c.StatusListener.OnStatusChange(mTx)
+	}
+}
+
+// operate applies the change to the monitored tx between two monitoring cycles and persists it with dbTx.
+// A change persisted with a dbTx isn't notified, it may still be rolled back: the caller notifies
+// it with NotifyStatusChange once committed
+func (c *Client) operate(ctx context.Context, owner, id string, dbTx pgx.Tx, change func(mTx *txmTypes.MonitoredTx) error) (txmTypes.MonitoredTx, error) {
+	if err := c.lockCycle(ctx); err != nil {
+		return txmTypes.MonitoredTx{}, err
+	}
+	defer c.unlockCycle()
+
+	mTx, err := c.storage.Get(ctx, owner, id, dbTx)
+	if err != nil {
+		return txmTypes.MonitoredTx{}, err
+	}
+	persistedStatus := mTx.Status
+
+	if err := change(&mTx); err != nil {
+		return txmTypes.MonitoredTx{}, err
+	}
+
+	if dbTx != nil {
+		err = c.storage.Update(ctx, mTx, dbTx)
+	} else {
+		err = c.update(ctx, mTx, &persistedStatus)
+	}
+	if err != nil {
+		return txmTypes.MonitoredTx{}, fmt.Errorf("failed to update monitored tx: %w", err)
+	}
+
+	createMonitoredTxLogger(mTx).Infof("updated by an operator, status %s", mTx.Status)
+
+	return mTx, nil
+}
+
+// lockCycle waits for the monitoring cycle in progress to complete
+func (c *Client) lockCycle(ctx context.Context) error {
+	select {
+	case c.cycle <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) unlockCycle() {
+	<-c.cycle
+}
@@ -0,0 +1,135 @@
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/agglayer/mocks"
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// settlementReserver records the retried txs whose range is reserved, failing with err if set
+type settlementReserver struct {
+	reserved []string
+	err      error
+}
+
+func (r *settlementReserver) ReserveSettlement(ctx context.Context, mTx txmTypes.MonitoredTx) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.reserved = append(r.reserved, mTx.ID)
+
+	return nil
+}
+
+func TestAdminOperations(t *testing.T) {
+	dbCfg := newStateDBConfig(t)
+	etherman := mocks.NewEthermanMock(t)
+	storage, err := NewPostgresStorageWithCfg(dbCfg)
+	require.NoError(t, err)
+
+	ethTxManagerClient := New(defaultEthTxmanagerConfigForTests, etherman, storage, etherman)
+	statuses := &statusRecorder{}
+	ethTxManagerClient.StatusListener = statuses
+	settlements := &settlementReserver{}
+	ethTxManagerClient.Settlements = settlements
+
+	ctx := context.Background()
+	owner := "owner"
+	to := common.HexToAddress("0x2")
+
+	add := func(id string, status txmTypes.MonitoredTxStatus, history map[common.Hash]bool) {
+		t.Helper()
+
+		err := storage.Add(ctx, txmTypes.MonitoredTx{
+			Owner: owner, ID: id, From: common.HexToAddress("0x1"), To: &to, Nonce: 1,
+			Gas: 1, GasPrice: big.NewInt(10), Status: status, History: history, NumRetries: 3,
+		}, nil)
+		require.NoError(t, err)
+	}
+
+	add("failed", txmTypes.MonitoredTxStatusFailed, map[common.Hash]bool{common.HexToHash("0x3"): true})
+	add("settled", txmTypes.MonitoredTxStatusFailed, map[common.Hash]bool{common.HexToHash("0x5"): true})
+	add("created", txmTypes.MonitoredTxStatusCreated, map[common.Hash]bool{})
+	add("confirmed", txmTypes.MonitoredTxStatusConfirmed, map[common.Hash]bool{common.HexToHash("0x4"): true})
+
+	mTx, err := ethTxManagerClient.Retry(ctx, owner, "failed", nil)
+	require.NoError(t, err)
+	require.Equal(t, txmTypes.MonitoredTxStatusSent, mTx.Status)
+	require.Equal(t, uint64(1), mTx.NumRetries)
+	require.Equal(t, []string{"failed"}, settlements.reserved)
+
+	// a newer tx settled the range meanwhile
+	settlements.err = errors.New("range overlaps")
+	_, err = ethTxManagerClient.Retry(ctx, owner, "settled", nil)
+	require.ErrorContains(t, err, "range overlaps")
+
+	_, err = ethTxManagerClient.Retry(ctx, owner, "created", nil)
+	require.ErrorIs(t, err, txmTypes.ErrStatusNotAllowed)
+
+	_, err = ethTxManagerClient.Replace(ctx, owner, "created", big.NewInt(10), nil)
+	require.ErrorIs(t, err, txmTypes.ErrGasPriceTooLow)
+
+	mTx, err = ethTxManagerClient.Replace(ctx, owner, "created", big.NewInt(20), nil)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(20), mTx.GasPrice)
+
+	// a pending tx can't be marked done, its nonce would be left unused
+	_, err = ethTxManagerClient.MarkDone(ctx, owner, "created", nil)
+	require.ErrorIs(t, err, txmTypes.ErrStatusNotAllowed)
+
+	// the nonce of a tx that wasn't sent yet is used by the self transfer
+	cancelTx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(30)})
+	etherman.On("SignTx", ctx, common.HexToAddress("0x1"), mock.IsType(&types.Transaction{})).Return(cancelTx, nil).Once()
+	etherman.On("SendTx", ctx, cancelTx).Return(nil).Once()
+
+	mTx, err = ethTxManagerClient.Cancel(ctx, owner, "created", big.NewInt(30), nil)
+	require.NoError(t, err)
+	require.Equal(t, txmTypes.MonitoredTxStatusFailed, mTx.Status)
+	require.True(t, mTx.History[cancelTx.Hash()])
+
+	mTx, err = ethTxManagerClient.MarkDone(ctx, owner, "confirmed", nil)
+	require.NoError(t, err)
+	require.Equal(t, txmTypes.MonitoredTxStatusDone, mTx.Status)
+
+	_, err = ethTxManagerClient.MarkDone(ctx, owner, "confirmed", nil)
+	require.ErrorIs(t, err, txmTypes.ErrStatusNotAllowed)
+
+	_, err = ethTxManagerClient.MarkDone(ctx, owner, "unknown", nil)
+	require.ErrorIs(t, err, txmTypes.ErrNotFound)
+
+	stored, err := storage.Get(ctx, owner, "created", nil)
+	require.NoError(t, err)
+	require.Equal(t, txmTypes.MonitoredTxStatusFailed, stored.Status)
+
+	require.Equal(t, []txmTypes.MonitoredTxStatus{
+		txmTypes.MonitoredTxStatusSent,
+		txmTypes.MonitoredTxStatusFailed,
+		txmTypes.MonitoredTxStatusDone,
+	}, statuses.Statuses())
+
+	// a change persisted with a dbTx is only notified by the caller, once committed
+	add("committed", txmTypes.MonitoredTxStatusFailed, map[common.Hash]bool{common.HexToHash("0x6"): true})
+	dbTx, err := storage.Begin(ctx)
+	require.NoError(t, err)
+
+	mTx, err = ethTxManagerClient.MarkDone(ctx, owner, "committed", dbTx)
+	require.NoError(t, err)
+	require.Len(t, statuses.Statuses(), 3)
+
+	require.NoError(t, dbTx.Commit(ctx))
+	ethTxManagerClient.NotifyStatusChange(mTx)
+	require.Equal(t, txmTypes.MonitoredTxStatusDone, statuses.Statuses()[3])
+
+	ethTxManagerClient.Pause()
+	require.True(t, ethTxManagerClient.Paused())
+	ethTxManagerClient.Resume()
+	require.False(t, ethTxManagerClient.Paused())
+}
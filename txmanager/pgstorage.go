@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -42,15 +43,24 @@ func NewPostgresStorageWithCfg(dbCfg db.Config) (*PostgresStorage, error) {
 func (s *PostgresStorage) Add(ctx context.Context, mTx txmTypes.MonitoredTx, dbTx pgx.Tx) error {
 	conn := s.dbConn(dbTx)
 	cmd := `
-        INSERT INTO state.monitored_txs (owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at, num_retries)
-                                 VALUES (   $1, $2,        $3,      $4,    $5,    $6,   $7,  $8,         $9,       $10,    $11,       $12,     $13,        $14,        $15,         $16)`
+        INSERT INTO state.monitored_txs (owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at, num_retries, rollup_id, last_verified_batch, new_verified_batch)
+                                 VALUES (   $1, $2,        $3,      $4,    $5,    $6,   $7,  $8,         $9,       $10,    $11,       $12,     $13,        $14,        $15,         $16,       $17,                 $18,                $19)`
+
+	var rollupID, lastVerifiedBatch, newVerifiedBatch *uint64
+	if mTx.Batches != nil {
+		id := uint64(mTx.Batches.RollupID)
+		rollupID = &id
+		lastVerifiedBatch = &mTx.Batches.LastVerifiedBatch
+		newVerifiedBatch = &mTx.Batches.NewVerifiedBatch
+	}
 
 	_, err := conn.Exec(ctx, cmd, mTx.Owner,
 		mTx.ID, mTx.From.String(), mTx.ToStringPtr(),
 		mTx.Nonce, mTx.ValueU64Ptr(), mTx.DataStringPtr(),
 		mTx.Gas, mTx.GasOffset, mTx.GasPrice.Uint64(), string(mTx.Status), mTx.BlockNumberU64Ptr(),
 		mTx.HistoryStringSlice(), time.Now().UTC().Round(time.Microsecond),
-		time.Now().UTC().Round(time.Microsecond), mTx.NumRetries)
+		time.Now().UTC().Round(time.Microsecond), mTx.NumRetries,
+		rollupID, lastVerifiedBatch, newVerifiedBatch)
 
 	if err != nil {
 		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.ConstraintName == "monitored_txs_pkey" {
@@ -67,7 +77,7 @@ func (s *PostgresStorage) Add(ctx context.Context, mTx txmTypes.MonitoredTx, dbT
 func (s *PostgresStorage) Get(ctx context.Context, owner, id string, dbTx pgx.Tx) (txmTypes.MonitoredTx, error) {
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at, num_retries
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at, num_retries, rollup_id, last_verified_batch, new_verified_batch
           FROM state.monitored_txs
          WHERE owner = $1 
            AND id = $2`
@@ -91,7 +101,7 @@ func (s *PostgresStorage) GetByStatus(ctx context.Context, owner *string, status
 
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at, num_retries
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at, num_retries, rollup_id, last_verified_batch, new_verified_batch
           FROM state.monitored_txs
          WHERE (owner = $1 OR $1 IS NULL)`
 	if hasStatusToFilter {
@@ -139,7 +149,7 @@ func (s *PostgresStorage) GetBySenderAndStatus(
 
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at, num_retries
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at, num_retries, rollup_id, last_verified_batch, new_verified_batch
           FROM state.monitored_txs
          WHERE from_addr = $1`
 	if hasStatusToFilter {
@@ -219,23 +229,102 @@ func (s *PostgresStorage) Update(ctx context.Context, mTx txmTypes.MonitoredTx,
 	return nil
 }
 
+// List loads the monitored txs of an owner matching the filter, newest first. The rollup
+// filter is backed by the (owner, rollup_id, created_at, id) index
+func (s *PostgresStorage) List(ctx context.Context, filter txmTypes.MonitoredTxFilter, dbTx pgx.Tx) ([]txmTypes.MonitoredTx, error) {
+	conn := s.dbConn(dbTx)
+	cmd := `
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at, num_retries, rollup_id, last_verified_batch, new_verified_batch
+          FROM state.monitored_txs
+         WHERE owner = $1`
+	args := []interface{}{filter.Owner}
+
+	where := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		cmd += fmt.Sprintf(`
+           AND `+cond, len(args))
+	}
+
+	if filter.RollupID != nil {
+		where("rollup_id = $%d", *filter.RollupID)
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		where("status = ANY($%d)", statuses)
+	}
+	// a tx verifies the batches after its last verified batch up to its new verified batch
+	if filter.FromBatch != nil {
+		where("new_verified_batch >= $%d", *filter.FromBatch)
+	}
+	if filter.ToBatch != nil {
+		where("last_verified_batch < $%d", *filter.ToBatch)
+	}
+	if filter.CreatedAfter != nil {
+		where("created_at >= $%d", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		where("created_at <= $%d", *filter.CreatedBefore)
+	}
+	if filter.Cursor != nil {
+		args = append(args, filter.Cursor.CreatedAt, filter.Cursor.ID)
+		cmd += fmt.Sprintf(`
+           AND (created_at, id) < ($%d, $%d)`, len(args)-1, len(args))
+	}
+
+	args = append(args, filter.Limit)
+	cmd += fmt.Sprintf(`
+         ORDER BY created_at DESC, id DESC
+         LIMIT $%d`, len(args))
+
+	rows, err := conn.Query(ctx, cmd, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mTxs := []txmTypes.MonitoredTx{}
+	for rows.Next() {
+		mTx := txmTypes.MonitoredTx{}
+		if err := s.scanMtx(rows, &mTx); err != nil {
+			return nil, err
+		}
+		mTxs = append(mTxs, mTx)
+	}
+
+	return mTxs, rows.Err()
+}
+
 // scanMtx scans a row and fill the provided instance of monitoredTx with
 // the row data
 func (s *PostgresStorage) scanMtx(row pgx.Row, mTx *txmTypes.MonitoredTx) error {
-	// id, from, to, nonce, value, data, gas, gas_offset, gas_price, status, history, created_at, updated_at, num_retries
+	// id, from, to, nonce, value, data, gas, gas_offset, gas_price, status, history, created_at, updated_at, num_retries,
+	// rollup_id, last_verified_batch, new_verified_batch
 	var from, status string
 	var to, data *string
 	var history []string
 	var value, blockNumber *uint64
 	var gasPrice uint64
+	var rollupID, lastVerifiedBatch, newVerifiedBatch *uint64
 
 	err := row.Scan(&mTx.Owner, &mTx.ID, &from, &to, &mTx.Nonce, &value,
 		&data, &mTx.Gas, &mTx.GasOffset, &gasPrice, &status, &blockNumber, &history,
-		&mTx.CreatedAt, &mTx.UpdatedAt, &mTx.NumRetries)
+		&mTx.CreatedAt, &mTx.UpdatedAt, &mTx.NumRetries,
+		&rollupID, &lastVerifiedBatch, &newVerifiedBatch)
 	if err != nil {
 		return err
 	}
 
+	if rollupID != nil && lastVerifiedBatch != nil && newVerifiedBatch != nil {
+		mTx.Batches = &txmTypes.RollupBatches{
+			RollupID:          uint32(*rollupID),
+			LastVerifiedBatch: *lastVerifiedBatch,
+			NewVerifiedBatch:  *newVerifiedBatch,
+		}
+	}
+
 	mTx.From = common.HexToAddress(from)
 	mTx.GasPrice = big.NewInt(0).SetUint64(gasPrice)
 	mTx.Status = txmTypes.MonitoredTxStatus(status)
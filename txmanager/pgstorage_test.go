@@ -206,6 +206,92 @@ func TestAddAndGetByStatus(t *testing.T) {
 	assert.Equal(t, "confirmed2", mTxs[7].ID)
 }
 
+func TestList(t *testing.T) {
+	dbCfg := newStateDBConfig(t)
+	storage, err := NewPostgresStorageWithCfg(dbCfg)
+	require.NoError(t, err)
+
+	to := common.HexToAddress("0x2")
+	baseMtx := txmTypes.MonitoredTx{
+		Owner:    "owner",
+		From:     common.HexToAddress("0x1"),
+		To:       &to,
+		Value:    big.NewInt(2),
+		GasPrice: big.NewInt(4),
+	}
+
+	type mTxReplaceInfo struct {
+		id      string
+		status  txmTypes.MonitoredTxStatus
+		batches *txmTypes.RollupBatches
+	}
+
+	// ids grow with the insertion order, so the listing order doesn't depend on created_at ties
+	mTxsReplaceInfo := []mTxReplaceInfo{
+		{id: "tx1", status: txmTypes.MonitoredTxStatusDone},
+		{id: "tx2", status: txmTypes.MonitoredTxStatusConfirmed, batches: &txmTypes.RollupBatches{RollupID: 1, LastVerifiedBatch: 0, NewVerifiedBatch: 10}},
+		{id: "tx3", status: txmTypes.MonitoredTxStatusSent, batches: &txmTypes.RollupBatches{RollupID: 2, LastVerifiedBatch: 0, NewVerifiedBatch: 5}},
+		{id: "tx4", status: txmTypes.MonitoredTxStatusSent, batches: &txmTypes.RollupBatches{RollupID: 1, LastVerifiedBatch: 10, NewVerifiedBatch: 20}},
+	}
+
+	for _, replaceInfo := range mTxsReplaceInfo {
+		baseMtx.ID = replaceInfo.id
+		baseMtx.Status = replaceInfo.status
+		baseMtx.Batches = replaceInfo.batches
+		require.NoError(t, storage.Add(context.Background(), baseMtx, nil))
+	}
+
+	ids := func(mTxs []txmTypes.MonitoredTx) []string {
+		ids := make([]string, 0, len(mTxs))
+		for _, mTx := range mTxs {
+			ids = append(ids, mTx.ID)
+		}
+		return ids
+	}
+
+	mTxs, err := storage.List(context.Background(), txmTypes.MonitoredTxFilter{Owner: "owner", Limit: 10}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx4", "tx3", "tx2", "tx1"}, ids(mTxs))
+	assert.Nil(t, mTxs[3].Batches)
+	assert.Equal(t, &txmTypes.RollupBatches{RollupID: 1, LastVerifiedBatch: 10, NewVerifiedBatch: 20}, mTxs[0].Batches)
+
+	mTxs, err = storage.List(context.Background(), txmTypes.MonitoredTxFilter{Owner: "other", Limit: 10}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, mTxs)
+
+	rollupID := uint32(1)
+	mTxs, err = storage.List(context.Background(), txmTypes.MonitoredTxFilter{Owner: "owner", RollupID: &rollupID, Limit: 10}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx4", "tx2"}, ids(mTxs))
+
+	mTxs, err = storage.List(context.Background(), txmTypes.MonitoredTxFilter{
+		Owner:    "owner",
+		Statuses: []txmTypes.MonitoredTxStatus{txmTypes.MonitoredTxStatusSent},
+		Limit:    10,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx4", "tx3"}, ids(mTxs))
+
+	fromBatch, toBatch := uint64(6), uint64(10)
+	mTxs, err = storage.List(context.Background(), txmTypes.MonitoredTxFilter{Owner: "owner", FromBatch: &fromBatch, ToBatch: &toBatch, Limit: 10}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx2"}, ids(mTxs))
+
+	createdAfter := time.Now().Add(time.Hour)
+	mTxs, err = storage.List(context.Background(), txmTypes.MonitoredTxFilter{Owner: "owner", CreatedAfter: &createdAfter, Limit: 10}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, mTxs)
+
+	mTxs, err = storage.List(context.Background(), txmTypes.MonitoredTxFilter{Owner: "owner", Limit: 2}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx4", "tx3"}, ids(mTxs))
+
+	cursor := txmTypes.NewMonitoredTxCursor(mTxs[1])
+	mTxs, err = storage.List(context.Background(), txmTypes.MonitoredTxFilter{Owner: "owner", Cursor: &cursor, Limit: 2}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tx2", "tx1"}, ids(mTxs))
+}
+
 func TestAddAndGetBySenderAndStatus(t *testing.T) {
 	dbCfg := newStateDBConfig(t)
 	storage, err := NewPostgresStorageWithCfg(dbCfg)
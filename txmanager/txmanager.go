@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/0xPolygon/agglayer/config"
@@ -31,6 +32,17 @@ type Client struct {
 	etherman aggLayerTypes.IEtherman
 	storage  txmTypes.StorageInterface
 	state    txmTypes.StateInterface
+
+	// cycle is held while the monitored txs are processed, so the admin operations
+	// don't race with the monitoring loop
+	cycle  chan struct{}
+	paused atomic.Bool
+
+	// StatusListener, if set, is notified every time a new status of a monitored tx is persisted
+	StatusListener txmTypes.StatusListener
+
+	// Settlements, if set, reserves again the batch range of a failed tx before it's retried
+	Settlements txmTypes.SettlementReserver
 }
 
 // New creates new eth tx manager
@@ -40,6 +52,7 @@ func New(cfg config.EthTxManagerConfig, ethMan aggLayerTypes.IEtherman, storage
 		etherman: ethMan,
 		storage:  storage,
 		state:    state,
+		cycle:    make(chan struct{}, 1),
 	}
 
 	return c
@@ -57,7 +70,15 @@ func (c *Client) Start() {
 		case <-c.ctx.Done():
 			return
 		case <-time.After(c.cfg.FrequencyToMonitorTxs.Duration):
+			if c.paused.Load() {
+				continue
+			}
+
+			if err := c.lockCycle(c.ctx); err != nil {
+				return
+			}
 			err := c.monitorTxs(context.Background())
+			c.unlockCycle()
 			if err != nil {
 				c.logErrorAndWait("failed to monitor txs: %v", err)
 			}
@@ -71,7 +92,7 @@ func (c *Client) Stop() {
 }
 
 // Add a transaction to be sent and monitored
-func (c *Client) Add(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, dbTx pgx.Tx) error {
+func (c *Client) Add(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, batches *txmTypes.RollupBatches, dbTx pgx.Tx) error {
 	// get nonce
 	nonce, err := c.getTxNonce(ctx, from)
 	if err != nil {
@@ -113,6 +134,7 @@ func (c *Client) Add(ctx context.Context, owner, id string, from common.Address,
 		GasOffset: gasOffset,
 		GasPrice:  gasPrice,
 		Status:    txmTypes.MonitoredTxStatusCreated,
+		Batches:   batches,
 	}
 
 	// add to storage
@@ -129,6 +151,31 @@ func (c *Client) Add(ctx context.Context, owner, id string, from common.Address,
 	return nil
 }
 
+// List returns a page of the monitored txs matching the filter and the cursor
+// of the next page, nil if it's the last one
+func (c *Client) List(ctx context.Context, filter txmTypes.MonitoredTxFilter, dbTx pgx.Tx) ([]txmTypes.MonitoredTx, *txmTypes.MonitoredTxCursor, error) {
+	// one more tx is loaded to know whether there's a next page
+	limit := filter.Limit
+	filter.Limit++
+
+	mTxs, err := c.storage.List(ctx, filter, dbTx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if uint64(len(mTxs)) <= limit {
+		return mTxs, nil, nil
+	}
+
+	mTxs = mTxs[:limit]
+	if len(mTxs) == 0 {
+		return mTxs, nil, nil
+	}
+	next := txmTypes.NewMonitoredTxCursor(mTxs[len(mTxs)-1])
+
+	return mTxs, &next, nil
+}
+
 // Result returns the current result of the transaction execution with all the details
 func (c *Client) Result(ctx context.Context, owner, id string, dbTx pgx.Tx) (txmTypes.MonitoredTxResult, error) {
 	mTx, err := c.storage.Get(ctx, owner, id, dbTx)
@@ -167,9 +214,14 @@ func (c *Client) buildResult(ctx context.Context, mTx txmTypes.MonitoredTx) (txm
 	}
 
 	result := txmTypes.MonitoredTxResult{
-		ID:     mTx.ID,
-		Status: mTx.Status,
-		Txs:    txs,
+		ID:          mTx.ID,
+		Status:      mTx.Status,
+		Txs:         txs,
+		GasPrice:    mTx.GasPrice,
+		BlockNumber: mTx.BlockNumber,
+		NumRetries:  mTx.NumRetries,
+		CreatedAt:   mTx.CreatedAt,
+		UpdatedAt:   mTx.UpdatedAt,
 	}
 
 	return result, nil
@@ -214,6 +266,8 @@ func (c *Client) monitorTxs(ctx context.Context) error {
 func (c *Client) monitorTx(ctx context.Context, mTx txmTypes.MonitoredTx, logger *zap.SugaredLogger) {
 	var err error
 	logger.Info("processing")
+	// status of the monitored tx as last persisted, to notify only the changes
+	persistedStatus := mTx.Status
 	// check if any of the txs in the history was confirmed
 	var lastReceiptChecked types.Receipt
 	// monitored tx is confirmed until we find a successful receipt
@@ -270,7 +324,7 @@ func (c *Client) monitorTx(ctx context.Context, mTx txmTypes.MonitoredTx, logger
 			logger.Errorf("failed to review monitored tx nonce: %v", err)
 			return
 		}
-		err = c.storage.Update(ctx, mTx, nil)
+		err = c.update(ctx, mTx, &persistedStatus)
 		if err != nil {
 			logger.Errorf("failed to update monitored tx nonce change: %v", err)
 			return
@@ -285,7 +339,7 @@ func (c *Client) monitorTx(ctx context.Context, mTx txmTypes.MonitoredTx, logger
 		mTx.Status = txmTypes.MonitoredTxStatusFailed
 		logger.Infof("marked as failed because reached the num of retires limit: %v", err)
 		// update monitored tx changes into storage
-		err = c.storage.Update(ctx, mTx, nil)
+		err = c.update(ctx, mTx, &persistedStatus)
 		if err != nil {
 			logger.Errorf("failed to update monitored tx when num of retires reached: %v", err)
 		}
@@ -308,14 +362,14 @@ func (c *Client) monitorTx(ctx context.Context, mTx txmTypes.MonitoredTx, logger
 				mTx.NumRetries++
 
 				// update numRetries and return
-				if err := c.storage.Update(ctx, mTx, nil); err != nil {
+				if err := c.update(ctx, mTx, &persistedStatus); err != nil {
 					logger.Errorf("failed to update monitored tx review change: %v", err)
 				}
 
 				return
 			}
 
-			if err := c.storage.Update(ctx, mTx, nil); err != nil {
+			if err := c.update(ctx, mTx, &persistedStatus); err != nil {
 				logger.Errorf("failed to update monitored tx review change: %v", err)
 				return
 			}
@@ -342,7 +396,7 @@ func (c *Client) monitorTx(ctx context.Context, mTx txmTypes.MonitoredTx, logger
 			return
 		} else {
 			// update monitored tx changes into storage
-			err = c.storage.Update(ctx, mTx, nil)
+			err = c.update(ctx, mTx, &persistedStatus)
 			if err != nil {
 				logger.Errorf("failed to update monitored tx: %v", err)
 				return
@@ -366,7 +420,7 @@ func (c *Client) monitorTx(ctx context.Context, mTx txmTypes.MonitoredTx, logger
 				mTx.Status = txmTypes.MonitoredTxStatusSent
 				logger.Debugf("status changed to %v", string(mTx.Status))
 				// update monitored tx changes into storage
-				err = c.storage.Update(ctx, mTx, nil)
+				err = c.update(ctx, mTx, &persistedStatus)
 				if err != nil {
 					logger.Errorf("failed to update monitored tx changes: %v", err)
 					return
@@ -433,13 +487,28 @@ func (c *Client) monitorTx(ctx context.Context, mTx txmTypes.MonitoredTx, logger
 	}
 
 	// update monitored tx changes into storage
-	err = c.storage.Update(ctx, mTx, nil)
+	err = c.update(ctx, mTx, &persistedStatus)
 	if err != nil {
 		logger.Errorf("failed to update monitored tx: %v", err)
 		return
 	}
 }
 
+// update persists the monitored tx changes and notifies the status listener
+// if the status differs from the one persisted before
+func (c *Client) update(ctx context.Context, mTx txmTypes.MonitoredTx, persistedStatus *txmTypes.MonitoredTxStatus) error {
+	if err := c.storage.Update(ctx, mTx, nil); err != nil {
+		return err
+	}
+
+	if mTx.Status != *persistedStatus {
+		*persistedStatus = mTx.Status
+		c.NotifyStatusChange(mTx)
+	}
+
+	return nil
+}
+
 // getTxNonce get the nonce for the given account
 func (c *Client) getTxNonce(ctx context.Context, from common.Address) (uint64, error) {
 	// Get created transactions from the database for the given account
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -31,6 +32,26 @@ var defaultEthTxmanagerConfigForTests = config.EthTxManagerConfig{
 	MaxRetries: 10,
 }
 
+// statusRecorder records the statuses notified to a status listener
+type statusRecorder struct {
+	mu       sync.Mutex
+	statuses []txmTypes.MonitoredTxStatus
+}
+
+func (r *statusRecorder) OnStatusChange(mTx txmTypes.MonitoredTx) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statuses = append(r.statuses, mTx.Status)
+}
+
+func (r *statusRecorder) Statuses() []txmTypes.MonitoredTxStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.statuses
+}
+
 func TestTxGetMined(t *testing.T) {
 	dbCfg := newStateDBConfig(t)
 	etherman := mocks.NewEthermanMock(t)
@@ -38,6 +59,8 @@ func TestTxGetMined(t *testing.T) {
 	require.NoError(t, err)
 
 	ethTxManagerClient := New(defaultEthTxmanagerConfigForTests, etherman, storage, etherman)
+	statuses := &statusRecorder{}
+	ethTxManagerClient.StatusListener = statuses
 
 	owner := "owner"
 	id := "unique_id"
@@ -129,7 +152,7 @@ func TestTxGetMined(t *testing.T) {
 		Return(block, nil).
 		Once()
 
-	err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, gasOffset, nil)
+	err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, gasOffset, nil, nil)
 	require.NoError(t, err)
 
 	go ethTxManagerClient.Start()
@@ -143,6 +166,7 @@ func TestTxGetMined(t *testing.T) {
 	require.Equal(t, signedTx, result.Txs[signedTx.Hash()].Tx)
 	require.Equal(t, receipt, result.Txs[signedTx.Hash()].Receipt)
 	require.Equal(t, "", result.Txs[signedTx.Hash()].RevertMessage)
+	require.Equal(t, []txmTypes.MonitoredTxStatus{txmTypes.MonitoredTxStatusSent, txmTypes.MonitoredTxStatusConfirmed}, statuses.Statuses())
 }
 
 func TestTxGetMinedAfterReviewed(t *testing.T) {
@@ -298,7 +322,7 @@ func TestTxGetMinedAfterReviewed(t *testing.T) {
 		Return("", nil).
 		Once()
 
-	err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, gasOffset, nil)
+	err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, gasOffset, nil, nil)
 	require.NoError(t, err)
 
 	go ethTxManagerClient.Start()
@@ -485,7 +509,7 @@ func TestExecutionReverted(t *testing.T) {
 		Return("", nil).
 		Once()
 
-	err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, gasOffset, nil)
+	err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, gasOffset, nil, nil)
 	require.NoError(t, err)
 
 	go ethTxManagerClient.Start()
@@ -579,7 +603,7 @@ func TestGasPriceMarginAndLimit(t *testing.T) {
 
 			expectedSuggestedGasPrice := big.NewInt(tc.expectedGasPrice)
 
-			err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, gasOffset, nil)
+			err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, gasOffset, nil, nil)
 			require.NoError(t, err)
 
 			monitoredTx, err := storage.Get(ctx, owner, id, nil)
@@ -656,7 +680,7 @@ func TestGasOffset(t *testing.T) {
 				Return(suggestedGasPrice, nil).
 				Once()
 
-			err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, tc.gasOffset, nil)
+			err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, tc.gasOffset, nil, nil)
 			require.NoError(t, err)
 
 			monitoredTx, err := storage.Get(ctx, owner, id, nil)
@@ -773,7 +797,7 @@ func TestFailedToEstimateTxWithForcedGasGetMined(t *testing.T) {
 		Return(block, nil).
 		Once()
 
-	err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, gasOffset, nil)
+	err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, gasOffset, nil, nil)
 	require.NoError(t, err)
 
 	go ethTxManagerClient.Start()
@@ -907,7 +931,7 @@ func TestTxRetry_MaxRetriesReached(t *testing.T) {
 		Return("", nil).
 		Once()
 
-	err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, gasOffset, nil)
+	err = ethTxManagerClient.Add(ctx, owner, id, from, to, value, data, gasOffset, nil, nil)
 	require.NoError(t, err)
 
 	go ethTxManagerClient.Start()
@@ -30,8 +30,21 @@ type StorageInterface interface {
 	GetByStatus(ctx context.Context, owner *string, statuses []MonitoredTxStatus, dbTx pgx.Tx) ([]MonitoredTx, error)
 	GetBySenderAndStatus(ctx context.Context, sender common.Address, statuses []MonitoredTxStatus, dbTx pgx.Tx) ([]MonitoredTx, error)
 	Update(ctx context.Context, mTx MonitoredTx, dbTx pgx.Tx) error
+	List(ctx context.Context, filter MonitoredTxFilter, dbTx pgx.Tx) ([]MonitoredTx, error)
 }
 
 type StateInterface interface {
 	GetLastBlock(ctx context.Context, dbTx pgx.Tx) (*state.Block, error)
 }
+
+// StatusListener is notified of the new statuses of the monitored txs once they're persisted,
+// it must not block as it's called while the monitored tx is being processed
+type StatusListener interface {
+	OnStatusChange(mTx MonitoredTx)
+}
+
+// SettlementReserver tracks the batch ranges being settled on L1, a failed tx is only
+// monitored again if its range can still be settled on top of what is settled or being settled
+type SettlementReserver interface {
+	ReserveSettlement(ctx context.Context, mTx MonitoredTx) error
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor when a cursor can't be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const cursorSeparator = "|"
+
+// MonitoredTxFilter selects the monitored txs of an owner returned by a listing
+type MonitoredTxFilter struct {
+	Owner string
+
+	// RollupID selects the txs verifying batches of a rollup
+	RollupID *uint32
+
+	// Statuses selects the txs in any of the statuses, all of them if empty
+	Statuses []MonitoredTxStatus
+
+	// FromBatch and ToBatch select the txs verifying at least one batch of the range, both inclusive
+	FromBatch *uint64
+	ToBatch   *uint64
+
+	// CreatedAfter and CreatedBefore select the txs created within the time window, both inclusive
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+
+	// Cursor resumes the listing after the last tx of the previous page
+	Cursor *MonitoredTxCursor
+
+	// Limit is the maximum number of txs returned
+	Limit uint64
+}
+
+// MonitoredTxCursor is the position of a monitored tx in a listing, newest txs come first
+type MonitoredTxCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// NewMonitoredTxCursor returns the cursor resuming a listing after the given tx
+func NewMonitoredTxCursor(mTx MonitoredTx) MonitoredTxCursor {
+	return MonitoredTxCursor{CreatedAt: mTx.CreatedAt, ID: mTx.ID}
+}
+
+// Encode returns the opaque representation of the cursor handed over to the callers
+func (c MonitoredTxCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + cursorSeparator + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeMonitoredTxCursor parses a cursor returned by Encode
+func DecodeMonitoredTxCursor(encoded string) (MonitoredTxCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return MonitoredTxCursor{}, ErrInvalidCursor
+	}
+
+	createdAt, id, found := strings.Cut(string(raw), cursorSeparator)
+	if !found || id == "" {
+		return MonitoredTxCursor{}, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return MonitoredTxCursor{}, ErrInvalidCursor
+	}
+
+	return MonitoredTxCursor{CreatedAt: t, ID: id}, nil
+}
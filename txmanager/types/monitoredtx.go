@@ -19,6 +19,11 @@ var (
 	// ErrExecutionReverted returned when trying to get the revert message
 	// but the call fails without revealing the revert reason
 	ErrExecutionReverted = errors.New("execution reverted")
+
+	// ErrStatusNotAllowed when an operation isn't allowed in the current status of the monitored tx
+	ErrStatusNotAllowed = errors.New("operation not allowed in the current status")
+	// ErrGasPriceTooLow when the gas price of a replacement isn't higher than the one of the monitored tx
+	ErrGasPriceTooLow = errors.New("gas price must be higher than the current one")
 )
 
 const (
@@ -112,6 +117,17 @@ type MonitoredTx struct {
 
 	// NumRetries number of times tx was sent to the network
 	NumRetries uint64
+
+	// Batches is the batch range of a rollup this tx verifies on L1,
+	// nil for the txs created before it was recorded
+	Batches *RollupBatches
+}
+
+// RollupBatches is the batch range of a rollup verified on L1 by a monitored tx
+type RollupBatches struct {
+	RollupID          uint32
+	LastVerifiedBatch uint64
+	NewVerifiedBatch  uint64
 }
 
 // Tx uses the current information to build a tx
@@ -203,6 +219,17 @@ type MonitoredTxResult struct {
 	ID     string
 	Status MonitoredTxStatus
 	Txs    map[common.Hash]TxResult
+
+	// GasPrice is the gas price of the last tx sent
+	GasPrice *big.Int
+	// BlockNumber is the block where the tx was mined, if it was
+	BlockNumber *big.Int
+	// NumRetries number of times the tx was sent to the network
+	NumRetries uint64
+	// CreatedAt date time the monitored tx was created
+	CreatedAt time.Time
+	// UpdatedAt last date time the monitored tx was updated
+	UpdatedAt time.Time
 }
 
 // TxResult represents the result of a execution of a ethereum transaction in the block chain
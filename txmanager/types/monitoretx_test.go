@@ -1,9 +1,11 @@
 package types
 
 import (
+	"encoding/base64"
 	"encoding/hex"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -129,3 +131,18 @@ func TestMonitoredTx_DataStringPtr(t *testing.T) {
 	assert.NotNil(t, actual)
 	assert.Equal(t, expected, *actual)
 }
+
+func TestMonitoredTxCursor(t *testing.T) {
+	mTx := MonitoredTx{ID: "id|with|separators", CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)}
+
+	cursor, err := DecodeMonitoredTxCursor(NewMonitoredTxCursor(mTx).Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, mTx.ID, cursor.ID)
+	assert.True(t, mTx.CreatedAt.Equal(cursor.CreatedAt))
+
+	_, err = DecodeMonitoredTxCursor("not a cursor")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = DecodeMonitoredTxCursor(base64.RawURLEncoding.EncodeToString([]byte("yesterday|id")))
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
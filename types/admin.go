@@ -0,0 +1,28 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AdminAuditEntry records an action performed through the admin namespace
+type AdminAuditEntry struct {
+	ID uint64
+
+	// Actor is the operator authenticated by its token or client certificate
+	Actor      string
+	RemoteAddr string
+
+	// Action is the admin method called
+	Action string
+
+	// MonitoredTxID is the monitored tx the action was performed on, empty for the
+	// actions on the tx manager itself
+	MonitoredTxID string
+	Params        json.RawMessage
+
+	// Error is the reason the action failed, empty if it succeeded
+	Error string
+
+	CreatedAt time.Time
+}
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"time"
+
+	"github.com/0xPolygon/agglayer/tx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// TxOutcomePending means the tx is still being processed
+	TxOutcomePending = TxOutcome("pending")
+
+	// TxOutcomeSettled means the tx was mined on L1 successfully
+	TxOutcomeSettled = TxOutcome("settled")
+
+	// TxOutcomeRejected means the tx failed the checks or couldn't be handed over to L1
+	TxOutcomeRejected = TxOutcome("rejected")
+
+	// TxOutcomeFailed means the tx was mined on L1 but reverted
+	TxOutcomeFailed = TxOutcome("failed")
+)
+
+// TxOutcome is the final outcome of a tx
+type TxOutcome string
+
+// String returns a string representation of the outcome
+func (o TxOutcome) String() string {
+	return string(o)
+}
+
+// TxDetails represents the lifecycle of a tx received through interop_sendTx
+type TxDetails struct {
+	// Hash identifies the tx, it's the hash of the inner tx
+	Hash common.Hash `json:"hash"`
+
+	// Tx is the tx as received
+	Tx tx.Tx `json:"tx"`
+
+	// Signer is the address recovered from the signature
+	Signer common.Address `json:"signer"`
+
+	// SignatureScheme is the scheme the tx was signed with, empty when the signer isn't authorized
+	SignatureScheme string `json:"signatureScheme,omitempty"`
+
+	// Status is the same status returned by interop_getTxStatus
+	Status string `json:"status"`
+
+	// Outcome is the final outcome of the tx
+	Outcome TxOutcome `json:"outcome"`
+
+	// Error is the reason why the tx was rejected
+	Error string `json:"error,omitempty"`
+
+	// ErrorCode is the RPC error code of the reason why the tx was rejected
+	ErrorCode int `json:"errorCode,omitempty"`
+
+	// Stages are the date times the tx reached each stage
+	Stages TxStages `json:"stages"`
+
+	// Settlement is the L1 settlement of the tx, once handed over to the eth tx manager
+	Settlement *TxSettlement `json:"settlement,omitempty"`
+}
+
+// TxStages are the date times a tx reached each processing stage
+type TxStages struct {
+	ReceivedAt time.Time  `json:"receivedAt"`
+	VerifiedAt *time.Time `json:"verifiedAt,omitempty"`
+	SettlingAt *time.Time `json:"settlingAt,omitempty"`
+	RejectedAt *time.Time `json:"rejectedAt,omitempty"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// TxSettlement represents the monitored tx settling a tx on L1
+type TxSettlement struct {
+	// Status is the status of the monitored tx
+	Status string `json:"status"`
+
+	// GasPrice is the gas price of the last attempt
+	GasPrice *hexutil.Big `json:"gasPrice,omitempty"`
+
+	// BlockNumber is the L1 block the tx was mined in
+	BlockNumber *hexutil.Big `json:"blockNumber,omitempty"`
+
+	// NumRetries is the number of times the tx was sent to L1
+	NumRetries hexutil.Uint64 `json:"numRetries"`
+
+	// Attempts are all the L1 txs sent, sorted by gas price
+	Attempts []L1Attempt `json:"attempts"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// L1Attempt represents a L1 tx sent to settle a tx
+type L1Attempt struct {
+	Hash     common.Hash    `json:"hash"`
+	Nonce    hexutil.Uint64 `json:"nonce"`
+	GasPrice *hexutil.Big   `json:"gasPrice,omitempty"`
+	Gas      hexutil.Uint64 `json:"gas"`
+
+	// Receipt is nil until the L1 tx is mined
+	Receipt *ethTypes.Receipt `json:"receipt,omitempty"`
+
+	// RevertMessage is the reason the L1 tx reverted
+	RevertMessage string `json:"revertMessage,omitempty"`
+}
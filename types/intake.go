@@ -0,0 +1,98 @@
+package types
+
+import (
+	"errors"
+	"time"
+
+	"github.com/0xPolygon/agglayer/tx"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrIntakeTxNotFound when the tx is not in the intake queue
+	ErrIntakeTxNotFound = errors.New("intake tx not found")
+	// ErrIntakeTxAlreadyExists when the tx is already in the intake queue
+	ErrIntakeTxAlreadyExists = errors.New("intake tx already exists")
+)
+
+const (
+	// IntakeTxStatusReceived means the tx was persisted and waits to be processed
+	IntakeTxStatusReceived = IntakeTxStatus("received")
+
+	// IntakeTxStatusVerified means the tx passed the ZKP, signature and soundness checks
+	IntakeTxStatusVerified = IntakeTxStatus("verified")
+
+	// IntakeTxStatusRejected means the tx failed one of the checks or couldn't be settled,
+	// the reason is kept in the error of the intake tx
+	IntakeTxStatusRejected = IntakeTxStatus("rejected")
+
+	// IntakeTxStatusSettling means the tx was handed over to the eth tx manager,
+	// from here on its status is the one of the monitored tx
+	IntakeTxStatusSettling = IntakeTxStatus("settling")
+)
+
+// IntakeTxStatus represents the stage of a tx in the intake queue
+type IntakeTxStatus string
+
+// String returns a string representation of the status
+func (s IntakeTxStatus) String() string {
+	return string(s)
+}
+
+// IntakeTx represents a tx received through interop_sendTx and the outcome of each processing stage
+type IntakeTx struct {
+	// Hash identifies the tx, it's the hash of the inner tx
+	Hash common.Hash
+
+	// SignedTx is the tx as received
+	SignedTx tx.SignedTx
+
+	// Status is the current stage of the tx
+	Status IntakeTxStatus
+
+	// Error is the reason why the tx was rejected
+	Error string
+
+	// ErrorCode is the RPC error code of the reason why the tx was rejected
+	ErrorCode int
+
+	// Signer is the authorized signer of the tx, recovered when it was verified
+	Signer common.Address
+
+	// SignatureScheme is the scheme the signer was authorized with, empty until the tx is verified
+	SignatureScheme string
+
+	// Attempts is the number of times the processing of the tx was postponed by a failure unrelated to the tx
+	Attempts int
+
+	// NextAttemptAt date time before which the tx isn't processed again, nil if it's due
+	NextAttemptAt *time.Time
+
+	// ReceivedAt date time the tx was received
+	ReceivedAt time.Time
+
+	// VerifiedAt date time the tx passed the checks
+	VerifiedAt *time.Time
+
+	// SettlingAt date time the tx was handed over to the eth tx manager
+	SettlingAt *time.Time
+
+	// RejectedAt date time the tx was rejected
+	RejectedAt *time.Time
+
+	// UpdatedAt last date time it was updated
+	UpdatedAt time.Time
+}
+
+// NewIntakeTx returns an intake tx for a just received signed tx
+func NewIntakeTx(signedTx tx.SignedTx) IntakeTx {
+	now := time.Now().UTC().Round(time.Microsecond)
+
+	return IntakeTx{
+		Hash:       signedTx.Tx.Hash(),
+		SignedTx:   signedTx,
+		Status:     IntakeTxStatusReceived,
+		ReceivedAt: now,
+		UpdatedAt:  now,
+	}
+}
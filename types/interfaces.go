@@ -15,19 +15,53 @@ import (
 
 type IDB interface {
 	BeginStateTransaction(ctx context.Context) (pgx.Tx, error)
+	AddIntakeTx(ctx context.Context, itx IntakeTx, dbTx pgx.Tx) error
+	GetIntakeTx(ctx context.Context, hash common.Hash, dbTx pgx.Tx) (IntakeTx, error)
+	GetIntakeTxsByStatus(ctx context.Context, statuses []IntakeTxStatus, dbTx pgx.Tx) ([]IntakeTx, error)
+	UpdateIntakeTx(ctx context.Context, itx IntakeTx, dbTx pgx.Tx) error
+	UpsertDiscoveredRollup(ctx context.Context, rollup Rollup, dbTx pgx.Tx) error
+	GetDiscoveredRollups(ctx context.Context, dbTx pgx.Tx) ([]Rollup, error)
+	AddAdminAuditEntry(ctx context.Context, entry AdminAuditEntry, dbTx pgx.Tx) error
+}
+
+// IntakeStatusListener is notified of the new statuses of the txs in the intake queue once they're persisted,
+// it must not block as it's called while the tx is being processed
+type IntakeStatusListener interface {
+	OnIntakeStatusChange(itx IntakeTx)
 }
 
 type IEtherman interface {
 	GetSequencerAddr(rollupId uint32) (common.Address, error)
-	BuildTrustedVerifyBatchesTxData(lastVerifiedBatch, newVerifiedBatch uint64, proof tx.ZKP, rollupId uint32) (data []byte, err error)
+	GetCachedSequencerAddr(rollupId uint32) (common.Address, bool)
+	GetFreshSequencerAddr(rollupId uint32) (common.Address, error)
+	GetLastVerifiedBatch(rollupId uint32) (uint64, error)
+	GetRollupCount() (uint32, error)
+	GetRollup(rollupId uint32) (Rollup, error)
+	GetRollupL1State(rollupId uint32) (RollupL1State, error)
+	GetUpdatedRollups(ctx context.Context, fromBlock, toBlock uint64) ([]uint32, error)
+	BuildTrustedVerifyBatchesTxData(lastVerifiedBatch, newVerifiedBatch uint64, proof tx.ZKP, rollupId uint32, pendingStateNum uint64) (data []byte, err error)
 	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
 	txmTypes.EthermanInterface
 	GetLastBlock(ctx context.Context, dbTx pgx.Tx) (*state.Block, error)
+	GetFinalizedBlock(ctx context.Context) (*state.Block, error)
 }
 
 type IEthTxManager interface {
-	Add(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, dbTx pgx.Tx) error
+	Add(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, batches *txmTypes.RollupBatches, dbTx pgx.Tx) error
 	Result(ctx context.Context, owner, id string, dbTx pgx.Tx) (txmTypes.MonitoredTxResult, error)
+	List(ctx context.Context, filter txmTypes.MonitoredTxFilter, dbTx pgx.Tx) ([]txmTypes.MonitoredTx, *txmTypes.MonitoredTxCursor, error)
+}
+
+// ITxManagerAdmin are the operations on the tx manager exposed through the admin namespace
+type ITxManagerAdmin interface {
+	Pause()
+	Resume()
+	Paused() bool
+	Retry(ctx context.Context, owner, id string, dbTx pgx.Tx) (txmTypes.MonitoredTx, error)
+	Replace(ctx context.Context, owner, id string, gasPrice *big.Int, dbTx pgx.Tx) (txmTypes.MonitoredTx, error)
+	Cancel(ctx context.Context, owner, id string, gasPrice *big.Int, dbTx pgx.Tx) (txmTypes.MonitoredTx, error)
+	MarkDone(ctx context.Context, owner, id string, dbTx pgx.Tx) (txmTypes.MonitoredTx, error)
+	NotifyStatusChange(mTx txmTypes.MonitoredTx)
 }
 
 type IZkEVMClient interface {
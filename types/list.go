@@ -0,0 +1,129 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	txmTypes "github.com/0xPolygon/agglayer/txmanager/types"
+)
+
+// ErrInvalidTxListFilter when a listing filter selects an empty range or an unknown status
+var ErrInvalidTxListFilter = errors.New("invalid tx list filter")
+
+// listableStatuses are the statuses of the settlements that can be selected by a listing
+var listableStatuses = map[string]txmTypes.MonitoredTxStatus{
+	txmTypes.MonitoredTxStatusCreated.String():   txmTypes.MonitoredTxStatusCreated,
+	txmTypes.MonitoredTxStatusSent.String():      txmTypes.MonitoredTxStatusSent,
+	txmTypes.MonitoredTxStatusFailed.String():    txmTypes.MonitoredTxStatusFailed,
+	txmTypes.MonitoredTxStatusConfirmed.String(): txmTypes.MonitoredTxStatusConfirmed,
+	txmTypes.MonitoredTxStatusReorged.String():   txmTypes.MonitoredTxStatusReorged,
+	txmTypes.MonitoredTxStatusDone.String():      txmTypes.MonitoredTxStatusDone,
+}
+
+// TxListFilter selects the txs returned by interop_listTxs, all the filters are optional
+type TxListFilter struct {
+	// RollupID selects the txs of a rollup
+	RollupID *uint32 `json:"rollupId,omitempty"`
+
+	// Statuses selects the txs whose settlement is in any of the statuses
+	Statuses []string `json:"statuses,omitempty"`
+
+	// FromBatch and ToBatch select the txs verifying at least one batch of the range, both inclusive
+	FromBatch *hexutil.Uint64 `json:"fromBatch,omitempty"`
+	ToBatch   *hexutil.Uint64 `json:"toBatch,omitempty"`
+
+	// CreatedAfter and CreatedBefore select the txs handed over to L1 within the time window, both inclusive
+	CreatedAfter  *time.Time `json:"createdAfter,omitempty"`
+	CreatedBefore *time.Time `json:"createdBefore,omitempty"`
+
+	// Cursor is the NextCursor of the previous page
+	Cursor string `json:"cursor,omitempty"`
+
+	// Limit is the maximum number of txs returned
+	Limit uint64 `json:"limit,omitempty"`
+}
+
+// Validate checks the ranges of the filter aren't empty and the statuses are known
+func (f TxListFilter) Validate() error {
+	if f.FromBatch != nil && f.ToBatch != nil && *f.FromBatch > *f.ToBatch {
+		return fmt.Errorf("%w: fromBatch is greater than toBatch", ErrInvalidTxListFilter)
+	}
+
+	if f.CreatedAfter != nil && f.CreatedBefore != nil && f.CreatedAfter.After(*f.CreatedBefore) {
+		return fmt.Errorf("%w: createdAfter is later than createdBefore", ErrInvalidTxListFilter)
+	}
+
+	_, err := f.MonitoredTxStatuses()
+
+	return err
+}
+
+// MonitoredTxStatuses returns the statuses selected by the filter
+func (f TxListFilter) MonitoredTxStatuses() ([]txmTypes.MonitoredTxStatus, error) {
+	statuses := make([]txmTypes.MonitoredTxStatus, 0, len(f.Statuses))
+	for _, s := range f.Statuses {
+		status, ok := listableStatuses[s]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown status %s", ErrInvalidTxListFilter, s)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// TxList is a page of txs returned by interop_listTxs, newest first
+type TxList struct {
+	Txs []TxSummary `json:"txs"`
+
+	// NextCursor resumes the listing, empty on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// TxSummary represents the settlement of a tx in a listing
+type TxSummary struct {
+	// Hash identifies the tx, it's the hash of the inner tx
+	Hash common.Hash `json:"hash"`
+
+	// RollupID and the batch range are empty for the txs settled before they were recorded
+	RollupID          *uint32         `json:"rollupId,omitempty"`
+	LastVerifiedBatch *hexutil.Uint64 `json:"lastVerifiedBatch,omitempty"`
+	NewVerifiedBatch  *hexutil.Uint64 `json:"newVerifiedBatch,omitempty"`
+
+	// Status is the status of the monitored tx
+	Status string `json:"status"`
+
+	// BlockNumber is the L1 block the tx was mined in
+	BlockNumber *hexutil.Big `json:"blockNumber,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// NewTxSummary returns the summary of the settlement of a tx
+func NewTxSummary(mTx txmTypes.MonitoredTx) TxSummary {
+	summary := TxSummary{
+		Hash:        common.HexToHash(mTx.ID),
+		Status:      mTx.Status.String(),
+		BlockNumber: (*hexutil.Big)(mTx.BlockNumber),
+		CreatedAt:   mTx.CreatedAt,
+		UpdatedAt:   mTx.UpdatedAt,
+	}
+
+	if mTx.Batches != nil {
+		rollupID := mTx.Batches.RollupID
+		lastVerifiedBatch := hexutil.Uint64(mTx.Batches.LastVerifiedBatch)
+		newVerifiedBatch := hexutil.Uint64(mTx.Batches.NewVerifiedBatch)
+
+		summary.RollupID = &rollupID
+		summary.LastVerifiedBatch = &lastVerifiedBatch
+		summary.NewVerifiedBatch = &newVerifiedBatch
+	}
+
+	return summary
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrUnknownRollup when the rollup ID isn't registered in the rollup manager
+	ErrUnknownRollup = errors.New("unknown rollup")
+	// ErrRollupsNotSynced when the rollups haven't been discovered from L1 yet
+	ErrRollupsNotSynced = errors.New("rollups not synced from L1 yet")
+)
+
+// Rollup is a rollup registered in the rollup manager
+type Rollup struct {
+	ID       uint32
+	Contract common.Address
+	ChainID  uint64
+	// Verifier is the contract verifying the proofs of the rollup
+	Verifier common.Address
+	ForkID   uint64
+	// RollupTypeID is the rollup type the rollup was created from or upgraded to
+	RollupTypeID uint64
+	// RollupCompatibilityID tells the kind of verifier of the rollup, which can only
+	// be upgraded to rollup types with the same one
+	RollupCompatibilityID uint8
+	UpdatedAt             time.Time
+}
+
+// IRollupDiscovery tells the rollups registered in the rollup manager
+type IRollupDiscovery interface {
+	Rollup(rollupID uint32) (Rollup, error)
+}
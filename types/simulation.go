@@ -0,0 +1,76 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// SimulationStepPrevalidate is the check of the tx that doesn't need any network I/O
+	SimulationStepPrevalidate = SimulationStep("prevalidate")
+
+	// SimulationStepCheckTx checks the rollup is known and the soundness of the tx can be asserted
+	SimulationStepCheckTx = SimulationStep("checkTx")
+
+	// SimulationStepVerify verifies the signature and the ZKP of the tx
+	SimulationStepVerify = SimulationStep("verify")
+
+	// SimulationStepExecute checks the soundness of the tx against the full nodes
+	SimulationStepExecute = SimulationStep("execute")
+
+	// SimulationStepBuildCalldata builds the calldata of the L1 tx settling the tx
+	SimulationStepBuildCalldata = SimulationStep("buildCalldata")
+
+	// SimulationStepEstimateGas estimates the gas of the L1 tx settling the tx
+	SimulationStepEstimateGas = SimulationStep("estimateGas")
+)
+
+// SimulationStep is a step of the processing of a tx run by interop_simulateTx
+type SimulationStep string
+
+// String returns a string representation of the step
+func (s SimulationStep) String() string {
+	return string(s)
+}
+
+// TxSimulation is the result of processing a tx without settling it
+type TxSimulation struct {
+	// Hash identifies the tx, it's the hash of the inner tx
+	Hash common.Hash `json:"hash"`
+
+	// Success is whether every step passed, so the tx would be settled if sent
+	Success bool `json:"success"`
+
+	// Steps are the outcomes of the steps run, in order
+	Steps []SimulationStepResult `json:"steps"`
+
+	// To is the rollup manager the L1 tx would be sent to
+	To common.Address `json:"to"`
+
+	// From is the address the L1 tx would be sent from
+	From common.Address `json:"from"`
+
+	// Calldata is the calldata of the L1 tx, if it could be built
+	Calldata hexutil.Bytes `json:"calldata,omitempty"`
+
+	// GasEstimate is the gas of the L1 tx, if it could be estimated
+	GasEstimate *hexutil.Uint64 `json:"gasEstimate,omitempty"`
+
+	// RevertReason is the decoded reason why the L1 call reverted, if it did
+	RevertReason string `json:"revertReason,omitempty"`
+}
+
+// SimulationStepResult is the outcome of a step of a simulation
+type SimulationStepResult struct {
+	// Step is the step run
+	Step SimulationStep `json:"step"`
+
+	// Passed is whether the step succeeded
+	Passed bool `json:"passed"`
+
+	// Error is the reason why the step failed
+	Error string `json:"error,omitempty"`
+
+	// ErrorCode is the RPC error code of the reason why the step failed
+	ErrorCode int `json:"errorCode,omitempty"`
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RollupState is the state of a rollup returned by interop_getRollupState, combining
+// the settlements of the agglayer with the rollup data of the rollup manager
+type RollupState struct {
+	RollupID uint32 `json:"rollupId"`
+
+	// LastSettled is the last batch settled by the agglayer, empty if none was
+	LastSettled *SettledBatch `json:"lastSettled,omitempty"`
+
+	// InFlight are the settlements handed over to L1 and not mined yet, oldest first
+	InFlight []TxSummary `json:"inFlight"`
+
+	// L1 is the rollup data as registered in the rollup manager
+	L1 RollupL1State `json:"l1"`
+}
+
+// SettledBatch is a batch verified on L1 by a tx of the agglayer
+type SettledBatch struct {
+	// TxHash is the hash of the tx received through interop_sendTx
+	TxHash      common.Hash    `json:"txHash"`
+	BatchNumber hexutil.Uint64 `json:"batchNumber"`
+
+	// StateRoot and LocalExitRoot are the roots proven by the tx, empty if it wasn't received through the intake queue
+	StateRoot     *common.Hash `json:"stateRoot,omitempty"`
+	LocalExitRoot *common.Hash `json:"localExitRoot,omitempty"`
+
+	// L1BlockNumber and L1TxHash are the L1 block and tx the batch was settled in
+	L1BlockNumber *hexutil.Big `json:"l1BlockNumber,omitempty"`
+	L1TxHash      *common.Hash `json:"l1TxHash,omitempty"`
+
+	SettledAt time.Time `json:"settledAt"`
+}
+
+// RollupL1State is the verification state of a rollup in the rollup manager
+type RollupL1State struct {
+	LastVerifiedBatch hexutil.Uint64 `json:"lastVerifiedBatch"`
+
+	// StateRoot is the state root of the last verified batch
+	StateRoot common.Hash `json:"stateRoot"`
+
+	// LocalExitRoot is the local exit root of the last verified batch
+	LocalExitRoot common.Hash `json:"localExitRoot"`
+
+	LastBatchSequenced           hexutil.Uint64 `json:"lastBatchSequenced"`
+	LastPendingState             hexutil.Uint64 `json:"lastPendingState"`
+	LastPendingStateConsolidated hexutil.Uint64 `json:"lastPendingStateConsolidated"`
+}
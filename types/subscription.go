@@ -0,0 +1,63 @@
+package types
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrInvalidTxStatusFilter when a subscription filter selects neither a tx nor a rollup
+var ErrInvalidTxStatusFilter = errors.New("either a tx hash or a rollup ID must be provided")
+
+// TxStatusFilter selects the txs whose status changes are pushed to an interop_subscribe subscriber
+type TxStatusFilter struct {
+	// TxHash selects a single tx
+	TxHash *common.Hash `json:"txHash,omitempty"`
+
+	// RollupID selects all the txs of a rollup
+	RollupID *uint32 `json:"rollupId,omitempty"`
+}
+
+// Validate checks the filter selects either a tx or a rollup
+func (f TxStatusFilter) Validate() error {
+	if (f.TxHash == nil) == (f.RollupID == nil) {
+		return ErrInvalidTxStatusFilter
+	}
+
+	return nil
+}
+
+// Matches returns whether the status change is selected by the filter
+func (f TxStatusFilter) Matches(change TxStatusChange) bool {
+	if f.TxHash != nil {
+		return *f.TxHash == change.Hash
+	}
+
+	return f.RollupID != nil && *f.RollupID == change.RollupID
+}
+
+// TxStatusChange is pushed to the interop_subscribe subscribers when a new status of a tx is persisted
+type TxStatusChange struct {
+	// Hash identifies the tx, it's the hash of the inner tx
+	Hash common.Hash `json:"hash"`
+
+	// RollupID is the rollup the tx belongs to
+	RollupID uint32 `json:"rollupId"`
+
+	// Status is the new status, the same returned by interop_getTxStatus
+	Status string `json:"status"`
+
+	// BlockNumber is the L1 block the tx was mined in
+	BlockNumber *hexutil.Big `json:"blockNumber,omitempty"`
+
+	// Error is the reason why the tx was rejected
+	Error string `json:"error,omitempty"`
+
+	// ErrorCode is the RPC error code of the reason why the tx was rejected
+	ErrorCode int `json:"errorCode,omitempty"`
+
+	// UpdatedAt date time the status changed
+	UpdatedAt time.Time `json:"updatedAt"`
+}